@@ -0,0 +1,164 @@
+package conv
+
+import (
+	"fmt"
+	. "reflect"
+)
+
+// A LossKind classifies a Loss.
+type LossKind string
+
+const (
+	LossNarrowing  LossKind = "narrowing"  // numeric conversion into a smaller or less precise kind
+	LossTruncation LossKind = "truncation" // sequence into a shorter array
+	LossNil        LossKind = "nil"        // nil pointer into a non-pointer, which becomes a zero value
+	LossIgnored    LossKind = "ignored"    // source field without a destination, or excluded by directive
+	LossDefaulted  LossKind = "defaulted"  // destination field without a source, left zero
+	LossUnknown    LossKind = "unknown"    // pair outside the deep rules, left to other Builders
+)
+
+// A Loss is a part of a conversion that may not carry all information across.
+// Path locates it inside the converted value, such as "Items[].N" for field N of the elements of field Items, or "Scores[key]" for the keys of map field Scores. It is empty for the converted value itself.
+type Loss struct {
+	Path   string
+	Kind   LossKind
+	Detail string
+}
+
+func (x Loss) String() string {
+	o := string(x.Kind)
+	if x.Detail != "" {
+		o += " (" + x.Detail + ")"
+	}
+	if x.Path == "" {
+		return o
+	}
+	return x.Path + ": " + o
+}
+
+// Analyze reports the losses of converting "src" into "dst" through a Mapper returned by NewDeepMapper for this StructMap, for review in code audits.
+// The analysis is static, following types rather than values, and takes field directives into account. Extra Builders passed to NewDeepMapper are assumed not to override the standard rules; pairs that the standard rules don't cover are reported as LossUnknown.
+func (x *StructMap) Analyze(dst, src Type) []Loss {
+	return x.analyze("", dst, src, make(map[[2]Type]bool))
+}
+
+// analyze reports the losses at "path". "active" holds the struct pairs currently being analyzed, guarding against recursive types.
+func (x *StructMap) analyze(path string, tDst, tSrc Type, active map[[2]Type]bool) []Loss {
+	at := func(k LossKind, detail string) []Loss {
+		return []Loss{{
+			Path:   path,
+			Kind:   k,
+			Detail: detail,
+		}}
+	}
+
+	if _, ok := assignFunc(tDst, tSrc); ok {
+		if narrowing(tDst, tSrc) {
+			return at(LossNarrowing, tSrc.String()+" to "+tDst.String())
+		}
+		return nil
+	}
+
+	kDst, kSrc := tDst.Kind(), tSrc.Kind()
+	switch {
+	case kSrc == Pointer && kDst == Pointer:
+		return x.analyze(path, tDst.Elem(), tSrc.Elem(), active)
+	case kSrc == Pointer:
+		return append(at(LossNil, ""), x.analyze(path, tDst, tSrc.Elem(), active)...)
+	case kDst == Pointer:
+		return x.analyze(path, tDst.Elem(), tSrc, active)
+	case (kDst == Slice || kDst == Array) && (kSrc == Slice || kSrc == Array):
+		var o []Loss
+		if kDst == Array && (kSrc == Slice || tSrc.Len() > tDst.Len()) {
+			o = at(LossTruncation, fmt.Sprintf("limited to %d elements", tDst.Len()))
+		}
+		return append(o, x.analyze(path+"[]", tDst.Elem(), tSrc.Elem(), active)...)
+	case kDst == Map && kSrc == Map:
+		o := x.analyze(path+"[key]", tDst.Key(), tSrc.Key(), active)
+		return append(o, x.analyze(path+"[]", tDst.Elem(), tSrc.Elem(), active)...)
+	case kDst == Struct && kSrc == Struct:
+		return x.analyzeStruct(path, tDst, tSrc, active)
+	}
+	return at(LossUnknown, tSrc.String()+" to "+tDst.String())
+}
+
+func (x *StructMap) analyzeStruct(path string, tDst, tSrc Type, active map[[2]Type]bool) []Loss {
+	key := [2]Type{tDst, tSrc}
+	if active[key] {
+		return nil
+	}
+	active[key] = true
+	defer delete(active, key)
+
+	field := func(name string) string {
+		if path == "" {
+			return name
+		}
+		return path + "." + name
+	}
+
+	var o []Loss
+	matched := make(map[string]bool)
+	byName := fieldsByName(tSrc)
+	for _, df := range VisibleFields(tDst) {
+		if !df.IsExported() || (df.Anonymous && df.Type.Kind() == Struct) || throughPointer(tDst, df.Index) {
+			continue
+		}
+		name := field(df.Name)
+		sf, ok := byName[fieldName(df)]
+		if !ok {
+			o = append(o, Loss{Path: name, Kind: LossDefaulted, Detail: "no source field"})
+			continue
+		}
+		matched[sf.Name] = true
+
+		d := x.directive(tDst, df) | x.directive(tSrc, sf)
+		switch {
+		case d&fieldIgnore != 0:
+			o = append(o, Loss{Path: name, Kind: LossIgnored, Detail: "excluded by directive"})
+		case df.Type == sf.Type && (x.CopyIdentical || d&fieldCopy != 0):
+		default:
+			o = append(o, x.analyze(name, df.Type, sf.Type, active)...)
+		}
+	}
+
+	for _, sf := range VisibleFields(tSrc) {
+		if !sf.IsExported() || (sf.Anonymous && sf.Type.Kind() == Struct) || matched[sf.Name] {
+			continue
+		}
+		o = append(o, Loss{Path: field(sf.Name), Kind: LossIgnored, Detail: "no destination field"})
+	}
+	return o
+}
+
+// narrowing returns true if converting numeric type "tSrc" into "tDst" may lose information.
+func narrowing(tDst, tSrc Type) bool {
+	kDst, kSrc := tDst.Kind(), tSrc.Kind()
+	floatDst, floatSrc := kDst == Float32 || kDst == Float64, kSrc == Float32 || kSrc == Float64
+	switch {
+	case isInteger(kSrc) && isInteger(kDst):
+		uDst, uSrc := kDst >= Uint, kSrc >= Uint
+		switch {
+		case uDst == uSrc:
+			return tDst.Bits() < tSrc.Bits()
+		case uSrc:
+			return tDst.Bits() <= tSrc.Bits()
+		}
+		return true
+	case isInteger(kSrc) && floatDst:
+		bits := tSrc.Bits()
+		if kSrc < Uint {
+			bits--
+		}
+		mantissa := 53
+		if kDst == Float32 {
+			mantissa = 24
+		}
+		return bits > mantissa
+	case floatSrc && isInteger(kDst):
+		return true
+	case floatSrc && floatDst, (kSrc == Complex64 || kSrc == Complex128) && (kDst == Complex64 || kDst == Complex128):
+		return tDst.Bits() < tSrc.Bits()
+	}
+	return false
+}
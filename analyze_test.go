@@ -0,0 +1,72 @@
+package conv
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAnalyze(t *testing.T) {
+	type item struct {
+		N int64
+	}
+	type itemOut struct {
+		N int32
+	}
+	type src struct {
+		ID     int32
+		Score  float64
+		Ptr    *int
+		Items  []item
+		Codes  []byte
+		Tags   map[string]int
+		When   time.Time
+		Secret string
+		Next   *src
+	}
+	type dst struct {
+		ID     int64
+		Score  float32
+		Ptr    int
+		Items  []*itemOut
+		Codes  [4]byte
+		Tags   map[string]float64
+		When   string
+		Secret string `conv:"-"`
+		Extra  bool
+		Next   *dst
+	}
+
+	sm := &StructMap{}
+	var got []string
+	for _, l := range sm.Analyze(TypeEval[dst](), TypeEval[src]()) {
+		got = append(got, l.String())
+	}
+	want := []string{
+		"Score: narrowing (float64 to float32)",
+		"Ptr: nil",
+		"Items[].N: narrowing (int64 to int32)",
+		"Codes: truncation (limited to 4 elements)",
+		"Tags[]: narrowing (int to float64)",
+		"When: unknown (time.Time to string)",
+		"Secret: ignored (excluded by directive)",
+		"Extra: defaulted (no source field)",
+	}
+	if len(got) != len(want) {
+		t.Fatalf("wrong losses:\n%q", got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("loss %d: expected %q, got %q", i, want[i], got[i])
+		}
+	}
+
+	if l := sm.Analyze(TypeEval[src](), TypeEval[dst]()); len(l) == 0 || l[len(l)-1] != (Loss{"Extra", LossIgnored, "no destination field"}) {
+		t.Error("expected ignored Extra field", l)
+	}
+	if l := sm.Analyze(TypeEval[int8](), TypeEval[uint8]()); len(l) != 1 || l[0].Kind != LossNarrowing {
+		t.Error("expected narrowing", l)
+	}
+	if l := sm.Analyze(TypeEval[float64](), TypeEval[int32]()); l != nil {
+		t.Error("expected lossless", l)
+	}
+}
@@ -0,0 +1,19 @@
+//go:build goexperiment.arenas
+
+package conv
+
+import (
+	"arena"
+	. "reflect"
+)
+
+// MapArena is the same as Map, but allocates the new values of the conversion from arena "a", wherever its Mappings allocate through State.New, as the pointer Mappings of Deep do.
+// Large transient outputs, such as decoded requests, can then be freed wholesale with the arena, without garbage collector pressure. The results must not be used after the arena is freed.
+// Only available when built with GOEXPERIMENT=arenas.
+func (x *Mapper) MapArena(a *arena.Arena, dst, src any) error {
+	return x.mapState(dst, src, &State{
+		alloc: func(t Type) Value {
+			return ArenaNew(a, t)
+		},
+	})
+}
@@ -0,0 +1,37 @@
+//go:build goexperiment.arenas
+
+package conv
+
+import (
+	"arena"
+	"testing"
+)
+
+func TestMapArena(t *testing.T) {
+	type node struct {
+		N    int
+		Next *node
+	}
+	type src struct {
+		Head *node
+	}
+	type dst struct {
+		Head *struct {
+			N    int64
+			Next *node
+		}
+	}
+
+	a := arena.NewArena()
+	defer a.Free()
+
+	m := NewDeepMapper(nil)
+	var o dst
+	s := src{&node{1, &node{2, nil}}}
+	if err := m.MapArena(a, &o, s); err != nil {
+		t.Fatal(err)
+	}
+	if o.Head == nil || o.Head.N != 1 || o.Head.Next == nil || o.Head.Next.N != 2 {
+		t.Error("wrong result", o)
+	}
+}
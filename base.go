@@ -0,0 +1,382 @@
+package conv
+
+import (
+	"errors"
+	"fmt"
+	"go/token"
+	"hash/fnv"
+	. "reflect"
+	"strconv"
+	"strings"
+)
+
+var ErrBase = errors.New("invalid or unconstructible base")
+
+// Base returns a deterministic structural description of "t", using Go type syntax with all type names replaced by their underlying types.
+// Types that share a base have the same memory layout and field names, and interfaces the same method sets, regardless of how and where they were declared.
+// Methods declared on named types are not part of the base.
+// Struct tags are not part of the base.
+//
+// Recursive types are described using back references of the form "@N", where N is the depth of the referenced type, 0 being the root.
+// For example, the base of
+//
+//	type Node struct {
+//		Next *Node
+//	}
+//
+// is "struct{Next *@0}".
+func Base(t Type) string {
+	var b baseBuilder
+	b.write(t)
+	return b.String()
+}
+
+// describeType returns the description of "t" used by errors: its Go syntax, followed by its base if that differs, so that failures involving anonymous, generated or equally named types can be told apart from logs.
+func describeType(t Type) string {
+	s, b := t.String(), Base(t)
+	if s == b {
+		return s
+	}
+	return s + " (" + b + ")"
+}
+
+// Fingerprint returns the 64-bit FNV-1a hash of t's Base.
+func Fingerprint(t Type) uint64 {
+	return fingerprint(Base(t))
+}
+
+func fingerprint(base string) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(base))
+	return h.Sum64()
+}
+
+type baseBuilder struct {
+	strings.Builder
+	stack []Type // composite types currently being written
+}
+
+func (x *baseBuilder) write(t Type) {
+	k := t.Kind()
+	switch k {
+	case Array, Chan, Func, Interface, Map, Pointer, Slice, Struct:
+	default:
+		if k == UnsafePointer {
+			x.WriteString("unsafe.Pointer")
+		} else {
+			x.WriteString(k.String())
+		}
+		return
+	}
+
+	for i, st := range x.stack {
+		if st == t {
+			x.WriteByte('@')
+			x.WriteString(strconv.Itoa(i))
+			return
+		}
+	}
+	x.stack = append(x.stack, t)
+	defer func() {
+		x.stack = x.stack[:len(x.stack)-1]
+	}()
+
+	switch k {
+	case Array:
+		x.WriteByte('[')
+		x.WriteString(strconv.Itoa(t.Len()))
+		x.WriteByte(']')
+		x.write(t.Elem())
+	case Chan:
+		switch t.ChanDir() {
+		case RecvDir:
+			x.WriteString("<-chan ")
+		case SendDir:
+			x.WriteString("chan<- ")
+		default:
+			x.WriteString("chan ")
+			if t.Elem().Kind() == Chan && t.Elem().ChanDir() == RecvDir {
+				// avoid ambiguity with chan<-
+				x.WriteByte('(')
+				x.write(t.Elem())
+				x.WriteByte(')')
+				return
+			}
+		}
+		x.write(t.Elem())
+	case Func:
+		x.WriteString("func")
+		x.writeSignature(t)
+	case Interface:
+		x.WriteString("interface{")
+		for i, n := 0, t.NumMethod(); i < n; i++ {
+			if i > 0 {
+				x.WriteByte(';')
+			}
+			m := t.Method(i)
+			x.WriteString(m.Name)
+			x.writeSignature(m.Type)
+		}
+		x.WriteByte('}')
+	case Map:
+		x.WriteString("map[")
+		x.write(t.Key())
+		x.WriteByte(']')
+		x.write(t.Elem())
+	case Pointer:
+		x.WriteByte('*')
+		x.write(t.Elem())
+	case Slice:
+		x.WriteString("[]")
+		x.write(t.Elem())
+	case Struct:
+		x.WriteString("struct{")
+		for i, n := 0, t.NumField(); i < n; i++ {
+			if i > 0 {
+				x.WriteByte(';')
+			}
+			f := t.Field(i)
+			x.WriteString(f.Name)
+			x.WriteByte(' ')
+			x.write(f.Type)
+		}
+		x.WriteByte('}')
+	}
+}
+
+// writeSignature writes the parameter and result lists of func type "t".
+func (x *baseBuilder) writeSignature(t Type) {
+	x.WriteByte('(')
+	for i, n := 0, t.NumIn(); i < n; i++ {
+		if i > 0 {
+			x.WriteByte(',')
+		}
+		if i == n-1 && t.IsVariadic() {
+			x.WriteString("...")
+			x.write(t.In(i).Elem())
+		} else {
+			x.write(t.In(i))
+		}
+	}
+	x.WriteString(")(")
+	for i, n := 0, t.NumOut(); i < n; i++ {
+		if i > 0 {
+			x.WriteByte(',')
+		}
+		x.write(t.Out(i))
+	}
+	x.WriteByte(')')
+}
+
+var baseBasic = map[string]Type{
+	"bool":           TypeEval[bool](),
+	"int":            TypeEval[int](),
+	"int8":           TypeEval[int8](),
+	"int16":          TypeEval[int16](),
+	"int32":          TypeEval[int32](),
+	"int64":          TypeEval[int64](),
+	"uint":           TypeEval[uint](),
+	"uint8":          TypeEval[uint8](),
+	"uint16":         TypeEval[uint16](),
+	"uint32":         TypeEval[uint32](),
+	"uint64":         TypeEval[uint64](),
+	"uintptr":        TypeEval[uintptr](),
+	"float32":        TypeEval[float32](),
+	"float64":        TypeEval[float64](),
+	"complex64":      TypeEval[complex64](),
+	"complex128":     TypeEval[complex128](),
+	"string":         TypeEval[string](),
+	"unsafe.Pointer": TypeOf(Value.UnsafePointer).Out(0), // without importing unsafe
+}
+
+// asType constructs an unnamed type from its base description.
+// Recursive types and interfaces with methods cannot be constructed through reflection, and return ErrBase.
+// Unexported struct fields are attributed to this package.
+// Array lengths over "limit", if positive, fail with ErrTooLarge, as do bases nested deeper than maxBaseDepth.
+func asType(base string, limit int) (t Type, err error) {
+	defer func() {
+		// reflect constructors panic on invalid input, such as duplicate field names
+		if recover() != nil {
+			t, err = nil, ErrBase
+		}
+	}()
+
+	p := baseParser{s: base, limit: limit}
+	t, err = p.parse()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos != len(p.s) {
+		return nil, ErrBase
+	}
+	return t, nil
+}
+
+// maxBaseDepth caps the nesting of constructed bases, so that parsing them is bounded.
+const maxBaseDepth = 64
+
+type baseParser struct {
+	s     string
+	pos   int
+	limit int // array length limit, if positive
+	depth int
+}
+
+func (x *baseParser) consume(prefix string) bool {
+	if strings.HasPrefix(x.s[x.pos:], prefix) {
+		x.pos += len(prefix)
+		return true
+	}
+	return false
+}
+
+// until returns the text up to the first of the "stop" bytes, excluding it.
+func (x *baseParser) until(stop string) string {
+	i := strings.IndexAny(x.s[x.pos:], stop)
+	if i < 0 {
+		i = len(x.s) - x.pos
+	}
+	o := x.s[x.pos : x.pos+i]
+	x.pos += i
+	return o
+}
+
+func (x *baseParser) parse() (Type, error) {
+	if x.depth++; x.depth > maxBaseDepth {
+		return nil, fmt.Errorf("base nested over %d levels: %w", maxBaseDepth, ErrTooLarge)
+	}
+	defer func() { x.depth-- }()
+
+	switch {
+	case x.consume("[]"):
+		elem, err := x.parse()
+		if err != nil {
+			return nil, err
+		}
+		return SliceOf(elem), nil
+	case x.consume("["):
+		n, err := strconv.Atoi(x.until("]"))
+		if err != nil || n < 0 || !x.consume("]") {
+			return nil, ErrBase
+		}
+		if err := checkLen(uint64(n), x.limit); err != nil {
+			return nil, err
+		}
+		elem, err := x.parse()
+		if err != nil {
+			return nil, err
+		}
+		return ArrayOf(n, elem), nil
+	case x.consume("*"):
+		elem, err := x.parse()
+		if err != nil {
+			return nil, err
+		}
+		return PointerTo(elem), nil
+	case x.consume("map["):
+		key, err := x.parse()
+		if err != nil || !x.consume("]") {
+			return nil, ErrBase
+		}
+		elem, err := x.parse()
+		if err != nil {
+			return nil, err
+		}
+		return MapOf(key, elem), nil
+	case x.consume("<-chan "):
+		return x.parseChan(RecvDir)
+	case x.consume("chan<- "):
+		return x.parseChan(SendDir)
+	case x.consume("chan "):
+		if x.consume("(") {
+			elem, err := x.parse()
+			if err != nil || !x.consume(")") {
+				return nil, ErrBase
+			}
+			return ChanOf(BothDir, elem), nil
+		}
+		return x.parseChan(BothDir)
+	case x.consume("func"):
+		in, variadic, err := x.parseList()
+		if err != nil {
+			return nil, err
+		}
+		out, _, err := x.parseList()
+		if err != nil {
+			return nil, err
+		}
+		return FuncOf(in, out, variadic), nil
+	case x.consume("interface{}"):
+		return TypeEval[any](), nil
+	case x.consume("struct{"):
+		return x.parseStruct()
+	}
+
+	name := x.until("[]*(){};,) ")
+	if t, ok := baseBasic[name]; ok {
+		return t, nil
+	}
+	return nil, ErrBase
+}
+
+func (x *baseParser) parseChan(dir ChanDir) (Type, error) {
+	elem, err := x.parse()
+	if err != nil {
+		return nil, err
+	}
+	return ChanOf(dir, elem), nil
+}
+
+// parseList parses a parenthesized, comma separated type list.
+func (x *baseParser) parseList() ([]Type, bool, error) {
+	if !x.consume("(") {
+		return nil, false, ErrBase
+	}
+	var (
+		o        []Type
+		variadic bool
+	)
+	for !x.consume(")") {
+		if len(o) > 0 && !x.consume(",") {
+			return nil, false, ErrBase
+		}
+		variadic = x.consume("...")
+		t, err := x.parse()
+		if err != nil {
+			return nil, false, err
+		}
+		if variadic {
+			t = SliceOf(t)
+		}
+		o = append(o, t)
+	}
+	return o, variadic, nil
+}
+
+func (x *baseParser) parseStruct() (Type, error) {
+	var fields []StructField
+	for !x.consume("}") {
+		if len(fields) > 0 && !x.consume(";") {
+			return nil, ErrBase
+		}
+		name := x.until(" ")
+		if name == "" || !x.consume(" ") {
+			return nil, ErrBase
+		}
+		t, err := x.parse()
+		if err != nil {
+			return nil, err
+		}
+		f := StructField{
+			Name: name,
+			Type: t,
+		}
+		if !token.IsExported(name) {
+			f.PkgPath = basePkgPath
+		}
+		fields = append(fields, f)
+	}
+	return StructOf(fields), nil
+}
+
+var basePkgPath = TypeEval[baseParser]().PkgPath()
@@ -0,0 +1,77 @@
+package conv
+
+import (
+	"errors"
+	. "reflect"
+	"strings"
+	"testing"
+)
+
+func TestBase(t *testing.T) {
+	type node struct {
+		Val  int
+		Next *node
+	}
+	type myInt int
+	type fields struct {
+		A myInt
+		B []string
+		C map[string]<-chan float64
+		D func(int, ...bool) error
+		E chan (<-chan int)
+		F [2]*struct{ X uint8 }
+	}
+
+	cases := []struct {
+		t   Type
+		exp string
+	}{
+		{TypeEval[myInt](), "int"},
+		{TypeEval[node](), "struct{Val int;Next *@0}"},
+		{TypeEval[fields](), "struct{A int;B []string;C map[string]<-chan float64;D func(int,...bool)(interface{Error()(string)});E chan (<-chan int);F [2]*struct{X uint8}}"},
+	}
+	for _, c := range cases {
+		if s := Base(c.t); s != c.exp {
+			t.Errorf("%v: expected %s, got %s", c.t, c.exp, s)
+		}
+	}
+
+	type other struct {
+		Val  myInt `tag:"ignored"`
+		Next *other
+	}
+	if Fingerprint(TypeEval[node]()) != Fingerprint(TypeEval[other]()) {
+		t.Error("structurally identical types should share fingerprints")
+	}
+}
+
+func TestBaseErrors(t *testing.T) {
+	type id int
+	if s := describeType(TypeEval[string]()); s != "string" {
+		t.Error("wrong plain description", s)
+	}
+	if s := describeType(TypeEval[[]id]()); s != "[]conv.id ([]int)" {
+		t.Error("wrong named description", s)
+	}
+
+	gen := StructOf([]StructField{{Name: "A", Type: TypeEval[id](), Tag: `json:"a"`}})
+	c := NewConversion(StrconvConverter)
+	_, err := c.Call(New(gen).Elem().Interface())
+	if !errors.Is(err, ErrInvalid) || !strings.Contains(err.Error(), "(struct{A int}) into string") {
+		t.Error("wrong conversion error", err)
+	}
+
+	inv := NewInversion(StrconvInverter)
+	if _, err := inv.Invert(gen, ""); !errors.Is(err, ErrInvalid) || !strings.HasPrefix(err.Error(), "string into struct { A conv.id") {
+		t.Error("wrong inversion error", err)
+	}
+	if _, err := As[[]id](inv, ""); !errors.Is(err, ErrInvalid) || !strings.Contains(err.Error(), "([]int)") {
+		t.Error("wrong As error", err)
+	}
+
+	m := NewMapper(AssignMapping)
+	var o []string
+	if err := m.Map(&o, []id{}); !errors.Is(err, ErrInvalid) || err.Error() != "[]conv.id ([]int) into []string: invalid conversion" {
+		t.Error("wrong mapping error", err)
+	}
+}
@@ -0,0 +1,70 @@
+package conv
+
+import (
+	"encoding"
+	. "reflect"
+)
+
+var (
+	typeBinaryMarshaler   = TypeEval[encoding.BinaryMarshaler]()
+	typeBinaryUnmarshaler = TypeEval[encoding.BinaryUnmarshaler]()
+)
+
+// BinaryConverter is a Builder of []byte Converters for types that implement encoding.BinaryMarshaler, either directly or through their pointer.
+func BinaryConverter(t Type) (Converter[[]byte], bool) {
+	if !implements(t, typeBinaryMarshaler) {
+		return nil, false
+	}
+	return func(v Value) ([]byte, error) {
+		return methods[encoding.BinaryMarshaler](v).MarshalBinary()
+	}, true
+}
+
+// BinaryInverter is a Builder of []byte Inverters for types that implement encoding.BinaryUnmarshaler through their pointer.
+// Pointer destinations are also accepted, if their element type qualifies.
+func BinaryInverter(t Type) (Inverter[[]byte], bool) {
+	tElem, ok := unmarshalTarget(t, typeBinaryUnmarshaler)
+	if !ok {
+		return nil, false
+	}
+	return func(v []byte) (Value, error) {
+		o := New(tElem)
+		if err := o.Interface().(encoding.BinaryUnmarshaler).UnmarshalBinary(v); err != nil {
+			return Value{}, err
+		}
+		if tElem == t {
+			return o.Elem(), nil
+		}
+		return o, nil
+	}, true
+}
+
+// BinaryMapping is a Builder of Mappings from encoding.BinaryMarshaler types to byte slices, and from byte slices to encoding.BinaryUnmarshaler types.
+func BinaryMapping(t Type) (Mapping, bool) {
+	tDst, tSrc := t.Out(0), t.In(0)
+	switch {
+	case isBytes(tDst) && implements(tSrc, typeBinaryMarshaler):
+		return func(dst, src Value, s *State) error {
+			if tSrc.Kind() == Pointer && src.IsNil() {
+				dst.SetZero()
+				return nil
+			}
+			b, err := methods[encoding.BinaryMarshaler](src).MarshalBinary()
+			if err != nil {
+				return err
+			}
+			dst.SetBytes(b)
+			return nil
+		}, true
+	case isBytes(tSrc) && PointerTo(tDst).Implements(typeBinaryUnmarshaler):
+		return func(dst, src Value, s *State) error {
+			return dst.Addr().Interface().(encoding.BinaryUnmarshaler).UnmarshalBinary(src.Bytes())
+		}, true
+	}
+	return nil, false
+}
+
+// isBytes returns true if "t" is a slice of a byte kind.
+func isBytes(t Type) bool {
+	return t.Kind() == Slice && t.Elem().Kind() == Uint8
+}
@@ -0,0 +1,41 @@
+package conv
+
+import (
+	"errors"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestBinary(t *testing.T) {
+	tm := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	c := NewConversion(BinaryConverter)
+	b, err := c.Call(tm)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	inv := NewInversion(BinaryInverter)
+	if o, err := As[time.Time](inv, b); err != nil || !o.Equal(tm) {
+		t.Error("time inversion failed", o, err)
+	}
+	if o, err := As[*time.Time](inv, b); err != nil || !o.Equal(tm) {
+		t.Error("pointer inversion failed", o, err)
+	}
+	if _, err := c.Call(url.Values{}); !errors.Is(err, ErrInvalid) {
+		t.Error("non-marshaler should not build")
+	}
+
+	type src struct {
+		At []byte
+	}
+	type dst struct {
+		At time.Time
+	}
+	m := NewDeepMapper(nil, BinaryMapping)
+	var o dst
+	if err := m.Map(&o, src{b}); err != nil || !o.At.Equal(tm) {
+		t.Error("mapping failed", o, err)
+	}
+}
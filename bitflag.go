@@ -0,0 +1,212 @@
+package conv
+
+import (
+	"fmt"
+	. "reflect"
+	"strings"
+	"sync"
+)
+
+// Bitflags is a registry of the names of the bits of bitmask types, such as permission masks, which are integer kinds whose values combine single bit constants.
+// It builds Mappings between registered bitmask types and either string slices, with one name per set bit, or strings, with the names joined by Separator. Converters and Inverters are built for the string form.
+// Names are listed in ascending bit order, and matched exactly, with surrounding spaces ignored when parsing strings.
+//
+// Set bits without a name, and unknown names, fail with ErrInvalid, unless IgnoreUnknown is set, in which case they are dropped.
+// The bits of a type should all be registered before it is first converted, since its functions are built from the names known at that time; the same goes for Separator and IgnoreUnknown.
+type Bitflags struct {
+	Separator     string // defaults to ","
+	IgnoreUnknown bool
+
+	types map[Type]*bitflagTable
+	mux   sync.RWMutex
+}
+
+type bitflagTable struct {
+	bits   [64]string // names by bit index
+	values map[string]uint64
+}
+
+// RegisterBitflags registers the names of the bits of bitmask type F. Registering a type again adds to its names.
+// Panics if a value is not a single bit, or if a name or bit is registered twice, as that is a programming error.
+func RegisterBitflags[F EnumInt](x *Bitflags, names map[F]string) {
+	t := TypeEval[F]()
+
+	x.mux.Lock()
+	defer x.mux.Unlock()
+
+	if x.types == nil {
+		x.types = make(map[Type]*bitflagTable)
+	}
+	e := x.types[t]
+	if e == nil {
+		e = &bitflagTable{values: make(map[string]uint64)}
+		x.types[t] = e
+	}
+	for v, name := range names {
+		n := bitmask(ValueOf(v))
+		if n == 0 || n&(n-1) != 0 {
+			panic(fmt.Sprintf("conv: bitflag %v value %v is not a single bit", t, v))
+		}
+		i := bitIndex(n)
+		if _, ok := e.values[name]; ok || e.bits[i] != "" {
+			panic(fmt.Sprintf("conv: bitflag %v %q registered twice", t, name))
+		}
+		e.bits[i] = name
+		e.values[name] = n
+	}
+}
+
+// bitmask returns the bits of integer "v", within the size of its type.
+func bitmask(v Value) uint64 {
+	n := uint64(enumBits(v))
+	if v.CanInt() {
+		// sign extension would set bits past the size of the type
+		n &= 1<<v.Type().Bits() - 1
+	}
+	return n
+}
+
+func bitIndex(n uint64) int {
+	i := 0
+	for n > 1 {
+		n >>= 1
+		i++
+	}
+	return i
+}
+
+func (x *Bitflags) table(t Type) (*bitflagTable, bool) {
+	x.mux.RLock()
+	defer x.mux.RUnlock()
+	e, ok := x.types[t]
+	return e, ok
+}
+
+func (x *Bitflags) separator() string {
+	if x.Separator == "" {
+		return ","
+	}
+	return x.Separator
+}
+
+// Build is a Builder of Mappings between registered bitmask types and slices of string kinds or string kinds, in both directions.
+func (x *Bitflags) Build(t Type) (Mapping, bool) {
+	tDst, tSrc := t.Out(0), t.In(0)
+	if e, ok := x.table(tSrc); ok {
+		switch {
+		case tDst.Kind() == String:
+			return func(dst, src Value, s *State) error {
+				names, err := x.names(e, src)
+				if err != nil {
+					return err
+				}
+				dst.SetString(strings.Join(names, x.separator()))
+				return nil
+			}, true
+		case tDst.Kind() == Slice && tDst.Elem().Kind() == String:
+			return func(dst, src Value, s *State) error {
+				names, err := x.names(e, src)
+				if err != nil {
+					return err
+				}
+				o := MakeSlice(tDst, len(names), len(names))
+				for i, name := range names {
+					o.Index(i).SetString(name)
+				}
+				dst.Set(o)
+				return nil
+			}, true
+		}
+	}
+	if e, ok := x.table(tDst); ok {
+		switch {
+		case tSrc.Kind() == String:
+			return func(dst, src Value, s *State) error {
+				return x.value(e, dst, x.split(src.String()))
+			}, true
+		case tSrc.Kind() == Slice && tSrc.Elem().Kind() == String:
+			return func(dst, src Value, s *State) error {
+				names := make([]string, src.Len())
+				for i := range names {
+					names[i] = src.Index(i).String()
+				}
+				return x.value(e, dst, names)
+			}, true
+		}
+	}
+	return nil, false
+}
+
+// Converter is a Builder of Converters from registered bitmask types to their joined names.
+func (x *Bitflags) Converter(t Type) (Converter[string], bool) {
+	e, ok := x.table(t)
+	if !ok {
+		return nil, false
+	}
+	return func(v Value) (string, error) {
+		names, err := x.names(e, v)
+		return strings.Join(names, x.separator()), err
+	}, true
+}
+
+// Inverter is a Builder of Inverters from joined names to registered bitmask types.
+func (x *Bitflags) Inverter(t Type) (Inverter[string], bool) {
+	e, ok := x.table(t)
+	if !ok {
+		return nil, false
+	}
+	return func(s string) (Value, error) {
+		o := New(t).Elem()
+		if err := x.value(e, o, x.split(s)); err != nil {
+			return Value{}, err
+		}
+		return o, nil
+	}, true
+}
+
+// split returns the names of joined string "s", trimmed, without empty ones.
+func (x *Bitflags) split(s string) []string {
+	var o []string
+	for _, name := range strings.Split(s, x.separator()) {
+		if name = strings.TrimSpace(name); name != "" {
+			o = append(o, name)
+		}
+	}
+	return o
+}
+
+// names returns the names of the set bits of bitmask "v".
+func (x *Bitflags) names(e *bitflagTable, v Value) ([]string, error) {
+	n := bitmask(v)
+	var o []string
+	for i := 0; n != 0; i, n = i+1, n>>1 {
+		if n&1 == 0 {
+			continue
+		}
+		if e.bits[i] != "" {
+			o = append(o, e.bits[i])
+		} else if !x.IgnoreUnknown {
+			return nil, fmt.Errorf("%s bit %d: %w", describeType(v.Type()), i, ErrInvalid)
+		}
+	}
+	return o, nil
+}
+
+// value sets settable bitmask "dst" to the union of the bits of "names".
+func (x *Bitflags) value(e *bitflagTable, dst Value, names []string) error {
+	var n uint64
+	for _, name := range names {
+		b, ok := e.values[name]
+		if !ok && !x.IgnoreUnknown {
+			return fmt.Errorf("%s name %q: %w", describeType(dst.Type()), name, ErrInvalid)
+		}
+		n |= b
+	}
+	if dst.CanInt() {
+		// the top bit of the type is its sign bit
+		dst.SetInt(int64(n<<(64-dst.Type().Bits())) >> (64 - dst.Type().Bits()))
+	} else {
+		dst.SetUint(n)
+	}
+	return nil
+}
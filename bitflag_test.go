@@ -0,0 +1,84 @@
+package conv
+
+import (
+	"errors"
+	"testing"
+)
+
+type bitflagPerm uint8
+
+const (
+	permRead bitflagPerm = 1 << iota
+	permWrite
+	permExec
+)
+
+func TestBitflags(t *testing.T) {
+	f := &Bitflags{}
+	RegisterBitflags(f, map[bitflagPerm]string{permRead: "read", permWrite: "write", permExec: "exec"})
+
+	c := NewConversion(f.Converter)
+	if o, err := c.Call(permExec | permRead); err != nil || o != "read,exec" {
+		t.Error("wrong names", o, err)
+	}
+	if o, err := c.Call(bitflagPerm(0)); err != nil || o != "" {
+		t.Error("empty mask should have no names", o, err)
+	}
+	if _, err := c.Call(bitflagPerm(1 << 5)); !errors.Is(err, ErrInvalid) {
+		t.Error("unknown bit should fail", err)
+	}
+
+	inv := NewInversion(f.Inverter)
+	if o, err := As[bitflagPerm](inv, " write , read,"); err != nil || o != permRead|permWrite {
+		t.Error("wrong mask", o, err)
+	}
+	if _, err := As[bitflagPerm](inv, "read,admin"); !errors.Is(err, ErrInvalid) {
+		t.Error("unknown name should fail", err)
+	}
+
+	type user struct {
+		Perm bitflagPerm
+	}
+	type row struct {
+		Perm []string
+	}
+	m := NewDeepMapper(nil, f.Build)
+	var r row
+	if err := m.Map(&r, user{permWrite | permExec}); err != nil || len(r.Perm) != 2 || r.Perm[1] != "exec" {
+		t.Error("wrong row", r, err)
+	}
+	var u user
+	if err := m.Map(&u, row{[]string{"exec"}}); err != nil || u.Perm != permExec {
+		t.Error("wrong user", u, err)
+	}
+
+	f.IgnoreUnknown = true
+	f.Separator = "|"
+	if o, err := c.Call(bitflagPerm(1<<5) | permRead); err != nil || o != "read" {
+		t.Error("unknown bit should be dropped", o, err)
+	}
+	if o, err := As[bitflagPerm](inv, "read|admin|write"); err != nil || o != permRead|permWrite {
+		t.Error("unknown name should be dropped", o, err)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("multiple bit value should panic")
+		}
+	}()
+	RegisterBitflags(f, map[bitflagPerm]string{3: "rw"})
+}
+
+func TestBitflagsSigned(t *testing.T) {
+	type mask int8
+	f := &Bitflags{}
+	RegisterBitflags(f, map[mask]string{1: "low", -128: "high"})
+	c := NewConversion(f.Converter)
+	inv := NewInversion(f.Inverter)
+	if o, err := c.Call(mask(-127)); err != nil || o != "low,high" {
+		t.Error("wrong names", o, err)
+	}
+	if o, err := As[mask](inv, "high,low"); err != nil || o != -127 {
+		t.Error("wrong mask", o, err)
+	}
+}
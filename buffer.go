@@ -0,0 +1,272 @@
+package conv
+
+import (
+	"fmt"
+	"math"
+	. "reflect"
+
+	"github.com/blitz-frost/conv/wrap"
+)
+
+// A Buffer is a column in the Arrow memory layout, holding fixed width values of a bool or numeric kind (other than uintptr and complex kinds).
+// Validity is a bitmap with one bit per row, least significant bit first, set for non-null values; it is nil if there are no nulls.
+// Values are contiguous and little endian, with the width of Kind on the host (int and uint are 8 bytes on 64 bit platforms). Bools are bit packed, like the validity bitmap.
+type Buffer struct {
+	Name     string // field name, or conv tag name, for struct elements
+	Kind     Kind
+	Len      int
+	Validity []byte
+	Values   []byte
+}
+
+// Valid returns true if row "i" is not null.
+func (x Buffer) Valid(i int) bool {
+	return x.Validity == nil || x.Validity[i/8]&(1<<(i%8)) != 0
+}
+
+// BufferConverter is a Builder of Buffer Converters for slices and arrays of:
+//
+//   - bool and numeric kinds, producing a single Buffer
+//   - pointers to them, with nil pointers as nulls
+//   - structs made of the above, producing one Buffer per field visited by wrap.StructIter
+//
+// Slices of plain numbers are copied directly on little endian hosts, without going through individual elements, unless built in safe mode.
+func BufferConverter(t Type) (Converter[[]Buffer], bool) {
+	cols, ok := bufferPlan(t)
+	if !ok {
+		return nil, false
+	}
+	return func(v Value) ([]Buffer, error) {
+		n := v.Len()
+		o := make([]Buffer, len(cols))
+		for i, c := range cols {
+			o[i] = Buffer{
+				Name: c.name,
+				Kind: c.typ.Kind(),
+				Len:  n,
+			}
+			if c.index == nil && !c.ptr && c.typ.Kind() != Bool && hostLittleEndian && v.Kind() == Slice {
+				o[i].Values = append([]byte{}, rawBytes(v, n*int(c.typ.Size()))...)
+				continue
+			}
+			c.encode(&o[i], v)
+		}
+		return o, nil
+	}, true
+}
+
+// BufferInverter is a Builder of Buffer Inverters for the types covered by BufferConverter.
+// Struct fields are matched to Buffers by name; fields without a Buffer are left zero. Nulls decode as nil pointers, or zero values.
+// Kinds must match exactly, and all Buffers must have the same length, which must also match array types.
+func BufferInverter(t Type) (Inverter[[]Buffer], bool) {
+	cols, ok := bufferPlan(t)
+	if !ok {
+		return nil, false
+	}
+	return func(bufs []Buffer) (Value, error) {
+		n := -1
+		byName := make(map[string]*Buffer, len(bufs))
+		for i := range bufs {
+			b := &bufs[i]
+			if n >= 0 && b.Len != n {
+				return Value{}, fmt.Errorf("buffer %q length %d, expected %d: %w", b.Name, b.Len, n, ErrInvalid)
+			}
+			n = b.Len
+			byName[b.Name] = b
+		}
+		if n < 0 {
+			n = 0
+		}
+
+		var o Value
+		if t.Kind() == Array {
+			if n != t.Len() {
+				return Value{}, fmt.Errorf("%d rows into %s: %w", n, describeType(t), ErrInvalid)
+			}
+			o = New(t).Elem()
+		} else {
+			o = MakeSlice(t, n, n)
+		}
+
+		for _, c := range cols {
+			b, ok := byName[c.name]
+			if !ok {
+				continue
+			}
+			if err := c.check(b); err != nil {
+				return Value{}, err
+			}
+			if c.index == nil && !c.ptr && c.typ.Kind() != Bool && hostLittleEndian && b.Validity == nil && t.Kind() == Slice {
+				copy(rawBytes(o, n*int(c.typ.Size())), b.Values)
+				continue
+			}
+			c.decode(o, b)
+		}
+		return o, nil
+	}, true
+}
+
+type bufferColumn struct {
+	name  string
+	index []int // field index, nil for plain elements
+	ptr   bool
+	typ   Type // value type, after pointer indirection
+}
+
+// bufferPlan returns the columns of slice or array type "t".
+func bufferPlan(t Type) ([]bufferColumn, bool) {
+	if t.Kind() != Slice && t.Kind() != Array {
+		return nil, false
+	}
+	e := t.Elem()
+	if c, ok := bufferValue(e); ok {
+		return []bufferColumn{c}, true
+	}
+	if e.Kind() != Struct {
+		return nil, false
+	}
+
+	var o []bufferColumn
+	for _, f := range wrap.Fields(e) {
+		if throughPointer(e, f.Index) {
+			continue
+		}
+		c, ok := bufferValue(f.Type)
+		if !ok {
+			return nil, false
+		}
+		c.name, _ = wrap.Tag(f)
+		c.index = f.Index
+		o = append(o, c)
+	}
+	return o, len(o) > 0
+}
+
+// bufferValue returns the column of values of type "t", if it is a supported kind or a pointer to one.
+func bufferValue(t Type) (bufferColumn, bool) {
+	o := bufferColumn{typ: t}
+	if t.Kind() == Pointer {
+		o.ptr, o.typ = true, t.Elem()
+	}
+	switch o.typ.Kind() {
+	case Bool, Int, Int8, Int16, Int32, Int64, Uint, Uint8, Uint16, Uint32, Uint64, Float32, Float64:
+		return o, true
+	}
+	return o, false
+}
+
+// value returns the column value of row "v", or false if null.
+func (x bufferColumn) value(v Value) (Value, bool) {
+	if x.index != nil {
+		v = v.FieldByIndex(x.index)
+	}
+	if x.ptr {
+		if v.IsNil() {
+			return v, false
+		}
+		v = v.Elem()
+	}
+	return v, true
+}
+
+func (x bufferColumn) encode(b *Buffer, v Value) {
+	n, size := v.Len(), int(x.typ.Size())
+	if x.typ.Kind() == Bool {
+		b.Values = make([]byte, (n+7)/8)
+	} else {
+		b.Values = make([]byte, n*size)
+	}
+
+	for i := 0; i < n; i++ {
+		e, ok := x.value(v.Index(i))
+		if !ok {
+			if b.Validity == nil {
+				b.Validity = make([]byte, (n+7)/8)
+				for j := 0; j < i; j++ {
+					b.Validity[j/8] |= 1 << (j % 8)
+				}
+			}
+			continue
+		}
+		if b.Validity != nil {
+			b.Validity[i/8] |= 1 << (i % 8)
+		}
+
+		var u uint64
+		switch k := x.typ.Kind(); {
+		case k == Bool:
+			if e.Bool() {
+				b.Values[i/8] |= 1 << (i % 8)
+			}
+			continue
+		case k == Float32:
+			u = uint64(math.Float32bits(float32(e.Float())))
+		case k == Float64:
+			u = math.Float64bits(e.Float())
+		case k >= Int && k <= Int64:
+			u = uint64(e.Int())
+		default:
+			u = e.Uint()
+		}
+		for j, p := 0, b.Values[i*size:]; j < size; j++ {
+			p[j] = byte(u >> (8 * j))
+		}
+	}
+}
+
+// check verifies that "b" can be decoded into the column.
+func (x bufferColumn) check(b *Buffer) error {
+	if b.Kind != x.typ.Kind() {
+		return fmt.Errorf("buffer %q kind %v into %s: %w", b.Name, b.Kind, describeType(x.typ), ErrInvalid)
+	}
+	size := (b.Len + 7) / 8
+	if b.Validity != nil && len(b.Validity) < size {
+		return fmt.Errorf("buffer %q validity too short: %w", b.Name, ErrInvalid)
+	}
+	if b.Kind != Bool {
+		size = b.Len * int(x.typ.Size())
+	}
+	if len(b.Values) < size {
+		return fmt.Errorf("buffer %q values too short: %w", b.Name, ErrInvalid)
+	}
+	return nil
+}
+
+func (x bufferColumn) decode(v Value, b *Buffer) {
+	size := int(x.typ.Size())
+	for i := 0; i < b.Len; i++ {
+		if !b.Valid(i) {
+			continue
+		}
+		e := v.Index(i)
+		if x.index != nil {
+			e = e.FieldByIndex(x.index)
+		}
+		if x.ptr {
+			e.Set(New(x.typ))
+			e = e.Elem()
+		}
+
+		k := x.typ.Kind()
+		if k == Bool {
+			e.SetBool(b.Values[i/8]&(1<<(i%8)) != 0)
+			continue
+		}
+		var u uint64
+		for j, p := 0, b.Values[i*size:]; j < size; j++ {
+			u |= uint64(p[j]) << (8 * j)
+		}
+		switch {
+		case k == Float32:
+			e.SetFloat(float64(math.Float32frombits(uint32(u))))
+		case k == Float64:
+			e.SetFloat(math.Float64frombits(u))
+		case k >= Int && k <= Int64:
+			// sign extend
+			shift := 64 - 8*size
+			e.SetInt(int64(u<<shift) >> shift)
+		default:
+			e.SetUint(u)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package conv
+
+import (
+	"bytes"
+	"errors"
+	. "reflect"
+	"testing"
+)
+
+func TestBuffer(t *testing.T) {
+	c := NewConversion(BufferConverter)
+	inv := NewInversion(BufferInverter)
+
+	// fast path
+	nums := []int16{1, -2, 300}
+	bufs, err := c.Call(nums)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(bufs) != 1 || bufs[0].Kind != Int16 || bufs[0].Validity != nil || !bytes.Equal(bufs[0].Values, []byte{1, 0, 0xfe, 0xff, 0x2c, 0x01}) {
+		t.Error("wrong buffer", bufs)
+	}
+	if o, err := As[[]int16](inv, bufs); err != nil || !DeepEqual(o, nums) {
+		t.Error("wrong numbers", o, err)
+	}
+
+	type row struct {
+		ID    uint32
+		Score *float64
+		OK    bool
+		Skip  string `conv:"-"`
+	}
+	f := 0.5
+	rows := [3]row{{1, &f, true, "x"}, {2, nil, false, ""}, {3, &f, true, ""}}
+	if bufs, err = c.Call(rows); err != nil {
+		t.Fatal(err)
+	}
+	if len(bufs) != 3 || bufs[1].Name != "Score" || !bytes.Equal(bufs[1].Validity, []byte{0b101}) || !bytes.Equal(bufs[2].Values, []byte{0b101}) {
+		t.Error("wrong buffers", bufs)
+	}
+	if bufs[1].Valid(1) || !bufs[1].Valid(2) {
+		t.Error("wrong validity")
+	}
+
+	o, err := As[[]row](inv, bufs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rows[0].Skip = ""
+	if !DeepEqual(o, rows[:]) {
+		t.Error("wrong rows", o)
+	}
+
+	bufs[0].Kind = Int32
+	if _, err := As[[]row](inv, bufs); !errors.Is(err, ErrInvalid) {
+		t.Error("expected kind mismatch, got", err)
+	}
+	if _, ok := BufferConverter(TypeEval[[]string]()); ok {
+		t.Error("strings accepted")
+	}
+}
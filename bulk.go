@@ -0,0 +1,105 @@
+package conv
+
+import (
+	"fmt"
+	. "reflect"
+	"runtime"
+	"sync"
+	"time"
+)
+
+// ConvertEach converts the elements of slice, array or map "src" through "c", passing each result to "fn", along with its index.
+// Map values are converted in iteration order, indexed by their position in it; their keys are not passed.
+// The element Converter is resolved once for the whole collection, rather than once per element, with the same results as Call. Elements of interface type are the exception, as their dynamic types may differ; they go through Call individually.
+// Fails with ErrUnsupportedKind if "src" is not a collection.
+func ConvertEach[T any](c *Conversion[T], src any, fn func(int, T, error)) error {
+	v := ValueOf(src)
+	switch v.Kind() {
+	case Slice, Array, Map:
+	default:
+		return fmt.Errorf("%T: %w", src, ErrUnsupportedKind)
+	}
+
+	conv := elemConverter(c, v.Type().Elem())
+	if v.Kind() == Map {
+		i := 0
+		for iter := v.MapRange(); iter.Next(); i++ {
+			o, err := conv(iter.Value())
+			fn(i, o, err)
+		}
+		return nil
+	}
+	for i, n := 0, v.Len(); i < n; i++ {
+		o, err := conv(v.Index(i))
+		fn(i, o, err)
+	}
+	return nil
+}
+
+// ConvertSliceParallel converts the elements of slice or array "src" through "c", splitting them across "workers" goroutines, or GOMAXPROCS if not positive.
+// The results keep the order of "src". Conversion stops at the first failing element of each worker's share; the returned error is that of the lowest failing index.
+// Meant for large collections, where the conversion work outweighs the goroutine overhead. Converters must be safe for concurrent use, as Library ones are.
+// Fails with ErrUnsupportedKind if "src" is not a slice or array.
+func ConvertSliceParallel[T any](c *Conversion[T], src any, workers int) ([]T, error) {
+	v := ValueOf(src)
+	if k := v.Kind(); k != Slice && k != Array {
+		return nil, fmt.Errorf("%T: %w", src, ErrUnsupportedKind)
+	}
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	n := v.Len()
+	if workers > n {
+		workers = n
+	}
+
+	conv := elemConverter(c, v.Type().Elem())
+	o := make([]T, n)
+	errs := make([]error, workers)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			// shares differ by at most one element
+			for i, end := n*w/workers, n*(w+1)/workers; i < end; i++ {
+				e, err := conv(v.Index(i))
+				if err != nil {
+					errs[w] = fmt.Errorf("element %d: %w", i, err)
+					return
+				}
+				o[i] = e
+			}
+		}(w)
+	}
+	wg.Wait()
+
+	// shares are ordered, so the first failing share holds the lowest index
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	return o, nil
+}
+
+// elemConverter returns the Converter of collection elements of type "t", with the same results as Call: nil pointers go through the nil policy, and conversions are reported to the Metrics, if any.
+func elemConverter[T any](c *Conversion[T], t Type) Converter[T] {
+	if t.Kind() == Interface {
+		return func(v Value) (T, error) {
+			return c.Call(v.Interface())
+		}
+	}
+
+	h := c.For(t)
+	m := (*Library[Converter[T]])(c).metrics
+	if m == nil {
+		return h.CallValue
+	}
+	return func(v Value) (T, error) {
+		start := time.Now()
+		o, err := h.CallValue(v)
+		m.Convert(t, time.Since(start), err)
+		return o, err
+	}
+}
@@ -0,0 +1,120 @@
+package conv
+
+import (
+	"errors"
+	. "reflect"
+	"testing"
+)
+
+func TestConvertEach(t *testing.T) {
+	built := 0
+	c := NewConversion(func(t Type) (Converter[int64], bool) {
+		built++
+		if !isInteger(t.Kind()) {
+			return nil, false
+		}
+		return func(v Value) (int64, error) {
+			return v.Int() * 2, nil
+		}, true
+	})
+
+	var o []int64
+	collect := func(i int, v int64, err error) {
+		if err != nil {
+			t.Error(i, err)
+		}
+		o = append(o, v)
+	}
+	if err := ConvertEach(c, []int32{1, 2, 3}, collect); err != nil || len(o) != 3 || o[0] != 2 || o[2] != 6 || built != 1 {
+		t.Error("wrong slice result", o, err, built)
+	}
+
+	o = nil
+	if err := ConvertEach(c, [2]int8{4, 5}, collect); err != nil || len(o) != 2 || o[1] != 10 {
+		t.Error("wrong array result", o, err)
+	}
+
+	o = nil
+	if err := ConvertEach(c, map[string]int{"a": 6}, collect); err != nil || len(o) != 1 || o[0] != 12 {
+		t.Error("wrong map result", o, err)
+	}
+
+	// interface elements dispatch individually
+	var failed []int
+	err := ConvertEach(c, []any{1, "x", int16(2)}, func(i int, v int64, err error) {
+		if err != nil {
+			failed = append(failed, i)
+		}
+	})
+	if err != nil || len(failed) != 1 || failed[0] != 1 {
+		t.Error("wrong interface result", failed, err)
+	}
+
+	if err := ConvertEach(c, 1, collect); !errors.Is(err, ErrUnsupportedKind) {
+		t.Error("expected ErrUnsupportedKind", err)
+	}
+
+	// elements behave as through Call
+	m := &testMetrics{
+		builds:   make(map[Type]bool),
+		converts: make(map[Type]int),
+	}
+	deref := NewConversion(func(t Type) (Converter[int64], bool) {
+		return func(v Value) (int64, error) {
+			return v.Elem().Int(), nil
+		}, t.Kind() == Pointer
+	}, WithMetrics(m))
+	n := 1
+	var errs []error
+	if err := ConvertEach(deref, []*int{&n, nil}, func(i int, v int64, err error) {
+		errs = append(errs, err)
+	}); err != nil || errs[0] != nil || !errors.Is(errs[1], ErrNilSource) {
+		t.Error("nil elements should go through the nil policy", errs, err)
+	}
+	if m.converts[TypeEval[*int]()] != 2 || m.failures != 1 {
+		t.Error("elements should be reported", m.converts, m.failures)
+	}
+}
+
+func TestConvertSliceParallel(t *testing.T) {
+	c := NewConversion(func(t Type) (Converter[int64], bool) {
+		return func(v Value) (int64, error) {
+			if v.Kind() != Int {
+				return 0, ErrInvalid
+			}
+			if v.Int() < 0 {
+				return 0, ErrOverflow
+			}
+			return v.Int() + 1, nil
+		}, true
+	})
+
+	src := make([]int, 1000)
+	for i := range src {
+		src[i] = i
+	}
+	for _, workers := range []int{0, 1, 3, 2000} {
+		o, err := ConvertSliceParallel(c, src, workers)
+		if err != nil || len(o) != len(src) {
+			t.Fatal(workers, err, len(o))
+		}
+		for i, v := range o {
+			if v != int64(i+1) {
+				t.Fatal(workers, "wrong order at", i, v)
+			}
+		}
+	}
+
+	if o, err := ConvertSliceParallel(c, []int{}, 4); err != nil || len(o) != 0 {
+		t.Error("empty slice failed", o, err)
+	}
+
+	src[900], src[100] = -1, -1
+	if _, err := ConvertSliceParallel(c, src, 4); !errors.Is(err, ErrOverflow) || err.Error() != "element 100: "+ErrOverflow.Error() {
+		t.Error("expected lowest failing element", err)
+	}
+
+	if _, err := ConvertSliceParallel(c, map[int]int{}, 4); !errors.Is(err, ErrUnsupportedKind) {
+		t.Error("expected ErrUnsupportedKind", err)
+	}
+}
@@ -0,0 +1,81 @@
+package conv
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	. "reflect"
+)
+
+// Bytes is a set of Builders between byte slices and string kinds, encoding the bytes as base64 or hex text, as expected by JSON and other text based formats.
+// Base64 uses the standard alphabet with padding by default; URL selects the URL safe alphabet, and Raw omits padding, which must then also be absent when parsing.
+// Hex encodes lower case digits, and parses either case.
+//
+// Nil and empty slices both encode as empty strings, which decode as nil slices.
+type Bytes struct {
+	Hex bool
+	URL bool
+	Raw bool
+}
+
+// Converter is a Builder of string Converters for byte slices.
+func (x *Bytes) Converter(t Type) (Converter[string], bool) {
+	return mappingConverter(x.Build, t)
+}
+
+// Inverter is a Builder of string Inverters for byte slices.
+func (x *Bytes) Inverter(t Type) (Inverter[string], bool) {
+	return mappingInverter(x.Build, t)
+}
+
+// Build is a Builder of Mappings between byte slices and string kinds.
+func (x *Bytes) Build(t Type) (Mapping, bool) {
+	tDst, tSrc := t.Out(0), t.In(0)
+	switch {
+	case isBytes(tSrc) && tDst.Kind() == String:
+		return func(dst, src Value, s *State) error {
+			dst.SetString(x.encode(src.Bytes()))
+			return nil
+		}, true
+	case isBytes(tDst) && tSrc.Kind() == String:
+		return func(dst, src Value, s *State) error {
+			if src.Len() == 0 {
+				dst.SetZero()
+				return nil
+			}
+			b, err := x.decode(src.String())
+			if err != nil {
+				return fmt.Errorf("%w: %w", ErrInvalid, err)
+			}
+			dst.SetBytes(b)
+			return nil
+		}, true
+	}
+	return nil, false
+}
+
+func (x *Bytes) encode(b []byte) string {
+	if x.Hex {
+		return hex.EncodeToString(b)
+	}
+	return x.encoding().EncodeToString(b)
+}
+
+func (x *Bytes) decode(s string) ([]byte, error) {
+	if x.Hex {
+		return hex.DecodeString(s)
+	}
+	return x.encoding().DecodeString(s)
+}
+
+func (x *Bytes) encoding() *base64.Encoding {
+	switch {
+	case x.URL && x.Raw:
+		return base64.RawURLEncoding
+	case x.URL:
+		return base64.URLEncoding
+	case x.Raw:
+		return base64.RawStdEncoding
+	}
+	return base64.StdEncoding
+}
@@ -0,0 +1,47 @@
+package conv
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func TestBytes(t *testing.T) {
+	type blob []byte
+	data := []byte{0xfb, 0xff, 0x00, 0x10}
+
+	for _, tc := range []struct {
+		x   Bytes
+		exp string
+	}{
+		{Bytes{}, "+/8AEA=="},
+		{Bytes{URL: true}, "-_8AEA=="},
+		{Bytes{Raw: true}, "+/8AEA"},
+		{Bytes{URL: true, Raw: true}, "-_8AEA"},
+		{Bytes{Hex: true}, "fbff0010"},
+	} {
+		tc := tc
+		s, err := NewConversion(tc.x.Converter).Call(blob(data))
+		if err != nil || s != tc.exp {
+			t.Errorf("%+v: expected %q, got %q", tc.x, tc.exp, s)
+		}
+		b, err := As[blob](NewInversion(tc.x.Inverter), s)
+		if err != nil || !bytes.Equal(b, data) {
+			t.Errorf("%+v: wrong round trip %v %v", tc.x, b, err)
+		}
+	}
+
+	inv := NewInversion((&Bytes{}).Inverter)
+	if _, err := As[[]byte](inv, "+/8AEA"); !errors.Is(err, ErrInvalid) {
+		t.Error("expected missing padding error, got", err)
+	}
+	if b, err := As[[]byte](inv, ""); err != nil || b != nil {
+		t.Error("empty string not nil", b, err)
+	}
+
+	type record struct{ Key string }
+	var r record
+	if err := NewDeepMapper(nil, (&Bytes{Hex: true}).Build).Map(&r, struct{ Key []byte }{data}); err != nil || r.Key != "fbff0010" {
+		t.Error("wrong mapping", r, err)
+	}
+}
@@ -0,0 +1,324 @@
+package conv
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	. "reflect"
+
+	"github.com/blitz-frost/conv/wrap"
+)
+
+// CBOR major types
+const (
+	cborUint byte = iota << 5
+	cborNeg
+	cborBytes
+	cborText
+	cborArray
+	cborMap
+	cborTag
+	cborSimple
+)
+
+const (
+	cborFalse   = cborSimple | 20
+	cborTrue    = cborSimple | 21
+	cborNull    = cborSimple | 22
+	cborUndef   = cborSimple | 23
+	cborFloat16 = cborSimple | 25
+	cborFloat32 = cborSimple | 26
+	cborFloat64 = cborSimple | 27
+)
+
+// CBORConverter is a Builder of Converters that encode values as CBOR (RFC 8949) data items.
+// Values are traversed through the wrap package:
+//
+//   - integers use the shortest head; floats are encoded with the precision of their kind
+//   - strings are text strings; byte slices and arrays are byte strings
+//   - slices and arrays are arrays; maps are maps, with encoded keys
+//   - structs are maps keyed by field name, or conv tag name, containing the fields visited by wrap.StructIter
+//   - nil pointers, slices, maps and interfaces are null; other pointers are dereferenced
+//
+// Complex numbers, channels, functions and unsafe pointers are not supported. Neither are cyclic values, which fail once nested too deep.
+func CBORConverter(t Type) (Converter[[]byte], bool) {
+	if !supports(t, cborKind) {
+		return nil, false
+	}
+	return func(v Value) ([]byte, error) {
+		return cborAppend(nil, wrap.Of(v), 0)
+	}, true
+}
+
+// CBORInverter is a Builder of Inverters that decode single CBOR data items.
+// Integers and floats decode into any numeric kind they fit exactly, and fail with ErrOverflow otherwise. Null and undefined decode as zero values.
+// Struct fields are matched by name; unknown keys are ignored. Tags are skipped, decoding their content.
+// Indefinite lengths are not supported.
+func CBORInverter(t Type) (Inverter[[]byte], bool) {
+	if !supports(t, cborKind) {
+		return nil, false
+	}
+	return func(b []byte) (Value, error) {
+		d := cborDecoder{b: b}
+		w, err := d.item(0)
+		if err != nil {
+			return Value{}, err
+		}
+		if d.i != len(b) {
+			return Value{}, fmt.Errorf("cbor: %d trailing bytes: %w", len(b)-d.i, ErrInvalid)
+		}
+		o := New(t).Elem()
+		if err := wireAssign(o, w); err != nil {
+			return Value{}, err
+		}
+		return o, nil
+	}, true
+}
+
+// cborKind excludes complex kinds, which have no standard CBOR representation, on top of those unsupported by the Codec.
+func cborKind(k Kind) bool {
+	return codecKind(k) && k != Complex64 && k != Complex128
+}
+
+// cborHead appends a data item head of major type "major" with argument "n", using the shortest form.
+func cborHead(b []byte, major byte, n uint64) []byte {
+	switch {
+	case n < 24:
+		return append(b, major|byte(n))
+	case n <= math.MaxUint8:
+		return append(b, major|24, byte(n))
+	case n <= math.MaxUint16:
+		return binary.BigEndian.AppendUint16(append(b, major|25), uint16(n))
+	case n <= math.MaxUint32:
+		return binary.BigEndian.AppendUint32(append(b, major|26), uint32(n))
+	}
+	return binary.BigEndian.AppendUint64(append(b, major|27), n)
+}
+
+func cborAppend(b []byte, w any, depth int) ([]byte, error) {
+	if depth > wireMaxDepth {
+		return nil, ErrCycle
+	}
+	depth++
+
+	var err error
+	switch x := w.(type) {
+	case nil:
+		return append(b, cborNull), nil
+	case bool:
+		if x {
+			return append(b, cborTrue), nil
+		}
+		return append(b, cborFalse), nil
+	case string:
+		return append(cborHead(b, cborText, uint64(len(x))), x...), nil
+	case wrap.Number:
+		if n, ok := x.Int64(); ok {
+			if n < 0 {
+				return cborHead(b, cborNeg, uint64(-1-n)), nil
+			}
+			return cborHead(b, cborUint, uint64(n)), nil
+		}
+		if n, ok := x.Uint64(); ok {
+			return cborHead(b, cborUint, n), nil
+		}
+		if n, ok := x.Float64(); ok {
+			if x.Type().Kind() == Float32 {
+				return binary.BigEndian.AppendUint32(append(b, cborFloat32), math.Float32bits(float32(n))), nil
+			}
+			return binary.BigEndian.AppendUint64(append(b, cborFloat64), math.Float64bits(n)), nil
+		}
+	case wrap.Pointer:
+		if x.IsNil() {
+			return append(b, cborNull), nil
+		}
+		return cborAppend(b, x.Elem(), depth)
+	case wrap.Slice:
+		if x.IsNil() {
+			return append(b, cborNull), nil
+		}
+		n := x.Len()
+		if x.Type().Elem().Kind() == Uint8 {
+			b = cborHead(b, cborBytes, uint64(n))
+			for i := 0; i < n; i++ {
+				e, _ := x.Index(i).(wrap.Number).Uint64()
+				b = append(b, byte(e))
+			}
+			return b, nil
+		}
+		b = cborHead(b, cborArray, uint64(n))
+		for i := 0; i < n; i++ {
+			if b, err = cborAppend(b, x.Index(i), depth); err != nil {
+				return nil, err
+			}
+		}
+		return b, nil
+	case wrap.Map:
+		if x.IsNil() {
+			return append(b, cborNull), nil
+		}
+		b = cborHead(b, cborMap, uint64(x.Len()))
+		x.Range(func(k, v any) bool {
+			if b, err = cborAppend(b, k, depth); err != nil {
+				return false
+			}
+			b, err = cborAppend(b, v, depth)
+			return err == nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		return b, nil
+	case wrap.Struct:
+		// fields skipped through nil embedded pointers are only known after iterating
+		var (
+			body []byte
+			n    uint64
+		)
+		for iter := x.Iter(); iter.Next(); n++ {
+			body = append(cborHead(body, cborText, uint64(len(iter.Name()))), iter.Name()...)
+			if body, err = cborAppend(body, iter.Value(), depth); err != nil {
+				return nil, err
+			}
+		}
+		return append(cborHead(b, cborMap, n), body...), nil
+	}
+	return nil, ErrUnsupportedKind
+}
+
+type cborDecoder struct {
+	b []byte
+	i int
+}
+
+func (x *cborDecoder) next(n uint64) ([]byte, error) {
+	if n > uint64(len(x.b)-x.i) {
+		return nil, io.ErrUnexpectedEOF
+	}
+	o := x.b[x.i : x.i+int(n)]
+	x.i += int(n)
+	return o, nil
+}
+
+// head reads a data item head, returning its major type, additional information and argument.
+func (x *cborDecoder) head() (byte, byte, uint64, error) {
+	c, err := x.next(1)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	major, info := c[0]&0xe0, c[0]&0x1f
+	if info < 24 {
+		return major, info, uint64(info), nil
+	}
+	if info > 27 {
+		return 0, 0, 0, fmt.Errorf("cbor: additional information %d: %w", info, ErrUnsupportedKind)
+	}
+	arg, err := x.next(1 << (info - 24))
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	var n uint64
+	for _, c := range arg {
+		n = n<<8 | uint64(c)
+	}
+	return major, info, n, nil
+}
+
+// item decodes the next data item into a wire value.
+func (x *cborDecoder) item(depth int) (any, error) {
+	if depth > wireMaxDepth {
+		return nil, fmt.Errorf("cbor: nesting too deep: %w", ErrInvalid)
+	}
+	depth++
+
+	major, info, n, err := x.head()
+	if err != nil {
+		return nil, err
+	}
+	switch major {
+	case cborUint:
+		return n, nil
+	case cborNeg:
+		if n > math.MaxInt64 {
+			return nil, fmt.Errorf("cbor: -1-%d: %w", n, ErrOverflow)
+		}
+		return -1 - int64(n), nil
+	case cborBytes:
+		b, err := x.next(n)
+		if err != nil {
+			return nil, err
+		}
+		return append([]byte{}, b...), nil
+	case cborText:
+		b, err := x.next(n)
+		if err != nil {
+			return nil, err
+		}
+		return string(b), nil
+	case cborArray:
+		if n > uint64(len(x.b)-x.i) {
+			// every element takes at least one byte
+			return nil, io.ErrUnexpectedEOF
+		}
+		o := make([]any, n)
+		for i := range o {
+			if o[i], err = x.item(depth); err != nil {
+				return nil, err
+			}
+		}
+		return o, nil
+	case cborMap:
+		if n > uint64(len(x.b)-x.i)/2 {
+			return nil, io.ErrUnexpectedEOF
+		}
+		o := make(wireMap, n)
+		for i := range o {
+			if o[i].k, err = x.item(depth); err != nil {
+				return nil, err
+			}
+			if o[i].v, err = x.item(depth); err != nil {
+				return nil, err
+			}
+		}
+		return o, nil
+	case cborTag:
+		return x.item(depth)
+	}
+
+	// major type 7
+	switch major | info {
+	case cborFalse:
+		return false, nil
+	case cborTrue:
+		return true, nil
+	case cborNull, cborUndef:
+		return nil, nil
+	case cborFloat16:
+		return float16(uint16(n)), nil
+	case cborFloat32:
+		return float64(math.Float32frombits(uint32(n))), nil
+	case cborFloat64:
+		return math.Float64frombits(n), nil
+	}
+	return nil, fmt.Errorf("cbor: simple value %d: %w", n, ErrUnsupportedKind)
+}
+
+// float16 converts IEEE 754 half precision bits.
+func float16(h uint16) float64 {
+	sign := 1.0
+	if h&0x8000 != 0 {
+		sign = -1
+	}
+	exp, frac := int(h>>10&0x1f), float64(h&0x3ff)
+	switch exp {
+	case 0:
+		return sign * math.Ldexp(frac, -24)
+	case 0x1f:
+		if frac == 0 {
+			return math.Inf(int(sign))
+		}
+		return math.NaN()
+	}
+	return sign * math.Ldexp(frac+1024, exp-25)
+}
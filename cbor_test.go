@@ -0,0 +1,103 @@
+package conv
+
+import (
+	"bytes"
+	"errors"
+	. "reflect"
+	"testing"
+)
+
+func TestCBOR(t *testing.T) {
+	type point struct {
+		X, Y int16
+	}
+	type shape struct {
+		Name   string
+		Points []point
+		Tags   map[string]bool
+		Scale  *float32
+		Data   []byte
+		Skip   int `conv:"-"`
+		Extra  any
+	}
+
+	enc := NewConversion(CBORConverter)
+	dec := NewInversion(CBORInverter)
+
+	// known encodings, from RFC 8949 appendix A
+	known := []struct {
+		v   any
+		exp []byte
+	}{
+		{0, []byte{0x00}},
+		{uint8(24), []byte{0x18, 0x18}},
+		{1000, []byte{0x19, 0x03, 0xe8}},
+		{-1000, []byte{0x39, 0x03, 0xe7}},
+		{uint64(18446744073709551615), []byte{0x1b, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}},
+		{1.1, []byte{0xfb, 0x3f, 0xf1, 0x99, 0x99, 0x99, 0x99, 0x99, 0x9a}},
+		{float32(100000), []byte{0xfa, 0x47, 0xc3, 0x50, 0x00}},
+		{true, []byte{0xf5}},
+		{"IETF", []byte{0x64, 0x49, 0x45, 0x54, 0x46}},
+		{[]byte{1, 2}, []byte{0x42, 0x01, 0x02}},
+		{[]int{1, 2, 3}, []byte{0x83, 0x01, 0x02, 0x03}},
+		{[]int(nil), []byte{0xf6}},
+		{point{1, -2}, []byte{0xa2, 0x61, 'X', 0x01, 0x61, 'Y', 0x21}},
+	}
+	for _, c := range known {
+		b, err := enc.Call(c.v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(b, c.exp) {
+			t.Errorf("%v: expected %x, got %x", c.v, c.exp, b)
+		}
+	}
+
+	scale := float32(0.5)
+	v := shape{
+		Name:   "tri",
+		Points: []point{{0, 0}, {1, 0}, {0, -1}},
+		Tags:   map[string]bool{"closed": true},
+		Scale:  &scale,
+		Data:   []byte("raw"),
+		Skip:   7,
+		Extra:  []any{"x", 300},
+	}
+	b, err := enc.Call(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	o, err := As[shape](dec, b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	v.Skip = 0
+	v.Extra = []any{"x", int64(300)}
+	if !DeepEqual(o, v) {
+		t.Error("wrong round trip", o)
+	}
+
+	// half precision, and lossless numeric decoding
+	if f, err := As[float32](dec, []byte{0xf9, 0x3e, 0x00}); err != nil || f != 1.5 {
+		t.Error("wrong half float", f, err)
+	}
+	if _, err := As[int8](dec, []byte{0x19, 0x03, 0xe8}); !errors.Is(err, ErrOverflow) {
+		t.Error("expected overflow, got", err)
+	}
+	if n, err := As[uint16](dec, []byte{0xfb, 0x40, 0x59, 0, 0, 0, 0, 0, 0}); err != nil || n != 100 {
+		t.Error("wrong integral float", n, err)
+	}
+	if _, err := As[int](dec, []byte{0x01, 0x02}); !errors.Is(err, ErrInvalid) {
+		t.Error("expected trailing bytes error, got", err)
+	}
+	if _, err := As[[]int](dec, []byte{0x83, 0x01}); err == nil {
+		t.Error("expected truncation error")
+	}
+	if _, err := As[map[any]int](dec, []byte{0xa1, 0x81, 0x01, 0x01}); !errors.Is(err, ErrInvalid) {
+		t.Error("expected unhashable key error, got", err)
+	}
+
+	if _, ok := CBORConverter(TypeEval[complex64]()); ok {
+		t.Error("complex numbers accepted")
+	}
+}
@@ -0,0 +1,70 @@
+// Command conv-cover checks statically that the conversion schemes of a package cover the types they are called with.
+//
+// Usage:
+//
+//	conv-cover [-dir dir] [-o file] [-list]
+//
+// Call sites of Conversion.Call, As and Mapper.Map are located through type checking, and a test is written to "conv_cover_test.go" in the package directory, failing for each type that its scheme cannot build a function for.
+// Since Builders only run at run time, "go test" completes the check, catching ErrInvalid before production does.
+//
+// Call sites that cannot be checked, such as those using schemes held in local variables or arguments of interface type, are reported on standard error.
+// With -list, all call sites are printed instead, and no test is written.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/blitz-frost/conv/gen"
+)
+
+func main() {
+	dir := flag.String("dir", ".", "package directory")
+	out := flag.String("o", "conv_cover_test.go", "output file, relative to the package directory")
+	list := flag.Bool("list", false, "list call sites only")
+	flag.Parse()
+
+	if err := run(*dir, *out, *list); err != nil {
+		fmt.Fprintln(os.Stderr, "conv-cover:", err)
+		os.Exit(1)
+	}
+}
+
+func run(dir, out string, list bool) error {
+	pkg, err := gen.Load(dir)
+	if err != nil {
+		return err
+	}
+	calls := pkg.Calls()
+
+	if list {
+		for _, c := range calls {
+			fmt.Println(describe(c))
+		}
+		return nil
+	}
+	for _, c := range calls {
+		if c.Reason != "" {
+			fmt.Fprintln(os.Stderr, describe(c))
+		}
+	}
+	src, err := pkg.CoverageTest(calls)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, out), src, 0666)
+}
+
+// describe formats call site "c" for output.
+func describe(c gen.Call) string {
+	o := fmt.Sprintf("%s: %s %v", c.Pos, c.Kind, c.Type)
+	if c.Src != nil {
+		o += fmt.Sprintf(" from %v", c.Src)
+	}
+	if c.Reason != "" {
+		o += ": not checked: " + c.Reason
+	}
+	return o
+}
@@ -0,0 +1,290 @@
+// Command conv-gen generates static conversion functions for the type pairs of a package, as described by package gen.
+//
+// Usage:
+//
+//	conv-gen [-dir dir] [-o file] [-decl file] [-template file] [-fuzz] [-report file] [-types file] [-stub Interface]... [-enum Type]... [-pair Src:Dst]...
+//
+// Struct pairs are the usual case, declared directly through go:generate comments in the package:
+//
+//	//go:generate conv-gen -pair Row:User -pair User:Row
+//
+// Each -pair names two struct types of the package, and generates:
+//
+//	func ConvertRowToUser(src Row) (User, error)
+//
+// Other declarations, such as slices or differently named functions, go in a declaration file, which lists one function per line, as its name followed by the source and destination type expressions:
+//
+//	# comment
+//	UserFromRow  Row    User
+//	UsersFromRows []Row []User
+//
+// Type expressions must not contain spaces. The output is written to "conv_gen.go" in the package directory by default.
+//
+// The generated functions can be customized through a template file, redefining the "func" or "helper" templates of gen.DefaultTemplate, such as to add logging or metrics calls.
+//
+// With -fuzz, a test file named after the output file, "conv_gen_test.go" by default, is also written, with a round trip fuzz test for each pair declared in both directions.
+//
+// With -report, a JSON report of the losses of each function is written to the named file, such as narrowing numeric conversions, ignored source fields or defaulted destination fields, as a list of gen.Report objects. Use "-" for standard output.
+//
+// With -types, named types are declared for the conv.TypeOffer list in the named JSON file, as recorded from conv.Handshake.Offer, freezing types constructed at run time into static code. They are written to a file named after the output file, "conv_gen_types.go" by default, before any pairs are generated, so that pairs may refer to them.
+//
+// Each -stub names an interface type of the package, whose methods convert one value, such as:
+//
+//	type Converts interface {
+//		ToDTO(User) (UserDTO, error)
+//		ToModel(UserDTO) User
+//	}
+//
+// An implementation backed by a conv.Mapper is written to a file named after the output file, "conv_gen_stubs.go" by default, along with a NewConverts(*conv.Mapper) constructor, as described by gen.Package.Stubs.
+//
+// Each -enum names an integer type of the package, whose constants are registered by name with a conv.Enums, through a registerEnums(*conv.Enums) function written to a file named after the output file, "conv_gen_enums.go" by default, as described by gen.Package.Enums.
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/blitz-frost/conv"
+	"github.com/blitz-frost/conv/gen"
+)
+
+func main() {
+	dir := flag.String("dir", ".", "package directory")
+	out := flag.String("o", "conv_gen.go", "output file, relative to the package directory")
+	decl := flag.String("decl", "", "declaration file")
+	tmpl := flag.String("template", "", "template file, overriding the default templates")
+	fuzz := flag.Bool("fuzz", false, "also generate round trip fuzz tests")
+	report := flag.String("report", "", "loss report file, or - for standard output")
+	typs := flag.String("types", "", "JSON file of type offers to declare")
+	var stubs []string
+	flag.Func("stub", "`interface` to implement; may be repeated", func(s string) error {
+		stubs = append(stubs, s)
+		return nil
+	})
+	var enums []string
+	flag.Func("enum", "enum `type` to register; may be repeated", func(s string) error {
+		enums = append(enums, s)
+		return nil
+	})
+	var pairs []gen.Pair
+	flag.Func("pair", "struct pair `Src:Dst`; may be repeated", func(s string) error {
+		p, err := parsePair(s)
+		pairs = append(pairs, p)
+		return err
+	})
+	flag.Parse()
+
+	err := run(options{
+		dir:    *dir,
+		out:    *out,
+		decl:   *decl,
+		tmpl:   *tmpl,
+		report: *report,
+		types:  *typs,
+		stubs:  stubs,
+		enums:  enums,
+		fuzz:   *fuzz,
+		pairs:  pairs,
+	})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "conv-gen:", err)
+		os.Exit(1)
+	}
+}
+
+// options holds the command line flags.
+type options struct {
+	dir, out, decl, tmpl string
+	report, types        string
+	stubs, enums         []string
+	fuzz                 bool
+	pairs                []gen.Pair
+}
+
+func run(o options) error {
+	pairs := o.pairs
+	if o.decl == "" && pairs == nil && o.types == "" && o.stubs == nil && o.enums == nil {
+		return fmt.Errorf("no declaration file, pairs, types, stubs or enums")
+	}
+	stubsOut := strings.TrimSuffix(o.out, ".go") + "_stubs.go"
+	enumsOut := strings.TrimSuffix(o.out, ".go") + "_enums.go"
+
+	if o.types != "" {
+		if err := writeTypes(o.dir, o.out, o.types, stubsOut, enumsOut); err != nil {
+			return err
+		}
+	}
+	if o.stubs != nil {
+		pkg, err := gen.Load(o.dir, o.out, stubsOut, enumsOut)
+		if err != nil {
+			return err
+		}
+		src, err := pkg.Stubs(o.stubs)
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(filepath.Join(o.dir, stubsOut), src, 0666); err != nil {
+			return err
+		}
+	}
+	if o.enums != nil {
+		pkg, err := gen.Load(o.dir, o.out, stubsOut, enumsOut)
+		if err != nil {
+			return err
+		}
+		src, err := pkg.Enums(o.enums)
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(filepath.Join(o.dir, enumsOut), src, 0666); err != nil {
+			return err
+		}
+	}
+	if o.decl == "" && pairs == nil {
+		return nil
+	}
+
+	pkg, err := gen.Load(o.dir, o.out, stubsOut, enumsOut)
+	if err != nil {
+		return err
+	}
+	for _, p := range pairs {
+		for _, name := range [2]string{p.Src, p.Dst} {
+			t, err := pkg.Type(name)
+			if err != nil {
+				return err
+			}
+			if _, ok := t.Underlying().(*types.Struct); !ok {
+				return fmt.Errorf("pair %s:%s: %s is not a struct type", p.Src, p.Dst, name)
+			}
+		}
+	}
+	if o.tmpl != "" {
+		text, err := os.ReadFile(o.tmpl)
+		if err != nil {
+			return err
+		}
+		if pkg.Template, err = gen.ParseTemplate(string(text)); err != nil {
+			return err
+		}
+	}
+	if o.decl != "" {
+		declared, err := readDecl(o.decl)
+		if err != nil {
+			return err
+		}
+		pairs = append(pairs, declared...)
+	}
+
+	src, err := pkg.Generate(pairs)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(o.dir, o.out), src, 0666); err != nil {
+		return err
+	}
+	if o.report != "" {
+		if err := writeReport(pkg, pairs, o.report); err != nil {
+			return err
+		}
+	}
+	if !o.fuzz {
+		return nil
+	}
+
+	tests, err := pkg.FuzzTests(pairs)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(o.dir, strings.TrimSuffix(o.out, ".go")+"_test.go"), tests, 0666)
+}
+
+// writeTypes declares the types offered in JSON file "path", next to output file "out".
+// The "other" generated files are left out of type checking, as they may refer to the previous types.
+func writeTypes(dir, out, path string, other ...string) error {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	var offers []conv.TypeOffer
+	if err := json.Unmarshal(b, &offers); err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+
+	name := strings.TrimSuffix(out, ".go") + "_types.go"
+	pkg, err := gen.Load(dir, append([]string{out, name}, other...)...)
+	if err != nil {
+		return err
+	}
+	src, err := pkg.Declarations(offers)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, name), src, 0666)
+}
+
+// writeReport writes the JSON loss report of "pairs" to file "path", or standard output for "-".
+func writeReport(pkg *gen.Package, pairs []gen.Pair, path string) error {
+	reports, err := pkg.Report(pairs)
+	if err != nil {
+		return err
+	}
+	b, err := json.MarshalIndent(reports, "", "\t")
+	if err != nil {
+		return err
+	}
+	b = append(b, '\n')
+	if path == "-" {
+		_, err = os.Stdout.Write(b)
+		return err
+	}
+	return os.WriteFile(path, b, 0666)
+}
+
+// parsePair parses a "Src:Dst" pair of type names.
+func parsePair(s string) (gen.Pair, error) {
+	src, dst, ok := strings.Cut(s, ":")
+	if !ok || !token.IsIdentifier(src) || !token.IsIdentifier(dst) {
+		return gen.Pair{}, fmt.Errorf("pair %q: expected Src:Dst type names", s)
+	}
+	return gen.Pair{
+		Name: "Convert" + src + "To" + dst,
+		Src:  src,
+		Dst:  dst,
+	}, nil
+}
+
+// readDecl parses declaration file "path".
+func readDecl(path string) ([]gen.Pair, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var o []gen.Pair
+	sc := bufio.NewScanner(f)
+	for n := 1; sc.Scan(); n++ {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || line[0] == '#' {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) != 3 {
+			return nil, fmt.Errorf("%s:%d: expected name, source and destination type", path, n)
+		}
+		o = append(o, gen.Pair{
+			Name: fields[0],
+			Src:  fields[1],
+			Dst:  fields[2],
+		})
+	}
+	return o, sc.Err()
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/blitz-frost/conv"
+	"github.com/blitz-frost/conv/gen"
+)
+
+func TestRun(t *testing.T) {
+	dir := t.TempDir()
+	src := `package models
+
+//go:generate conv-gen -pair Row:User
+
+type Row struct {
+	ID   int64
+	Name string
+}
+
+type User struct {
+	ID   int
+	Name string
+}
+`
+	if err := os.WriteFile(filepath.Join(dir, "models.go"), []byte(src), 0666); err != nil {
+		t.Fatal(err)
+	}
+	decl := filepath.Join(dir, "conv.decl")
+	if err := os.WriteFile(decl, []byte("# slices\nUsersFromRows []Row []User\n"), 0666); err != nil {
+		t.Fatal(err)
+	}
+
+	p, err := parsePair("Row:User")
+	if err != nil || p != (gen.Pair{Name: "ConvertRowToUser", Src: "Row", Dst: "User"}) {
+		t.Fatal("wrong pair", p, err)
+	}
+	if err := run(options{dir: dir, out: "conv_gen.go", decl: decl, pairs: []gen.Pair{p}}); err != nil {
+		t.Fatal(err)
+	}
+	out, err := os.ReadFile(filepath.Join(dir, "conv_gen.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, s := range []string{"func ConvertRowToUser(src Row) (User, error)", "func UsersFromRows(src []Row) ([]User, error)", "dst.ID = int(src.ID)"} {
+		if !strings.Contains(string(out), s) {
+			t.Errorf("missing %q:\n%s", s, out)
+		}
+	}
+
+	// regeneration ignores the previous output
+	back, _ := parsePair("User:Row")
+	report := filepath.Join(dir, "report.json")
+	if err := run(options{dir: dir, out: "conv_gen.go", report: report, fuzz: true, pairs: []gen.Pair{p, back}}); err != nil {
+		t.Fatal(err)
+	}
+	if out, err := os.ReadFile(filepath.Join(dir, "conv_gen_test.go")); err != nil || !strings.Contains(string(out), "func FuzzConvertRowToUser(f *testing.F)") {
+		t.Errorf("missing fuzz test: %v\n%s", err, out)
+	}
+	var reports []gen.Report
+	if b, err := os.ReadFile(report); err != nil {
+		t.Error(err)
+	} else if err := json.Unmarshal(b, &reports); err != nil || len(reports) != 2 || reports[0].Name != "ConvertRowToUser" {
+		t.Errorf("wrong report: %v\n%s", err, b)
+	}
+
+	if _, err := parsePair("[]Row:User"); err == nil {
+		t.Error("expected invalid pair")
+	}
+	bad, _ := parsePair("Row:Label")
+	if err := os.WriteFile(filepath.Join(dir, "label.go"), []byte("package models\n\ntype Label string\n"), 0666); err != nil {
+		t.Fatal(err)
+	}
+	if err := run(options{dir: dir, out: "conv_gen.go", pairs: []gen.Pair{bad}}); err == nil {
+		t.Error("expected non struct error")
+	}
+
+	var h conv.Handshake
+	h.Expect("event", reflect.StructOf([]reflect.StructField{{Name: "At", Type: reflect.TypeOf(int64(0))}}))
+	b, _ := json.Marshal(h.Offer())
+	offers := filepath.Join(dir, "offers.json")
+	if err := os.WriteFile(offers, b, 0666); err != nil {
+		t.Fatal(err)
+	}
+	if err := run(options{dir: dir, out: "conv_gen.go", types: offers}); err != nil {
+		t.Fatal(err)
+	}
+	if out, err := os.ReadFile(filepath.Join(dir, "conv_gen_types.go")); err != nil || !strings.Contains(string(out), "type Event struct {") {
+		t.Errorf("missing type declaration: %v\n%s", err, out)
+	}
+
+	iface := "package models\n\ntype Converts interface {\n\tToUser(Row) (User, error)\n}\n"
+	if err := os.WriteFile(filepath.Join(dir, "iface.go"), []byte(iface), 0666); err != nil {
+		t.Fatal(err)
+	}
+	if err := run(options{dir: dir, out: "conv_gen.go", stubs: []string{"Converts"}}); err != nil {
+		t.Fatal(err)
+	}
+	if out, err := os.ReadFile(filepath.Join(dir, "conv_gen_stubs.go")); err != nil || !strings.Contains(string(out), "func NewConverts(m *conv.Mapper) Converts {") {
+		t.Errorf("missing stubs: %v\n%s", err, out)
+	}
+
+	enum := "package models\n\ntype Status int\n\nconst (\n\tActive Status = iota\n\tClosed\n)\n"
+	if err := os.WriteFile(filepath.Join(dir, "enum.go"), []byte(enum), 0666); err != nil {
+		t.Fatal(err)
+	}
+	if err := run(options{dir: dir, out: "conv_gen.go", enums: []string{"Status"}}); err != nil {
+		t.Fatal(err)
+	}
+	if out, err := os.ReadFile(filepath.Join(dir, "conv_gen_enums.go")); err != nil || !strings.Contains(string(out), "conv.RegisterEnum(e, map[Status]string{") {
+		t.Errorf("missing enums: %v\n%s", err, out)
+	}
+}
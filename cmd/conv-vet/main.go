@@ -0,0 +1,50 @@
+// Command conv-vet reports likely mistakes in the Builder, Converter, Inverter and Mapping implementations of packages, as described by gen.Package.Lint.
+//
+// Usage:
+//
+//	conv-vet [dir]...
+//
+// Each diagnostic is printed as "position: check: message". The exit status is 1 if any are found, and 2 on errors, like go vet.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/blitz-frost/conv/gen"
+)
+
+func main() {
+	flag.Parse()
+	dirs := flag.Args()
+	if len(dirs) == 0 {
+		dirs = []string{"."}
+	}
+
+	found, err := run(os.Stdout, dirs)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "conv-vet:", err)
+		os.Exit(2)
+	}
+	if found {
+		os.Exit(1)
+	}
+}
+
+// run lints the packages in "dirs", writing diagnostics to "w", and returns true if there were any.
+func run(w io.Writer, dirs []string) (bool, error) {
+	found := false
+	for _, dir := range dirs {
+		pkg, err := gen.Load(dir)
+		if err != nil {
+			return found, err
+		}
+		for _, d := range pkg.Lint() {
+			fmt.Fprintf(w, "%s: %s: %s\n", d.Pos, d.Check, d.Message)
+			found = true
+		}
+	}
+	return found, nil
+}
@@ -0,0 +1,530 @@
+package conv
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	. "reflect"
+	"sync"
+)
+
+var (
+	ErrCycle    = errors.New("cyclic value")
+	ErrMismatch = errors.New("encoded base does not match destination type")
+)
+
+const (
+	maxBaseLen  = 1 << 12 // length cap of encoded bases, in bytes, once SetMaxLen is set
+	maxBaseElem = 16      // size of the largest basic type, complex128
+)
+
+// A Codec encodes values in a self-describing binary format, where each value is prefixed by its Base.
+// Decoding resolves the base to a registered type with the same Fingerprint, or constructs an equivalent unnamed type through reflection when none is registered.
+//
+// Payloads follow the structure of the base: varints for integers, fixed size little endian floats, length prefixed strings, slices and maps, presence flags for pointers and nested self-describing values for interfaces.
+// Only exported struct fields are encoded; unexported ones are left zero on decode. Channels, functions and unsafe pointers are not supported, nor are cyclic values.
+//
+// Safe for concurrent use.
+type Codec struct {
+	types  map[uint64]Type
+	mux    sync.RWMutex
+	maxLen int
+}
+
+func NewCodec() *Codec {
+	return &Codec{
+		types: make(map[uint64]Type),
+	}
+}
+
+// Register makes "t" the decoding target for its base.
+func (x *Codec) Register(t Type) {
+	x.mux.Lock()
+	x.types[Fingerprint(t)] = t
+	x.mux.Unlock()
+}
+
+// SetMaxLen caps the lengths of decoded strings, slices and maps, as read from the input, failing with ErrTooLarge beyond "n".
+// Type bases are capped too: bases to 4 KiB, the array lengths in them as other lengths, and the types they construct to "n" times the size of complex128, the largest basic type.
+// A guard for untrusted input, which could otherwise have the Codec allocate or loop without bound. Zero or less means no limit, which is the default.
+// Should be set before use.
+func (x *Codec) SetMaxLen(n int) {
+	x.maxLen = n
+}
+
+// Encode writes "v" to "w", prefixed by its base.
+func (x *Codec) Encode(w io.Writer, v any) error {
+	b, err := x.appendAny(nil, ValueOf(v))
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(b)
+	return err
+}
+
+// Decode reads a value written by Encode.
+func (x *Codec) Decode(r io.Reader) (Value, error) {
+	return x.decodeAny(asByteReader(r), false)
+}
+
+// DecodeInto reads a value written by Encode into the value pointed to by "dst", whose type must have the same base as the encoded one.
+func (x *Codec) DecodeInto(r io.Reader, dst any) error {
+	d := ValueOf(dst)
+	if d.Kind() != Pointer || d.IsNil() {
+		return ErrInvalid
+	}
+
+	br := asByteReader(r)
+	base, err := readString(br, 0)
+	if err != nil {
+		return err
+	}
+	if base != Base(d.Type().Elem()) {
+		return ErrMismatch
+	}
+	return x.decode(br, d.Elem())
+}
+
+// Converter is a Builder of []byte Converters that encode through the Codec.
+func (x *Codec) Converter(t Type) (Converter[[]byte], bool) {
+	if !codecSupports(t) {
+		return nil, false
+	}
+	base := Base(t)
+	return func(v Value) ([]byte, error) {
+		o := appendString(nil, base)
+		return x.append(o, v, make(map[uintptr]bool))
+	}, true
+}
+
+// Inverter is a Builder of []byte Inverters that decode through the Codec, into types with the same base as the encoded one.
+func (x *Codec) Inverter(t Type) (Inverter[[]byte], bool) {
+	if !codecSupports(t) {
+		return nil, false
+	}
+	return func(v []byte) (Value, error) {
+		o := New(t).Elem()
+		if err := x.DecodeInto(bytes.NewReader(v), o.Addr().Interface()); err != nil {
+			return Value{}, err
+		}
+		return o, nil
+	}, true
+}
+
+func (x *Codec) appendAny(b []byte, v Value) ([]byte, error) {
+	if !v.IsValid() {
+		// nil interface
+		return appendString(b, ""), nil
+	}
+	b = appendString(b, Base(v.Type()))
+	return x.append(b, v, make(map[uintptr]bool))
+}
+
+// append encodes the payload of "v". "path" holds the pointers currently being encoded, to detect cycles.
+func (x *Codec) append(b []byte, v Value, path map[uintptr]bool) ([]byte, error) {
+	var err error
+	switch v.Kind() {
+	case Bool:
+		if v.Bool() {
+			return append(b, 1), nil
+		}
+		return append(b, 0), nil
+	case Int, Int8, Int16, Int32, Int64:
+		return binary.AppendVarint(b, v.Int()), nil
+	case Uint, Uint8, Uint16, Uint32, Uint64, Uintptr:
+		return binary.AppendUvarint(b, v.Uint()), nil
+	case Float32:
+		return binary.LittleEndian.AppendUint32(b, math.Float32bits(float32(v.Float()))), nil
+	case Float64:
+		return binary.LittleEndian.AppendUint64(b, math.Float64bits(v.Float())), nil
+	case Complex64:
+		c := v.Complex()
+		b = binary.LittleEndian.AppendUint32(b, math.Float32bits(float32(real(c))))
+		return binary.LittleEndian.AppendUint32(b, math.Float32bits(float32(imag(c)))), nil
+	case Complex128:
+		c := v.Complex()
+		b = binary.LittleEndian.AppendUint64(b, math.Float64bits(real(c)))
+		return binary.LittleEndian.AppendUint64(b, math.Float64bits(imag(c))), nil
+	case String:
+		return appendString(b, v.String()), nil
+	case Array:
+		for i, n := 0, v.Len(); i < n; i++ {
+			if b, err = x.append(b, v.Index(i), path); err != nil {
+				return nil, err
+			}
+		}
+		return b, nil
+	case Slice:
+		if v.IsNil() {
+			return append(b, 0), nil
+		}
+		b = binary.AppendUvarint(b, uint64(v.Len())+1)
+		for i, n := 0, v.Len(); i < n; i++ {
+			if b, err = x.append(b, v.Index(i), path); err != nil {
+				return nil, err
+			}
+		}
+		return b, nil
+	case Map:
+		if v.IsNil() {
+			return append(b, 0), nil
+		}
+		b = binary.AppendUvarint(b, uint64(v.Len())+1)
+		for iter := v.MapRange(); iter.Next(); {
+			if b, err = x.append(b, iter.Key(), path); err != nil {
+				return nil, err
+			}
+			if b, err = x.append(b, iter.Value(), path); err != nil {
+				return nil, err
+			}
+		}
+		return b, nil
+	case Pointer:
+		if v.IsNil() {
+			return append(b, 0), nil
+		}
+		ptr := v.Pointer()
+		if path[ptr] {
+			return nil, ErrCycle
+		}
+		path[ptr] = true
+		b, err = x.append(append(b, 1), v.Elem(), path)
+		delete(path, ptr)
+		return b, err
+	case Struct:
+		t := v.Type()
+		for i, n := 0, v.NumField(); i < n; i++ {
+			if !t.Field(i).IsExported() {
+				continue
+			}
+			if b, err = x.append(b, v.Field(i), path); err != nil {
+				return nil, err
+			}
+		}
+		return b, nil
+	case Interface:
+		if v.IsNil() {
+			return appendString(b, ""), nil
+		}
+		e := v.Elem()
+		b = appendString(b, Base(e.Type()))
+		return x.append(b, e, path)
+	}
+	return nil, ErrUnsupportedKind
+}
+
+// decodeAny reads a self-describing value. If "key" is set, the value is meant as a map key, and bases of types that can't be hashed fail with ErrInvalid.
+func (x *Codec) decodeAny(r io.ByteReader, key bool) (Value, error) {
+	limit := 0
+	if x.maxLen > 0 {
+		limit = maxBaseLen
+	}
+	base, err := readString(r, limit)
+	if err != nil {
+		return Value{}, err
+	}
+	if base == "" {
+		// nil interface
+		return Value{}, nil
+	}
+	t, err := x.resolve(base)
+	if err != nil {
+		return Value{}, err
+	}
+	if key && !t.Comparable() {
+		return Value{}, fmt.Errorf("key base %s: %w", base, ErrInvalid)
+	}
+	o := New(t).Elem()
+	if err := x.decode(r, o); err != nil {
+		return Value{}, err
+	}
+	return o, nil
+}
+
+// resolve returns the type to decode "base" into.
+func (x *Codec) resolve(base string) (Type, error) {
+	x.mux.RLock()
+	t, ok := x.types[fingerprint(base)]
+	x.mux.RUnlock()
+	if ok {
+		return t, nil
+	}
+
+	t, err := asType(base, x.maxLen)
+	if err != nil {
+		return nil, err
+	}
+	if limit := uint64(x.maxLen) * maxBaseElem; x.maxLen > 0 && uint64(t.Size()) > limit {
+		return nil, fmt.Errorf("base %s of size %d over %d: %w", base, t.Size(), limit, ErrTooLarge)
+	}
+	return t, nil
+}
+
+// decode reads a payload into the settable "v".
+func (x *Codec) decode(r io.ByteReader, v Value) error {
+	switch v.Kind() {
+	case Bool:
+		c, err := r.ReadByte()
+		if err != nil {
+			return err
+		}
+		v.SetBool(c != 0)
+	case Int, Int8, Int16, Int32, Int64:
+		n, err := binary.ReadVarint(r)
+		if err != nil {
+			return err
+		}
+		v.SetInt(n)
+	case Uint, Uint8, Uint16, Uint32, Uint64, Uintptr:
+		n, err := binary.ReadUvarint(r)
+		if err != nil {
+			return err
+		}
+		v.SetUint(n)
+	case Float32:
+		n, err := readFixed(r, 4)
+		if err != nil {
+			return err
+		}
+		v.SetFloat(float64(math.Float32frombits(uint32(n))))
+	case Float64:
+		n, err := readFixed(r, 8)
+		if err != nil {
+			return err
+		}
+		v.SetFloat(math.Float64frombits(n))
+	case Complex64:
+		re, err := readFixed(r, 4)
+		if err != nil {
+			return err
+		}
+		im, err := readFixed(r, 4)
+		if err != nil {
+			return err
+		}
+		v.SetComplex(complex(float64(math.Float32frombits(uint32(re))), float64(math.Float32frombits(uint32(im)))))
+	case Complex128:
+		re, err := readFixed(r, 8)
+		if err != nil {
+			return err
+		}
+		im, err := readFixed(r, 8)
+		if err != nil {
+			return err
+		}
+		v.SetComplex(complex(math.Float64frombits(re), math.Float64frombits(im)))
+	case String:
+		s, err := readString(r, x.maxLen)
+		if err != nil {
+			return err
+		}
+		v.SetString(s)
+	case Array:
+		for i, n := 0, v.Len(); i < n; i++ {
+			if err := x.decode(r, v.Index(i)); err != nil {
+				return err
+			}
+		}
+	case Slice:
+		n, err := binary.ReadUvarint(r)
+		if err != nil || n == 0 {
+			return err
+		}
+		n--
+		if err := checkLen(n, x.maxLen); err != nil {
+			return err
+		}
+		o := MakeSlice(v.Type(), 0, 0)
+		for i := uint64(0); i < n; i++ {
+			o = Append(o, Zero(v.Type().Elem()))
+			if err := x.decode(r, o.Index(int(i))); err != nil {
+				return err
+			}
+		}
+		v.Set(o)
+	case Map:
+		n, err := binary.ReadUvarint(r)
+		if err != nil || n == 0 {
+			return err
+		}
+		n--
+		if err := checkLen(n, x.maxLen); err != nil {
+			return err
+		}
+		t := v.Type()
+		o := MakeMap(t)
+		for i := uint64(0); i < n; i++ {
+			key := New(t.Key()).Elem()
+			if key.Kind() == Interface {
+				err = x.decodeInterface(r, key, true)
+			} else {
+				err = x.decode(r, key)
+			}
+			if err != nil {
+				return err
+			}
+			// interfaces nested in keys, such as in struct fields, may still hold slices or maps
+			if !key.Comparable() {
+				return fmt.Errorf("unhashable key of type %s: %w", describeType(t.Key()), ErrInvalid)
+			}
+			elem := New(t.Elem()).Elem()
+			if err := x.decode(r, elem); err != nil {
+				return err
+			}
+			o.SetMapIndex(key, elem)
+		}
+		v.Set(o)
+	case Pointer:
+		c, err := r.ReadByte()
+		if err != nil || c == 0 {
+			return err
+		}
+		o := New(v.Type().Elem())
+		if err := x.decode(r, o.Elem()); err != nil {
+			return err
+		}
+		v.Set(o)
+	case Struct:
+		t := v.Type()
+		for i, n := 0, v.NumField(); i < n; i++ {
+			if !t.Field(i).IsExported() {
+				continue
+			}
+			if err := x.decode(r, v.Field(i)); err != nil {
+				return err
+			}
+		}
+	case Interface:
+		return x.decodeInterface(r, v, false)
+	default:
+		return ErrUnsupportedKind
+	}
+	return nil
+}
+
+// decodeInterface reads a self-describing value into the settable interface "v", as decodeAny does.
+func (x *Codec) decodeInterface(r io.ByteReader, v Value, key bool) error {
+	o, err := x.decodeAny(r, key)
+	if err != nil {
+		return err
+	}
+	if !o.IsValid() {
+		v.SetZero()
+		return nil
+	}
+	if !o.Type().AssignableTo(v.Type()) {
+		return ErrMismatch
+	}
+	v.Set(o)
+	return nil
+}
+
+// codecSupports returns true if "t" contains no kinds unsupported by the Codec.
+func codecSupports(t Type) bool {
+	return supports(t, codecKind)
+}
+
+func codecKind(k Kind) bool {
+	switch k {
+	case Chan, Func, UnsafePointer, Invalid:
+		return false
+	}
+	return true
+}
+
+// supports returns true if "fn" accepts the kinds of "t" and of the types it contains, through exported struct fields.
+// Recursive types are assumed supported, as their recursion goes through already checked types.
+func supports(t Type, fn func(Kind) bool) bool {
+	return supportsCheck(t, fn, make(map[Type]bool))
+}
+
+func supportsCheck(t Type, fn func(Kind) bool, checked map[Type]bool) bool {
+	if checked[t] {
+		return true
+	}
+	checked[t] = true
+
+	if !fn(t.Kind()) {
+		return false
+	}
+	switch t.Kind() {
+	case Array, Pointer, Slice:
+		return supportsCheck(t.Elem(), fn, checked)
+	case Map:
+		return supportsCheck(t.Key(), fn, checked) && supportsCheck(t.Elem(), fn, checked)
+	case Struct:
+		for i, n := 0, t.NumField(); i < n; i++ {
+			if f := t.Field(i); f.IsExported() && !supportsCheck(f.Type, fn, checked) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func appendString(b []byte, s string) []byte {
+	b = binary.AppendUvarint(b, uint64(len(s)))
+	return append(b, s...)
+}
+
+func readString(r io.ByteReader, limit int) (string, error) {
+	n, err := binary.ReadUvarint(r)
+	if err != nil {
+		return "", err
+	}
+	if err := checkLen(n, limit); err != nil {
+		return "", err
+	}
+	b := make([]byte, 0, 64)
+	for i := uint64(0); i < n; i++ {
+		c, err := r.ReadByte()
+		if err != nil {
+			return "", io.ErrUnexpectedEOF
+		}
+		b = append(b, c)
+	}
+	return string(b), nil
+}
+
+// checkLen returns ErrTooLarge if length "n", as read from the input, is over "limit", if positive.
+func checkLen(n uint64, limit int) error {
+	if limit > 0 && n > uint64(limit) {
+		return fmt.Errorf("length %d over %d: %w", n, limit, ErrTooLarge)
+	}
+	return nil
+}
+
+// readFixed reads an "n" byte little endian unsigned integer.
+func readFixed(r io.ByteReader, n int) (uint64, error) {
+	var o uint64
+	for i := 0; i < n; i++ {
+		c, err := r.ReadByte()
+		if err != nil {
+			return 0, io.ErrUnexpectedEOF
+		}
+		o |= uint64(c) << (8 * i)
+	}
+	return o, nil
+}
+
+// asByteReader returns "r" as an io.ByteReader, wrapping it if necessary.
+// The wrapper reads one byte at a time, so as not to consume data past the end of a value.
+func asByteReader(r io.Reader) io.ByteReader {
+	if br, ok := r.(io.ByteReader); ok {
+		return br
+	}
+	return &byteReader{r: r}
+}
+
+type byteReader struct {
+	r   io.Reader
+	buf [1]byte
+}
+
+func (x *byteReader) ReadByte() (byte, error) {
+	_, err := io.ReadFull(x.r, x.buf[:])
+	return x.buf[0], err
+}
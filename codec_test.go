@@ -0,0 +1,155 @@
+package conv
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	. "reflect"
+	"strings"
+	"testing"
+)
+
+func TestCodec(t *testing.T) {
+	type inner struct {
+		F float32
+		C complex128
+	}
+	type value struct {
+		A int
+		B []string
+		M map[string]*inner
+		I any
+		N []int
+		u int
+	}
+
+	v := value{
+		A: -3,
+		B: []string{"x", ""},
+		M: map[string]*inner{"k": {1.5, 2i}, "nil": nil},
+		I: [2]uint8{1, 2},
+		u: 9,
+	}
+
+	x := NewCodec()
+	var buf bytes.Buffer
+	if err := x.Encode(&buf, v); err != nil {
+		t.Fatal(err)
+	}
+	raw := buf.Bytes()
+
+	// unregistered: reconstructed as an unnamed type
+	o, err := x.Decode(bytes.NewReader(raw))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if o.Type() == TypeEval[value]() || Base(o.Type()) != Base(TypeEval[value]()) {
+		t.Error("wrong reconstructed type", o.Type())
+	}
+
+	// registered
+	x.Register(TypeEval[value]())
+	o, err = x.Decode(bytes.NewReader(raw))
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := v
+	exp.u = 0
+	if !DeepEqual(o.Interface(), exp) {
+		t.Error("wrong decode", o)
+	}
+
+	var into value
+	if err := x.DecodeInto(bytes.NewReader(raw), &into); err != nil || !DeepEqual(into, exp) {
+		t.Error("wrong decode into", into, err)
+	}
+	var wrong inner
+	if err := x.DecodeInto(bytes.NewReader(raw), &wrong); err != ErrMismatch {
+		t.Error("expected mismatch", err)
+	}
+
+	c := NewConversion(x.Converter)
+	b, err := c.Call(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	inv := NewInversion(x.Inverter)
+	if o, err := As[value](inv, b); err != nil || !DeepEqual(o, exp) {
+		t.Error("inverter failed", o, err)
+	}
+
+	type node struct {
+		Next *node
+	}
+	n := &node{}
+	n.Next = n
+	if err := x.Encode(&buf, n); err != ErrCycle {
+		t.Error("expected cycle error", err)
+	}
+	if _, err := c.Call(make(chan int)); !errors.Is(err, ErrInvalid) {
+		t.Error("channels should not build")
+	}
+
+	// keys of types that can't be hashed, directly or nested in a struct
+	for _, tc := range []struct {
+		t   Type
+		key []byte
+	}{
+		{TypeEval[map[any]int](), appendString(nil, "[]int")},
+		{TypeEval[map[struct{ A any }]int](), appendString(nil, "[]int")},
+	} {
+		in := appendString(nil, Base(tc.t))
+		in = append(in, 2)              // one entry
+		in = append(in, tc.key...)      // key base
+		in = append(in, 2, 2)           // key []int{1}
+		in = binary.AppendVarint(in, 1) // value
+		if _, err := x.Decode(bytes.NewReader(in)); !errors.Is(err, ErrInvalid) {
+			t.Errorf("%v: expected unhashable key error, got %v", tc.t, err)
+		}
+	}
+}
+
+func TestCodecMaxLen(t *testing.T) {
+	x := NewCodec()
+	var buf bytes.Buffer
+	if err := x.Encode(&buf, make([]struct{}, 10)); err != nil {
+		t.Fatal(err)
+	}
+	raw := append([]byte{}, buf.Bytes()...)
+
+	x.SetMaxLen(4)
+	if _, err := x.Decode(bytes.NewReader(raw)); !errors.Is(err, ErrTooLarge) {
+		t.Error("expected too large slice", err)
+	}
+	buf.Reset()
+	if err := x.Encode(&buf, "hello"); err != nil {
+		t.Fatal(err)
+	}
+	var s string
+	if err := x.DecodeInto(&buf, &s); !errors.Is(err, ErrTooLarge) {
+		t.Error("expected too large string", err)
+	}
+
+	x.SetMaxLen(10)
+	if v, err := x.Decode(bytes.NewReader(raw)); err != nil || v.Len() != 10 {
+		t.Error("wrong slice", v, err)
+	}
+
+	// bases are checked before their types are allocated
+	x.SetMaxLen(16)
+	for _, base := range []string{
+		"[1073741824]uint8",
+		"[16][16][16]uint8",
+		strings.Repeat("[]", 100) + "int",
+		"struct{" + strings.Repeat("A", 5000) + " int}",
+	} {
+		in := appendString(nil, base)
+		if _, err := x.Decode(bytes.NewReader(in)); !errors.Is(err, ErrTooLarge) {
+			t.Errorf("%.32s: expected too large base, got %v", base, err)
+		}
+	}
+	if v, err := x.Decode(bytes.NewReader(appendString(nil, "[16]uint8"))); !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+		t.Error("small array base should be accepted", v, err)
+	}
+}
@@ -0,0 +1,205 @@
+package conv
+
+import (
+	. "reflect"
+)
+
+// ColumnConverter is a Builder that transposes slices or arrays of structs (rows) into T, a struct of slices (columns).
+// Each column is filled from the row field of the same name, which must be assignable or convertible to the column element type.
+// Row fields without a matching column are ignored, as are columns without a matching row field.
+// Builds only if at least one column matches.
+func ColumnConverter[T any](t Type) (Converter[T], bool) {
+	tCols := TypeEval[T]()
+	if (t.Kind() != Slice && t.Kind() != Array) || t.Elem().Kind() != Struct {
+		return nil, false
+	}
+
+	plan, ok := columnPlan(t.Elem(), tCols, false)
+	if !ok {
+		return nil, false
+	}
+
+	return func(v Value) (T, error) {
+		n := v.Len()
+		o := New(tCols).Elem()
+		for _, p := range plan {
+			col := MakeSlice(tCols.Field(p.col).Type, n, n)
+			for i := 0; i < n; i++ {
+				field, err := v.Index(i).FieldByIndexErr(p.row)
+				if err != nil {
+					// nil embedded pointer; leave zero
+					continue
+				}
+				p.set(col.Index(i), field)
+			}
+			o.Field(p.col).Set(col)
+		}
+		return o.Interface().(T), nil
+	}, true
+}
+
+// ColumnInverter is the ColumnConverter counterpart, transposing T back into slices or arrays of structs.
+// Slice destinations are as long as the longest matched column. Rows beyond the end of shorter (or nil) columns keep the zero value for those fields.
+// Array destinations have a fixed length; excess column elements are ignored.
+func ColumnInverter[T any](t Type) (Inverter[T], bool) {
+	tCols := TypeEval[T]()
+	if (t.Kind() != Slice && t.Kind() != Array) || t.Elem().Kind() != Struct {
+		return nil, false
+	}
+
+	plan, ok := columnPlan(t.Elem(), tCols, true)
+	if !ok {
+		return nil, false
+	}
+
+	return func(v T) (Value, error) {
+		cols := ValueOf(&v).Elem()
+
+		var o Value
+		n := 0
+		if t.Kind() == Array {
+			o = New(t).Elem()
+			n = t.Len()
+		} else {
+			for _, p := range plan {
+				if l := cols.Field(p.col).Len(); l > n {
+					n = l
+				}
+			}
+			o = MakeSlice(t, n, n)
+		}
+
+		for _, p := range plan {
+			col := cols.Field(p.col)
+			for i, m := 0, col.Len(); i < m && i < n; i++ {
+				p.set(o.Index(i).FieldByIndex(p.row), col.Index(i))
+			}
+		}
+		return o, nil
+	}, true
+}
+
+// columnPair links a row field to its column.
+type columnPair struct {
+	row []int                // row field index
+	col int                  // column field index
+	set func(dst, src Value) // row to column, or column to row if inverse
+}
+
+// columnPlan matches row fields to columns by name, returning false if there are no matches or either type is unsuitable.
+// Row fields promoted through embedded pointers are not considered, as they may not be settable.
+// If "inverse" is true, element assignments go from column to row.
+func columnPlan(tRow, tCols Type, inverse bool) ([]columnPair, bool) {
+	if tRow.Kind() != Struct || tCols.Kind() != Struct {
+		return nil, false
+	}
+
+	var o []columnPair
+	for i, n := 0, tCols.NumField(); i < n; i++ {
+		col := tCols.Field(i)
+		if !col.IsExported() || col.Type.Kind() != Slice {
+			continue
+		}
+
+		row, ok := tRow.FieldByName(col.Name)
+		if !ok || !row.IsExported() || throughPointer(tRow, row.Index) {
+			continue
+		}
+
+		tSrc, tDst := row.Type, col.Type.Elem()
+		if inverse {
+			tSrc, tDst = tDst, tSrc
+		}
+		set, ok := assignFunc(tDst, tSrc)
+		if !ok {
+			continue
+		}
+
+		o = append(o, columnPair{
+			row: row.Index,
+			col: i,
+			set: set,
+		})
+	}
+
+	return o, len(o) > 0
+}
+
+// throughPointer returns true if the field at "index" is promoted through an embedded pointer.
+func throughPointer(t Type, index []int) bool {
+	for _, i := range index[:len(index)-1] {
+		t = t.Field(i).Type
+		if t.Kind() == Pointer {
+			return true
+		}
+	}
+	return false
+}
+
+// assignFunc returns a function that sets values of type "tSrc" into settable values of type "tDst", using Go assignment or conversion rules.
+// Integer to string conversions are excluded, as are slice to array conversions, which can panic.
+func assignFunc(tDst, tSrc Type) (func(dst, src Value), bool) {
+	if set := kindAssign(tDst.Kind(), tSrc.Kind()); set != nil {
+		return set, true
+	}
+	if tDst.Kind() == Slice && tSrc.Kind() == Slice && tDst.Elem() == tSrc.Elem() && tSrc.Elem().Kind() == Uint8 {
+		return func(dst, src Value) {
+			dst.SetBytes(src.Bytes())
+		}, true
+	}
+	switch {
+	case tSrc.AssignableTo(tDst):
+		return func(dst, src Value) {
+			dst.Set(src)
+		}, true
+	case isInteger(tSrc.Kind()) && tDst.Kind() == String:
+	case tSrc.Kind() == Slice && (tDst.Kind() == Array || tDst.Kind() == Pointer):
+	case tSrc.ConvertibleTo(tDst):
+		return func(dst, src Value) {
+			dst.Set(src.Convert(tDst))
+		}, true
+	}
+	return nil, false
+}
+
+// kindAssign returns a fast path for assignments between the kinds that dominate real workloads, going through the typed Value accessors rather than Set and Convert, which check types and allocate intermediate Values.
+// Returns nil if there is none. The results match those of Convert, which truncates integers and rounds floats the same way.
+func kindAssign(kDst, kSrc Kind) func(dst, src Value) {
+	switch {
+	case kDst == String && kSrc == String:
+		return func(dst, src Value) {
+			dst.SetString(src.String())
+		}
+	case kDst == Bool && kSrc == Bool:
+		return func(dst, src Value) {
+			dst.SetBool(src.Bool())
+		}
+	case (kDst == Float32 || kDst == Float64) && (kSrc == Float32 || kSrc == Float64):
+		return func(dst, src Value) {
+			dst.SetFloat(src.Float())
+		}
+	case isInteger(kDst) && isInteger(kSrc):
+		switch dstSigned, srcSigned := kDst < Uint, kSrc < Uint; {
+		case dstSigned && srcSigned:
+			return func(dst, src Value) {
+				dst.SetInt(src.Int())
+			}
+		case dstSigned:
+			return func(dst, src Value) {
+				dst.SetInt(int64(src.Uint()))
+			}
+		case srcSigned:
+			return func(dst, src Value) {
+				dst.SetUint(uint64(src.Int()))
+			}
+		}
+		return func(dst, src Value) {
+			dst.SetUint(src.Uint())
+		}
+	}
+	return nil
+}
+
+func isInteger(k Kind) bool {
+	return (k >= Int && k <= Int64) || (k >= Uint && k <= Uintptr)
+}
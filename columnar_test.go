@@ -0,0 +1,91 @@
+package conv
+
+import (
+	"errors"
+	"math"
+	. "reflect"
+	"testing"
+)
+
+func TestColumns(t *testing.T) {
+	type row struct {
+		A int
+		B string
+		C float32
+	}
+	type cols struct {
+		A []int
+		B []string
+		C []float64
+		D []bool
+	}
+
+	rows := []row{{1, "a", 1.5}, {2, "b", 2.5}, {3, "c", 3.5}}
+
+	c := NewConversion(ColumnConverter[cols])
+	o, err := c.Call(rows)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !DeepEqual(o.A, []int{1, 2, 3}) || !DeepEqual(o.B, []string{"a", "b", "c"}) || !DeepEqual(o.C, []float64{1.5, 2.5, 3.5}) || o.D != nil {
+		t.Error("transpose failed", o)
+	}
+
+	inv := NewInversion(ColumnInverter[cols])
+	o.B = o.B[:1]
+	back, err := As[[]row](inv, o)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := []row{{1, "a", 1.5}, {2, "", 2.5}, {3, "", 3.5}}
+	if !DeepEqual(back, exp) {
+		t.Error("inverse transpose failed", back)
+	}
+
+	arr, err := As[[2]row](inv, o)
+	if err != nil || arr != [2]row{{1, "a", 1.5}, {2, "", 2.5}} {
+		t.Error("array inverse failed", arr, err)
+	}
+
+	if _, err := c.Call([]int{1}); !errors.Is(err, ErrInvalid) {
+		t.Error("non-struct rows should not build")
+	}
+}
+
+func TestKindAssign(t *testing.T) {
+	type str string
+	type raw []byte
+	values := []any{
+		"s", str("t"), true, false,
+		int(-1), int8(-128), int16(300), int32(-70000), int64(math.MinInt64),
+		uint(1 << 40), uint8(255), uint16(65535), uint32(1 << 31), uint64(math.MaxUint64), uintptr(7),
+		float32(1.5), float64(1e300), math.Inf(-1),
+		[]byte{1}, raw{2},
+	}
+	for _, src := range values {
+		for _, d := range values {
+			tDst, tSrc := TypeOf(d), TypeOf(src)
+			set, ok := assignFunc(tDst, tSrc)
+			if ok != (tSrc.ConvertibleTo(tDst) && !(isInteger(tSrc.Kind()) && tDst.Kind() == String)) {
+				t.Errorf("%v to %v: wrong coverage %v", tSrc, tDst, ok)
+				continue
+			}
+			if !ok {
+				continue
+			}
+			o := New(tDst).Elem()
+			set(o, ValueOf(src))
+			if exp := ValueOf(src).Convert(tDst); !DeepEqual(o.Interface(), exp.Interface()) {
+				t.Errorf("%v to %v: expected %v, got %v", tSrc, tDst, exp, o)
+			}
+		}
+	}
+}
+
+func BenchmarkAssignMapping(b *testing.B) {
+	fn, _ := AssignMapping(MappingType(TypeEval[int64](), TypeEval[int32]()))
+	dst, src := New(TypeEval[int64]()).Elem(), ValueOf(int32(3))
+	for i := 0; i < b.N; i++ {
+		fn(dst, src, nil)
+	}
+}
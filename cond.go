@@ -0,0 +1,69 @@
+package conv
+
+import (
+	. "reflect"
+)
+
+// ConverterIf returns a Builder that dispatches on the runtime value, rather than only its type: values that "pred" returns true for are converted by the Converter that "then" builds, and others by the one "otherwise" builds.
+// Covers the types that both Builders cover.
+//
+// Lets a single Scheme entry tell apart values of the same type, such as strings holding timestamps from other strings.
+func ConverterIf[T any](pred func(Value) bool, then, otherwise Builder[Converter[T]]) Builder[Converter[T]] {
+	return func(t Type) (Converter[T], bool) {
+		a, ok := then(t)
+		if !ok {
+			return nil, false
+		}
+		b, ok := otherwise(t)
+		if !ok {
+			return nil, false
+		}
+		return func(v Value) (T, error) {
+			if pred(v) {
+				return a(v)
+			}
+			return b(v)
+		}, true
+	}
+}
+
+// InverterIf is the Inverter counterpart of ConverterIf, dispatching on the inverted value.
+// Useful for interface destinations, where the value decides the dynamic type, such as inverting strings that parse as RFC 3339 timestamps into time.Time, and others into string.
+func InverterIf[T any](pred func(T) bool, then, otherwise Builder[Inverter[T]]) Builder[Inverter[T]] {
+	return func(t Type) (Inverter[T], bool) {
+		a, ok := then(t)
+		if !ok {
+			return nil, false
+		}
+		b, ok := otherwise(t)
+		if !ok {
+			return nil, false
+		}
+		return func(v T) (Value, error) {
+			if pred(v) {
+				return a(v)
+			}
+			return b(v)
+		}, true
+	}
+}
+
+// MappingIf is the Mapping counterpart of ConverterIf, dispatching on the source value.
+func MappingIf(pred func(Value) bool, then, otherwise Builder[Mapping]) Builder[Mapping] {
+	return func(t Type) (Mapping, bool) {
+		a, ok := then(t)
+		if !ok {
+			return nil, false
+		}
+		b, ok := otherwise(t)
+		if !ok {
+			return nil, false
+		}
+		return func(dst, src Value, s *State) error {
+			if pred(src) {
+				return a(dst, src, s)
+			}
+			return b(dst, src, s)
+		}, true
+	}
+}
@@ -0,0 +1,93 @@
+package conv
+
+import (
+	"errors"
+	. "reflect"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestConverterIf(t *testing.T) {
+	ints := func(t Type) (Converter[string], bool) {
+		return func(v Value) (string, error) {
+			return strconv.FormatInt(v.Int(), 10), nil
+		}, t.Kind() == Int
+	}
+	negative := func(t Type) (Converter[string], bool) {
+		return func(v Value) (string, error) {
+			return "", errors.New("negative")
+		}, true
+	}
+	c := NewConversion(ConverterIf(func(v Value) bool {
+		return v.Int() >= 0
+	}, ints, negative))
+
+	if o, err := c.Call(3); err != nil || o != "3" {
+		t.Error("wrong result", o, err)
+	}
+	if _, err := c.Call(-3); err == nil {
+		t.Error("negative values should fail")
+	}
+	if _, err := c.Call("x"); !errors.Is(err, ErrInvalid) {
+		t.Error("types not covered by both should be uncovered", err)
+	}
+
+	nils := func(t Type) (Converter[string], bool) {
+		return func(v Value) (string, error) {
+			return "nil", nil
+		}, true
+	}
+	c = NewConversion(ConverterIf(func(v Value) bool { return v.IsNil() }, nils, nils), WithNilAccepted(nil))
+	if o, err := c.Call((*int)(nil)); err != nil || o != "nil" {
+		t.Error("nils should be accepted", o, err)
+	}
+}
+
+func TestInverterIf(t *testing.T) {
+	tAny := TypeEval[any]()
+	parse := func(t Type) (Inverter[string], bool) {
+		return func(s string) (Value, error) {
+			tm, err := time.Parse(time.RFC3339, s)
+			return ValueOf(tm), err
+		}, t == tAny
+	}
+	plain := func(t Type) (Inverter[string], bool) {
+		return func(s string) (Value, error) {
+			return ValueOf(s), nil
+		}, t == tAny
+	}
+	inv := NewInversion(InverterIf(func(s string) bool {
+		_, err := time.Parse(time.RFC3339, s)
+		return err == nil
+	}, parse, plain))
+
+	if o, err := As[any](inv, "2024-01-02T03:04:05Z"); err != nil || o.(time.Time).Year() != 2024 {
+		t.Error("timestamp should invert to time", o, err)
+	}
+	if o, err := As[any](inv, "hello"); err != nil || o != "hello" {
+		t.Error("plain string should stay a string", o, err)
+	}
+}
+
+func TestMappingIf(t *testing.T) {
+	set := func(v string) Builder[Mapping] {
+		return func(t Type) (Mapping, bool) {
+			return func(dst, src Value, s *State) error {
+				dst.SetString(v)
+				return nil
+			}, t.Out(0).Kind() == String
+		}
+	}
+	m := NewMapper(MappingIf(func(v Value) bool {
+		return v.Len() > 3
+	}, set("long"), set("short")))
+
+	var o string
+	if err := m.Map(&o, "abcdef"); err != nil || o != "long" {
+		t.Error("wrong result", o, err)
+	}
+	if err := m.Map(&o, "ab"); err != nil || o != "short" {
+		t.Error("wrong result", o, err)
+	}
+}
@@ -3,15 +3,31 @@
 // This package explicitly imports all "reflect" identifiers.
 //
 // Builder, Scheme and Library are the core types of this package.
+//
+// Building with the conv_safe or purego tag excludes package unsafe, for environments that forbid it. Fast paths that rely on it fall back to plain reflection, and Layout.Memory is left out.
 package conv
 
 import (
 	"errors"
+	"fmt"
 	. "reflect"
+	"sort"
 	"sync"
+	"sync/atomic"
+	"time"
 )
 
-var ErrInvalid = errors.New("invalid conversion")
+// Failure classes, shared by the Builders of this package and available to user ones, so that callers can tell failures apart with errors.Is.
+var (
+	ErrInvalid         = errors.New("invalid conversion")
+	ErrOverflow        = errors.New("value out of range")
+	ErrPrecisionLoss   = errors.New("value not exactly representable")
+	ErrNilSource       = errors.New("nil source")
+	ErrUnsupportedKind = errors.New("unsupported kind")
+	ErrMissingField    = errors.New("missing field")
+	ErrTooLarge        = errors.New("length over limit")
+	ErrUnknownField    = errors.New("unknown field")
+)
 
 // A Builder is used to obtain conversion functions for a particular type. It must return false if it cannot handle the input type.
 // Multiple Builders should be used together, each one covering a different case, making code more modular.
@@ -28,84 +44,545 @@ type Inverter[T any] func(T) (Value, error)
 // A Library wraps a Builder, caching build results for future reuse.
 // This favors complex Builders that return optimized functions for a particular type, as the build time must only be spent once for each unique encountered type.
 // Safe for concurrent use.
+//
+// The cache is copied on write: looking up a cached type is a single atomic load followed by a map read, without locking or allocating, while each newly cached type copies the whole cache.
+// This suits the usual pattern of a few distinct types, each looked up many times.
+// The last type looked up is also checked before the cache, as call sites usually look up runs of the same type.
+//
+// Since functions are built once per type, Builders configured through fields, such as CSV, Time or Enums, should be fully configured before use: later changes only reach the types built after them, unless the affected types are invalidated.
+//
+// By default, every type ever looked up stays cached. Processes that see an unbounded stream of types, such as from reflect.StructOf, should cap the cache with SetCacheLimit, or trim it periodically with Purge.
 type Library[T any] struct {
-	m   map[Type]T
-	mux sync.RWMutex
+	m    atomic.Pointer[map[Type]*libraryEntry[T]] // immutable once stored
+	mux  sync.Mutex                                // serializes writers
+	last atomic.Pointer[libraryEntry[T]]           // inline cache of the last looked up entry
 
 	b    Builder[T]
 	zero T // default value to use, if one cannot be built
+
+	fast atomic.Value // used by Conversion, to hold Register fast paths
+
+	metrics Metrics         // optional, set before use
+	nils    NilPolicy       // used by Conversion
+	accept  func(Type) bool // used by Conversion, to set libraryEntry.nils
+	depth   int             // used by Mapper
+	share   bool            // used by Mapper
+	strict  bool            // used by Mapper
+	lossy   bool            // used by Mapper
+	limit   int             // maximum number of cached types, if positive
+
+	ring []*libraryEntry[T] // evictable entries, if limited, in clock order; guarded by mux
+	hand int                // next ring position to consider for eviction
+}
+
+type libraryEntry[T any] struct {
+	t     Type
+	v     T
+	ok    bool        // false if the zero value was used
+	fixed bool        // registered rather than built; kept by invalidation
+	nils  bool        // nil pointers go to the function, regardless of the nil policy; used by Conversion
+	stale atomic.Bool // set once replaced in the cache, such as by Register
+	used  atomic.Bool // set by lookups, cleared by eviction sweeps and Purge
+}
+
+// touch marks "x" as used, skipping the store if already marked, so that concurrent lookups don't contend for its cache line.
+func (x *libraryEntry[T]) touch() {
+	if !x.used.Load() {
+		x.used.Store(true)
+	}
 }
 
 // "zero" will be used as default when the wrapped builder doesn't cover a particular type.
 func NewLibrary[T any](b Builder[T], zero T) *Library[T] {
-	return &Library[T]{
-		m:    make(map[Type]T),
+	x := &Library[T]{
 		b:    b,
 		zero: zero,
 	}
+	m := make(map[Type]*libraryEntry[T])
+	x.m.Store(&m)
+	return x
 }
 
 // Get returns the cached function for type "t". If this is the first time that the type is encountered, builds and caches the return value first.
 func (x *Library[T]) Get(t Type) T {
-	x.mux.RLock()
+	o, _ := x.Lookup(t)
+	return o
+}
+
+// Lookup is the same as Get, but also returns false if the wrapped builder doesn't cover "t", and the zero value is returned instead.
+func (x *Library[T]) Lookup(t Type) (T, bool) {
+	e := x.entry(t)
+	return e.v, e.ok
+}
 
-	if o, ok := x.m[t]; ok {
-		x.mux.RUnlock()
-		return o
+// entry returns the cache entry of "t", building it first if needed.
+func (x *Library[T]) entry(t Type) *libraryEntry[T] {
+	if e := x.last.Load(); e != nil && e.t == t && !e.stale.Load() {
+		e.touch()
+		return e
+	}
+	if e, ok := (*x.m.Load())[t]; ok {
+		e.touch()
+		x.last.Store(e)
+		return e
 	}
 
-	x.mux.RUnlock()
 	x.mux.Lock()
+	defer x.mux.Unlock()
 
 	// check again, in case another goroutine locked just before this one, for the same reason
-	if o, ok := x.m[t]; ok {
-		x.mux.Unlock()
-		return o
+	if e, ok := (*x.m.Load())[t]; ok {
+		return e
 	}
 
-	o, ok := x.b(t)
+	o, ok := x.build(t)
 	if !ok {
 		o = x.zero
 	}
-	x.m[t] = o
+	e := &libraryEntry[T]{
+		t:    t,
+		v:    o,
+		ok:   ok,
+		nils: x.acceptsNil(t),
+	}
+	e.used.Store(true)
+	switch {
+	case x.limit <= 0:
+		x.store(e)
+	case len(x.ring) < x.limit:
+		x.store(e)
+		x.ring = append(x.ring, e)
+	default:
+		// the new entry takes the place of the evicted one, behind the hand, so that it is considered last
+		x.store(e, x.victim())
+		x.ring[x.hand] = e
+		x.hand++
+	}
+
+	return e
+}
+
+// victim picks the next entry to evict, by the clock algorithm: the hand sweeps the ring, clearing the used marks, and stops at the first entry that has not been used since it last passed.
+// An approximation of least recently used, which doesn't cost lookups more than a flag.
+// Must be called with the write lock held, on a non empty ring.
+func (x *Library[T]) victim() *libraryEntry[T] {
+	for {
+		if x.hand >= len(x.ring) {
+			x.hand = 0
+		}
+		e := x.ring[x.hand]
+		if !e.used.Load() {
+			return e
+		}
+		e.used.Store(false)
+		x.hand++
+	}
+}
+
+// SetCacheLimit caps the number of types that the Library caches functions for at "n", evicting the least recently used ones to make room for new ones.
+// Evicted types are built again on their next lookup. Registered functions are never evicted, and don't count towards the limit.
+// Zero or less means no limit, which is the default.
+// Must be called before the Library is used.
+func (x *Library[T]) SetCacheLimit(n int) {
+	x.limit = n
+}
+
+// Len returns the number of cached types, including those that the wrapped builder doesn't cover.
+func (x *Library[T]) Len() int {
+	return len(*x.m.Load())
+}
+
+// Purge drops the cached functions that have not been looked up since the previous Purge, returning their number.
+// Meant to be called periodically, such as from a ticker, to release types that have fallen out of use, without capping the cache.
+// Registered functions are kept. Eviction sweeps also count as a Purge for the entries they pass.
+func (x *Library[T]) Purge() int {
+	return x.drop(func(e *libraryEntry[T]) bool {
+		return !e.used.Swap(false)
+	})
+}
 
-	x.mux.Unlock()
+// Range calls "fn" for each cached type and its function, in no particular order, until it returns false.
+// Types that the wrapped builder doesn't cover are visited with the zero value; Lookup tells them apart.
+// Iterates over a snapshot of the cache, so "fn" may use the Library.
+func (x *Library[T]) Range(fn func(Type, T) bool) {
+	for t, e := range *x.m.Load() {
+		if !fn(t, e.v) {
+			return
+		}
+	}
+}
 
+// Types returns the cached types, sorted by their string representation.
+func (x *Library[T]) Types() []Type {
+	m := *x.m.Load()
+	o := make([]Type, 0, len(m))
+	for t := range m {
+		o = append(o, t)
+	}
+	sortTypes(o)
 	return o
 }
 
+// sortTypes sorts "types" by their string representation, for deterministic output.
+func sortTypes(types []Type) {
+	sort.Slice(types, func(i, j int) bool {
+		return types[i].String() < types[j].String()
+	})
+}
+
+// store publishes a copy of the cache, with entry "e" set for its type, and the "evicted" entries removed.
+// Must be called with the write lock held.
+func (x *Library[T]) store(e *libraryEntry[T], evicted ...*libraryEntry[T]) {
+	old := *x.m.Load()
+	m := make(map[Type]*libraryEntry[T], len(old)+1)
+	for k, v := range old {
+		m[k] = v
+	}
+	for _, v := range evicted {
+		delete(m, v.t)
+	}
+	m[e.t] = e
+	x.m.Store(&m)
+	// readers may still hold the replaced entries, and make them the last one after this; marking them keeps them from using them
+	for _, v := range evicted {
+		v.stale.Store(true)
+	}
+	if prev, ok := old[e.t]; ok {
+		prev.stale.Store(true)
+		if x.limit > 0 && !prev.fixed {
+			x.compact()
+		}
+	}
+}
+
+// drop removes the non registered entries that "fn" returns true for from the cache, returning their number.
+func (x *Library[T]) drop(fn func(*libraryEntry[T]) bool) int {
+	x.mux.Lock()
+	defer x.mux.Unlock()
+
+	old := *x.m.Load()
+	m := make(map[Type]*libraryEntry[T], len(old))
+	var dropped []*libraryEntry[T]
+	for t, e := range old {
+		if !e.fixed && fn(e) {
+			dropped = append(dropped, e)
+			continue
+		}
+		m[t] = e
+	}
+	if dropped == nil {
+		return 0
+	}
+	x.m.Store(&m)
+	for _, e := range dropped {
+		e.stale.Store(true)
+	}
+	x.compact()
+	return len(dropped)
+}
+
+// compact removes the stale entries from the eviction ring.
+// Must be called with the write lock held.
+func (x *Library[T]) compact() {
+	ring := x.ring[:0]
+	for i, e := range x.ring {
+		if e.stale.Load() {
+			if i < x.hand {
+				x.hand--
+			}
+			continue
+		}
+		ring = append(ring, e)
+	}
+	for i := len(ring); i < len(x.ring); i++ {
+		x.ring[i] = nil
+	}
+	x.ring = ring
+}
+
+// Invalidate drops the cached functions of "types", which are built again on their next lookup.
+// Lets long running processes rebuild functions after reconfiguring their Builders, or release types they no longer use, such as those of unloaded plugins.
+// Functions registered rather than built, such as through Register, are kept.
+func (x *Library[T]) Invalidate(types ...Type) {
+	set := make(map[Type]bool, len(types))
+	for _, t := range types {
+		set[t] = true
+	}
+	x.InvalidateFunc(func(t Type) bool {
+		return set[t]
+	})
+}
+
+// InvalidateFunc is the same as Invalidate, for the cached types that "fn" returns true for.
+// Mappers are keyed by MappingType, the In and Out types of which are the source and destination types.
+func (x *Library[T]) InvalidateFunc(fn func(Type) bool) {
+	x.drop(func(e *libraryEntry[T]) bool {
+		return fn(e.t)
+	})
+}
+
+// Clear drops all cached functions, as Invalidate does.
+func (x *Library[T]) Clear() {
+	x.InvalidateFunc(func(Type) bool {
+		return true
+	})
+}
+
 // A Conversion is a Library specialized in standard Converter functions (from multiple types to a specific one).
 // Users can define their own Converter and Conversion variants, if the standard ones don't suit needs.
 type Conversion[T any] Library[Converter[T]]
 
-func NewConversion[T any](b Builder[Converter[T]]) *Conversion[T] {
-	return (*Conversion[T])(NewLibrary[Converter[T]](b, converterInvalid[T]))
+func NewConversion[T any](b Builder[Converter[T]], opts ...Option) *Conversion[T] {
+	o := applyOptions(opts)
+	if o.numeric {
+		b = NumericConverterWith(b, o.policy)
+	}
+	lib := NewLibrary[Converter[T]](b, converterInvalid[T])
+	lib.configure(o)
+	return (*Conversion[T])(lib)
+}
+
+// Extend returns a new Conversion, trying the "extra" Builders first, in order, and falling back to this Conversion for the types they don't claim.
+// Functions already built by this Conversion, including registered ones, are shared rather than rebuilt, so variants of a base scheme, such as strict and lenient ones, are cheap to derive.
+// The new Conversion keeps the options of this one, and its functions registered so far, which take precedence over the "extra" Builders, as they do over built ones.
+func (x *Conversion[T]) Extend(extra ...Builder[Converter[T]]) *Conversion[T] {
+	base := (*Library[Converter[T]])(x)
+	scheme := append(Scheme[Converter[T]]{}, extra...)
+	lib := NewLibrary[Converter[T]](func(t Type) (Converter[T], bool) {
+		if e, ok := (*base.m.Load())[t]; ok && e.fixed {
+			return e.v, true
+		}
+		if o, ok := scheme.Build(t); ok {
+			return o, true
+		}
+		return base.Lookup(t)
+	}, base.zero)
+
+	lib.metrics = base.metrics
+	lib.nils, lib.accept = base.nils, base.accept
+	lib.depth, lib.share, lib.strict, lib.lossy = base.depth, base.share, base.strict, base.lossy
+	lib.limit = base.limit
+	if fast, ok := base.fast.Load().([]conversionFast[T]); ok {
+		lib.fast.Store(fast)
+	}
+	return (*Conversion[T])(lib)
 }
 
 func (x *Conversion[T]) Call(v any) (T, error) {
-	f := (*Library[Converter[T]])(x).Get(TypeOf(v))
-	return f(ValueOf(v))
+	if m := (*Library[Converter[T]])(x).metrics; m != nil {
+		start := time.Now()
+		o, err := x.call(v)
+		if t := TypeOf(v); t != nil {
+			m.Convert(t, time.Since(start), err)
+		}
+		return o, err
+	}
+	return x.call(v)
+}
+
+func (x *Conversion[T]) call(v any) (T, error) {
+	lib := (*Library[Converter[T]])(x)
+	if v == nil {
+		return x.nilValue(nil)
+	}
+	if fast, ok := lib.fast.Load().([]conversionFast[T]); ok {
+		for _, f := range fast {
+			if o, ok, err := f.fn(v); ok {
+				return o, err
+			}
+		}
+	}
+
+	rv := ValueOf(v)
+	e := lib.entry(rv.Type())
+	if !e.nils && isNil(rv) {
+		return x.nilValue(rv.Type())
+	}
+	return e.v(rv)
+}
+
+// MustCall is the same as Call, but panics with the error on failure, for initialization code and tests.
+func (x *Conversion[T]) MustCall(v any) T {
+	o, err := x.Call(v)
+	if err != nil {
+		panic(err)
+	}
+	return o
+}
+
+// CallInto is the same as Call, but writes the result into "dst", which is left unchanged on failure.
+// Lets hot loops reuse their storage, such as the elements of a preallocated slice.
+func (x *Conversion[T]) CallInto(dst *T, v any) error {
+	o, err := x.Call(v)
+	if err != nil {
+		return err
+	}
+	*dst = o
+	return nil
+}
+
+// For returns a Handle bound to the Converter of type "t", for callers that convert many values of the same known type, such as row scanners and stream processors.
+// Later Register calls for "t" don't affect the returned Handle.
+func (x *Conversion[T]) For(t Type) Handle[T] {
+	e := (*Library[Converter[T]])(x).entry(t)
+	return Handle[T]{
+		x:    x,
+		t:    t,
+		fn:   e.v,
+		nils: e.nils,
+	}
+}
+
+// A Handle is a Converter prebound to a type, as returned by Conversion.For.
+// Its calls skip the type lookup, and must be given values of exactly that type. Other values are not detected, and will likely make the Converter fail or panic.
+type Handle[T any] struct {
+	x    *Conversion[T]
+	t    Type
+	fn   Converter[T]
+	nils bool // nil pointers go to fn
+}
+
+// Call converts "v", which must be of the Handle type.
+func (x Handle[T]) Call(v any) (T, error) {
+	return x.CallValue(ValueOf(v))
+}
+
+// CallValue converts "v", which must be of the Handle type. Avoids boxing values already held as a Value, such as struct fields.
+// Nil pointers and interfaces are subject to the nil policy of the Conversion, as with Conversion.Call.
+func (x Handle[T]) CallValue(v Value) (T, error) {
+	if !x.nils && isNil(v) {
+		return x.x.nilValue(x.t)
+	}
+	return x.fn(v)
+}
+
+// Type returns the type that the Handle is bound to.
+func (x Handle[T]) Type() Type {
+	return x.t
+}
+
+type conversionFast[T any] struct {
+	t  Type
+	fn func(any) (T, bool, error) // returns false if the input is not of type t
+}
+
+// Register makes "fn" the Converter of S, taking precedence over built ones.
+// Calls with values of dynamic type S dispatch to "fn" directly, through a type assertion, skipping reflection entirely.
+// Meant for the few hottest conversions, as each registration adds a check to all calls.
+// Nil pointers are subject to the nil policy, as with built functions.
+func Register[S any, T any](x *Conversion[T], fn func(S) (T, error)) {
+	lib := (*Library[Converter[T]])(x)
+	t := TypeEval[S]()
+
+	lib.mux.Lock()
+	defer lib.mux.Unlock()
+
+	nils := lib.acceptsNil(t)
+	lib.store(&libraryEntry[Converter[T]]{
+		t: t,
+		v: func(v Value) (T, error) {
+			return fn(v.Interface().(S))
+		},
+		ok:    true,
+		fixed: true,
+		nils:  nils,
+	})
+
+	// values held in interfaces never have interface dynamic types
+	if t.Kind() == Interface {
+		return
+	}
+	var zero S
+	guard := t.Kind() == Pointer && !nils
+	f := conversionFast[T]{
+		t: t,
+		fn: func(v any) (T, bool, error) {
+			s, ok := v.(S)
+			if !ok {
+				var o T
+				return o, false, nil
+			}
+			if guard && v == any(zero) {
+				o, err := x.nilValue(t)
+				return o, true, err
+			}
+			o, err := fn(s)
+			return o, true, err
+		},
+	}
+	old, _ := lib.fast.Load().([]conversionFast[T])
+	fast := make([]conversionFast[T], 0, len(old)+1)
+	for _, g := range old {
+		if g.t != t {
+			fast = append(fast, g)
+		}
+	}
+	lib.fast.Store(append(fast, f))
 }
 
 // A Inversion is a Library specialized in standard Inverter functions (from one specific type to multiple others).
 type Inversion[T any] Library[Inverter[T]]
 
-func NewInversion[T any](b Builder[Inverter[T]]) *Inversion[T] {
-	return (*Inversion[T])(NewLibrary[Inverter[T]](b, inverterInvalid[T]))
+func NewInversion[T any](b Builder[Inverter[T]], opts ...Option) *Inversion[T] {
+	o := applyOptions(opts)
+	if o.numeric {
+		b = NumericInverterWith(b, o.policy)
+	}
+	lib := NewLibrary[Inverter[T]](b, inverterInvalid[T])
+	lib.configure(o)
+	return (*Inversion[T])(lib)
+}
+
+// Invert converts "v" into a Value of type "t", for destination types only known at run time, such as through TypeByName.
+func (x *Inversion[T]) Invert(t Type, v T) (Value, error) {
+	f, ok := (*Library[Inverter[T]])(x).Lookup(t)
+	o, err := f(v)
+	if !ok && err == ErrInvalid {
+		err = invertInvalid[T](t)
+	}
+	return o, err
 }
 
 // As is the equivalent of the Conversion.Call method, but Go methods cannot currently take type parameters.
 func As[S any, T any](x *Inversion[T], v T) (S, error) {
 	t := TypeOf((*S)(nil)).Elem()
-	f := (*Library[Inverter[T]])(x).Get(t)
+	f, ok := (*Library[Inverter[T]])(x).Lookup(t)
 	ov, err := f(v)
 	if err != nil {
+		if !ok && err == ErrInvalid {
+			err = invertInvalid[T](t)
+		}
 		var o S
 		return o, err
 	}
 	return ov.Interface().(S), nil
 }
 
+// MustAs is the same as As, but panics with the error on failure, for initialization code and tests.
+func MustAs[S any, T any](x *Inversion[T], v T) S {
+	o, err := As[S](x, v)
+	if err != nil {
+		panic(err)
+	}
+	return o
+}
+
+// AsInto is the same as As, but writes the result into "dst", which is left unchanged on failure.
+// Avoids the interface boxing of the result that As incurs, so that hot loops can run without per call allocations, given Inverters that don't allocate either.
+func AsInto[S any, T any](x *Inversion[T], dst *S, v T) error {
+	t := TypeEval[S]()
+	f, ok := (*Library[Inverter[T]])(x).Lookup(t)
+	ov, err := f(v)
+	if err != nil {
+		if !ok && err == ErrInvalid {
+			err = invertInvalid[T](t)
+		}
+		return err
+	}
+	ValueOf(dst).Elem().Set(ov)
+	return nil
+}
+
 // A Scheme is a collection of Builders to be used together.
 // Each member will be called sequentially, in the order they were added, until one return true.
 type Scheme[T any] []Builder[T]
@@ -168,113 +645,17 @@ func TypeEval[T any]() Type {
 
 func converterInvalid[T any](v Value) (T, error) {
 	var o T
-	return o, ErrInvalid
-}
-
-func inverterInvalid[T any](v T) (Value, error) {
-	return Value{}, ErrInvalid
-}
-
-/*
-func Implicit(tIn, tOut Type) (Converter, bool) {
-	if tIn.AssignableTo(tOut) {
-		return func(v Value) (Value, error) {
-			o := New(tOut).Elem()
-			o.Set(v)
-			return o, nil
-		}, true
-	}
-
-	if tIn.ConvertibleTo(tOut) {
-		return func(v Value) (Value, error) {
-			o := v.Convert(tOut)
-			return o, nil
-		}, true
-	}
-
-	// check here, because the standard Go logic should already cover most common cases
-	if !canImplicit(tIn, tOut) {
-		return nil, false
-	}
-
-	switch tIn.Kind() {
-	case Func, Pointer:
-		// can directly get unsafe.Pointer of these types
-		return func(v Value) (Value, error) {
-			ptr := v.UnsafePointer()
-			o := NewAt(tOut, ptr).Elem()
-			return o, nil
-		}, true
+	if !v.IsValid() {
+		return o, ErrInvalid
 	}
-
-	// unaddressable types must be copied
-	return func(v Value) (Value, error) {
-		oPtr := New(tIn)
-		oPtr.Elem().Set(v)
-		ptr := oPtr.UnsafePointer()
-		o := NewAt(tOut, ptr).Elem()
-		return o, nil
-	}, true
+	return o, fmt.Errorf("%s into %s: %w", describeType(v.Type()), describeType(TypeEval[T]()), ErrInvalid)
 }
 
-// canImplicit returns true if tIn and tOut share the same memory representation
-func canImplicit(tIn, tOut Type) bool {
-	k := tIn.Kind()
-	if k == Interface {
-		return false
-	}
-	if k != tOut.Kind() {
-		return false
-	}
-
-	switch k {
-	case Array:
-		if tIn.Len() != tOut.Len() {
-			return false
-		}
-		return canImplicit(tIn.Elem(), tOut.Elem())
-	case Chan:
-		if tIn.ChanDir() != tOut.ChanDir() {
-			return false
-		}
-		return canImplicit(tIn.Elem(), tOut.Elem())
-	case Func:
-		nIn := tIn.NumIn()
-		nOut := tIn.NumOut()
-		if nIn != tOut.NumIn() || nOut != tOut.NumOut() {
-			return false
-		}
-		for i := 0; i < nIn; i++ {
-			if !canImplicit(tIn.In(i), tOut.In(i)) {
-				return false
-			}
-		}
-		for i := 0; i < nOut; i++ {
-			if !canImplicit(tIn.Out(i), tOut.Out(i)) {
-				return false
-			}
-		}
-	case Map:
-		if !canImplicit(tIn.Key(), tOut.Key()) {
-			return false
-		}
-		fallthrough
-	case Pointer:
-		fallthrough
-	case Slice:
-		return canImplicit(tIn.Elem(), tOut.Elem())
-	case Struct:
-		n := tIn.NumField()
-		if n != tOut.NumField() {
-			return false
-		}
-		for i := 0; i < n; i++ {
-			if !canImplicit(tIn.Field(i).Type, tOut.Field(i).Type) {
-				return false
-			}
-		}
-	}
+// invertInvalid returns the error of inverting T into uncovered type "t", as the zero Inverter of Inversions cannot know it.
+func invertInvalid[T any](t Type) error {
+	return fmt.Errorf("%s into %s: %w", describeType(TypeEval[T]()), describeType(t), ErrInvalid)
+}
 
-	return true
+func inverterInvalid[T any](v T) (Value, error) {
+	return Value{}, ErrInvalid
 }
-*/
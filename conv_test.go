@@ -1,7 +1,10 @@
 package conv
 
 import (
+	"errors"
 	. "reflect"
+	"sync"
+	"sync/atomic"
 	"testing"
 )
 
@@ -83,3 +86,463 @@ func TestInversion(t *testing.T) {
 		t.Error("slice failed", err)
 	}
 }
+
+func TestLibraryLookup(t *testing.T) {
+	lib := NewLibrary(func(t Type) (int, bool) {
+		return 1, t.Kind() == Int
+	}, -1)
+
+	if o, ok := lib.Lookup(TypeOf(0)); o != 1 || !ok {
+		t.Error("int should be covered")
+	}
+	if o, ok := lib.Lookup(TypeOf("")); o != -1 || ok {
+		t.Error("string should not be covered")
+	}
+	if o, ok := lib.Lookup(TypeOf("")); o != -1 || ok {
+		t.Error("cached miss should not be covered")
+	}
+}
+
+func TestLibraryInvalidate(t *testing.T) {
+	built := make(map[Type]int)
+	c := NewConversion(func(t Type) (Converter[int], bool) {
+		built[t]++
+		n := built[t]
+		return func(Value) (int, error) {
+			return n, nil
+		}, true
+	})
+	Register(c, func(v bool) (int, error) {
+		return -1, nil
+	})
+	lib := (*Library[Converter[int]])(c)
+
+	for _, v := range []any{0, "", 1.5, true} {
+		c.Call(v)
+	}
+	lib.Invalidate(TypeOf(0))
+	if o, _ := c.Call(0); o != 2 {
+		t.Error("int should be rebuilt", o)
+	}
+	if o, _ := c.Call(""); o != 1 {
+		t.Error("string should stay cached", o)
+	}
+
+	lib.InvalidateFunc(func(t Type) bool {
+		return t.Kind() == String
+	})
+	if o, _ := c.Call(""); o != 2 {
+		t.Error("string should be rebuilt", o)
+	}
+	if o, _ := c.Call(0); o != 2 {
+		t.Error("int should stay cached", o)
+	}
+
+	lib.Clear()
+	for _, tc := range []struct {
+		v   any
+		exp int
+	}{
+		{0, 3},
+		{"", 3},
+		{1.5, 2},
+		{true, -1}, // registered
+	} {
+		if o, _ := c.Call(tc.v); o != tc.exp {
+			t.Errorf("%v: expected %d, got %d", tc.v, tc.exp, o)
+		}
+	}
+	if n := len(lib.Types()); n != 4 {
+		t.Error("wrong cached type count", n)
+	}
+}
+
+func TestLibraryEvict(t *testing.T) {
+	built := make(map[Type]int)
+	lib := NewLibrary(func(t Type) (int, bool) {
+		built[t]++
+		return 1, true
+	}, 0)
+	lib.SetCacheLimit(2)
+
+	a, b, c, d := TypeOf(0), TypeOf(""), TypeOf(1.5), TypeOf(false)
+	lib.Get(a)
+	lib.Get(b)
+	lib.Get(c) // sweeps both, then evicts a
+	lib.Get(c)
+	lib.Get(d) // evicts b, which has not been used since the sweep
+	lib.Get(c)
+	if n := lib.Len(); n != 2 {
+		t.Error("wrong cached type count", n)
+	}
+	for typ, exp := range map[Type]int{a: 1, b: 1, c: 1, d: 1} {
+		if built[typ] != exp {
+			t.Error("wrong build count", typ, built[typ])
+		}
+	}
+	lib.Get(b)
+	lib.Get(a)
+	if built[a] != 2 || built[b] != 2 {
+		t.Error("evicted types should be rebuilt", built)
+	}
+
+	// a Conversion with a limit keeps its registered functions
+	conv := NewConversion(func(t Type) (Converter[int], bool) {
+		return func(Value) (int, error) {
+			return 1, nil
+		}, true
+	}, WithCacheLimit(1))
+	Register(conv, func(v bool) (int, error) {
+		return 2, nil
+	})
+	conv.Call(0)
+	conv.Call("")
+	if o, _ := conv.Call(true); o != 2 {
+		t.Error("registered function should not be evicted", o)
+	}
+}
+
+func TestLibraryPurge(t *testing.T) {
+	lib := NewLibrary(func(t Type) (int, bool) {
+		return 1, true
+	}, 0)
+	a, b := TypeOf(0), TypeOf("")
+	lib.Get(a)
+	lib.Get(b)
+	if n := lib.Purge(); n != 0 {
+		t.Error("fresh types should survive", n)
+	}
+	lib.Get(a)
+	lib.Get(a)
+	if n := lib.Purge(); n != 1 {
+		t.Error("wrong purge count", n)
+	}
+	if got := lib.Types(); len(got) != 1 || got[0] != a {
+		t.Error("wrong remaining types", got)
+	}
+	if n := lib.Purge(); n != 1 || lib.Len() != 0 {
+		t.Error("unused type should be purged", n, lib.Len())
+	}
+}
+
+func TestLibraryRange(t *testing.T) {
+	lib := NewLibrary(func(t Type) (int, bool) {
+		return 1, t.Kind() == Int
+	}, -1)
+	if len(lib.Types()) != 0 {
+		t.Error("new Library should be empty")
+	}
+
+	lib.Get(TypeOf(""))
+	lib.Get(TypeOf(0))
+	lib.Get(TypeOf(true))
+	if types := lib.Types(); len(types) != 3 || types[0] != TypeOf(true) || types[2] != TypeOf("") {
+		t.Error("wrong types", types)
+	}
+
+	var missing []Type
+	lib.Range(func(typ Type, v int) bool {
+		if _, ok := lib.Lookup(typ); !ok {
+			missing = append(missing, typ)
+			if v != -1 {
+				t.Error("uncovered types should hold the zero value", typ, v)
+			}
+		}
+		return true
+	})
+	if len(missing) != 2 {
+		t.Error("wrong uncovered types", missing)
+	}
+
+	n := 0
+	lib.Range(func(Type, int) bool {
+		n++
+		return false
+	})
+	if n != 1 {
+		t.Error("Range should stop", n)
+	}
+}
+
+func TestLibraryConcurrent(t *testing.T) {
+	var built int32
+	lib := NewLibrary(func(t Type) (Type, bool) {
+		atomic.AddInt32(&built, 1)
+		return t, true
+	}, nil)
+
+	types := []Type{TypeOf(0), TypeOf(""), TypeOf(1.5), TypeOf([]int{}), TypeOf(map[int]int{})}
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				for _, typ := range types {
+					if o := lib.Get(typ); o != typ {
+						t.Error("wrong entry", o, typ)
+					}
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	if built != int32(len(types)) {
+		t.Error("wrong build count", built)
+	}
+
+	if n := testing.AllocsPerRun(100, func() {
+		lib.Get(types[0])
+	}); n != 0 {
+		t.Error("cached lookup allocates", n)
+	}
+}
+
+func TestRegister(t *testing.T) {
+	var built int
+	c := NewConversion(func(t Type) (Converter[string], bool) {
+		built++
+		return func(v Value) (string, error) {
+			return "built", nil
+		}, true
+	})
+
+	Register(c, func(v int) (string, error) {
+		return "int", nil
+	})
+	Register(c, func(v bool) (string, error) {
+		return "bool", nil
+	})
+	// replaces the previous registration
+	Register(c, func(v int) (string, error) {
+		return "int2", nil
+	})
+
+	for _, tc := range []struct {
+		v   any
+		exp string
+	}{
+		{1, "int2"},
+		{true, "bool"},
+		{1.5, "built"},
+	} {
+		if o, err := c.Call(tc.v); err != nil || o != tc.exp {
+			t.Errorf("%v: expected %s, got %s", tc.v, tc.exp, o)
+		}
+	}
+	if built != 1 {
+		t.Error("wrong build count", built)
+	}
+
+	// the reflect path uses registrations too
+	if o, err := (*Library[Converter[string]])(c).Get(TypeEval[int]())(ValueOf(3)); err != nil || o != "int2" {
+		t.Error("wrong library function", o, err)
+	}
+}
+
+func TestHandle(t *testing.T) {
+	built := 0
+	c := NewConversion(func(t Type) (Converter[int64], bool) {
+		built++
+		if !isInteger(t.Kind()) {
+			return nil, false
+		}
+		return func(v Value) (int64, error) {
+			return v.Int(), nil
+		}, true
+	})
+
+	h := c.For(TypeEval[int32]())
+	if h.Type() != TypeEval[int32]() {
+		t.Error("wrong handle type", h.Type())
+	}
+	for i := int32(0); i < 3; i++ {
+		if o, err := h.Call(i); err != nil || o != int64(i) {
+			t.Error("wrong handle result", o, err)
+		}
+	}
+	if o, err := h.CallValue(ValueOf(struct{ N int32 }{7}).Field(0)); err != nil || o != 7 {
+		t.Error("wrong value result", o, err)
+	}
+	if o, err := c.Call(int32(5)); err != nil || o != 5 || built != 1 {
+		t.Error("handle should share the library cache", o, err, built)
+	}
+
+	if _, err := c.For(TypeEval[string]()).Call(""); err == nil {
+		t.Error("uncovered type should fail")
+	}
+}
+
+func TestInto(t *testing.T) {
+	c := NewConversion(func(t Type) (Converter[int], bool) {
+		return func(v Value) (int, error) {
+			if v.Kind() != Int {
+				return 0, ErrInvalid
+			}
+			return int(v.Int()), nil
+		}, true
+	})
+	inv := NewInversion(func(t Type) (Inverter[int], bool) {
+		if t != TypeEval[int]() {
+			return nil, false
+		}
+		return func(v int) (Value, error) {
+			return ValueOf(v), nil
+		}, true
+	})
+
+	var o int
+	if err := c.CallInto(&o, 7); err != nil || o != 7 {
+		t.Error("wrong CallInto result", o, err)
+	}
+	if err := c.CallInto(&o, ""); err == nil || o != 7 {
+		t.Error("failed CallInto should leave dst unchanged", o, err)
+	}
+	if err := AsInto(inv, &o, 8); err != nil || o != 8 {
+		t.Error("wrong AsInto result", o, err)
+	}
+	var s string
+	if err := AsInto(inv, &s, 9); err == nil || s != "" {
+		t.Error("failed AsInto should leave dst unchanged", s, err)
+	}
+
+	v := 5
+	if n := testing.AllocsPerRun(100, func() {
+		c.CallInto(&o, v)
+		AsInto(inv, &o, v)
+	}); n != 0 {
+		t.Error("calls allocate", n)
+	}
+}
+
+func TestLibraryLast(t *testing.T) {
+	c := NewConversion(func(t Type) (Converter[string], bool) {
+		return func(v Value) (string, error) {
+			return v.Type().String(), nil
+		}, true
+	})
+	lib := (*Library[Converter[string]])(c)
+	call := func(v any) string {
+		o, _ := lib.Get(TypeOf(v))(ValueOf(v))
+		return o
+	}
+
+	if call(1) != "int" || call(1) != "int" || call("") != "string" || call(1) != "int" {
+		t.Error("wrong results")
+	}
+	if e := lib.last.Load(); e == nil || e.t != TypeEval[int]() {
+		t.Error("last entry should be int")
+	}
+
+	// the replaced entry must not linger as the last one
+	Register(c, func(v int) (string, error) {
+		return "registered", nil
+	})
+	if o := call(1); o != "registered" {
+		t.Error("stale last entry", o)
+	}
+}
+
+func BenchmarkConversionCall(b *testing.B) {
+	c := NewConversion(func(t Type) (Converter[int64], bool) {
+		return func(v Value) (int64, error) {
+			return v.Int(), nil
+		}, true
+	})
+	var v any = int32(3)
+	c.Call(int8(1))
+	c.Call(int16(1))
+	for i := 0; i < b.N; i++ {
+		c.Call(v)
+	}
+}
+
+func TestMust(t *testing.T) {
+	panics := func(fn func()) (err error) {
+		defer func() {
+			err, _ = recover().(error)
+		}()
+		fn()
+		return nil
+	}
+
+	c := NewConversion(StrconvConverter)
+	if o := c.MustCall(3); o != "3" {
+		t.Error("wrong MustCall result", o)
+	}
+	if err := panics(func() { c.MustCall([]int{}) }); !errors.Is(err, ErrInvalid) {
+		t.Error("MustCall should panic with the error", err)
+	}
+
+	inv := NewInversion(StrconvInverter)
+	if o := MustAs[int](inv, "4"); o != 4 {
+		t.Error("wrong MustAs result", o)
+	}
+	if err := panics(func() { MustAs[int](inv, "x") }); err == nil {
+		t.Error("MustAs should panic with the error")
+	}
+
+	m := NewDeepMapper(nil)
+	if o := MustConvert[[]int64](m, []int{5}); len(o) != 1 || o[0] != 5 {
+		t.Error("wrong MustConvert result", o)
+	}
+	if err := panics(func() { MustConvert[chan int](m, 1) }); !errors.Is(err, ErrInvalid) {
+		t.Error("MustConvert should panic with the error", err)
+	}
+}
+
+func TestExtend(t *testing.T) {
+	built := 0
+	base := NewConversion(func(t Type) (Converter[string], bool) {
+		built++
+		return StrconvConverter(t)
+	})
+	base.Call(1)
+
+	strict := base.Extend(func(t Type) (Converter[string], bool) {
+		if t.Kind() != Float64 {
+			return nil, false
+		}
+		return func(v Value) (string, error) {
+			return "", ErrOverflow
+		}, true
+	})
+	if o, err := strict.Call(2); err != nil || o != "2" || built != 1 {
+		t.Error("int converter should be shared", o, err, built)
+	}
+	if _, err := strict.Call(1.5); !errors.Is(err, ErrOverflow) {
+		t.Error("extra builder should take precedence", err)
+	}
+	if o, err := base.Call(1.5); err != nil || o != "1.5" {
+		t.Error("base should be unaffected", o, err)
+	}
+	if _, err := strict.Call([]int{}); !errors.Is(err, ErrInvalid) {
+		t.Error("uncovered type should fail", err)
+	}
+
+	// options and registered functions carry over
+	m := &testMetrics{
+		builds:   make(map[Type]bool),
+		converts: make(map[Type]int),
+	}
+	base = NewConversion(StrconvConverter, WithNil(NilZero), WithMetrics(m), WithCacheLimit(1))
+	Register(base, func(f float64) (string, error) {
+		return "registered", nil
+	})
+	ext := base.Extend(func(t Type) (Converter[string], bool) {
+		return func(v Value) (string, error) {
+			return "extra", nil
+		}, t.Kind() == Float64 || t.Kind() == Pointer
+	})
+	if o, err := ext.Call(1.5); err != nil || o != "registered" {
+		t.Error("registered function should take precedence", o, err)
+	}
+	if o, err := ext.Call((*int)(nil)); err != nil || o != "" {
+		t.Error("nil policy should carry over", o, err)
+	}
+	ext.Call(1)
+	ext.Call(true)
+	if m.converts[TypeEval[float64]()] != 1 || (*Library[Converter[string]])(ext).Len() != 1 {
+		t.Error("metrics and cache limit should carry over", m.converts, (*Library[Converter[string]])(ext).Len())
+	}
+}
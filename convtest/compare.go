@@ -0,0 +1,124 @@
+package convtest
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/blitz-frost/conv"
+)
+
+// A Comparison is the outcome of running the samples of one type through two Conversions, as returned by Compare.
+type Comparison struct {
+	Type reflect.Type
+	A, B Result // measurements per sample, averaged over the samples of the type
+
+	Diff   string // description of the first sample with different results, if any
+	Sample any    // the first sample with different results
+}
+
+// Differ returns true if the Conversions produced different results for some sample.
+func (x Comparison) Differ() bool {
+	return x.Diff != ""
+}
+
+// Speedup returns how many times faster B is than A, or 0 if either was not measured.
+func (x Comparison) Speedup() float64 {
+	if x.A.NsPerOp == 0 || x.B.NsPerOp == 0 {
+		return 0
+	}
+	return x.A.NsPerOp / x.B.NsPerOp
+}
+
+func (x Comparison) String() string {
+	s := fmt.Sprintf("%v: %s against %s", x.Type, x.A.measurement(), x.B.measurement())
+	if x.Differ() {
+		s += "; " + x.Diff
+	}
+	return s
+}
+
+// measurement is the String of "x", without the type.
+func (x Result) measurement() string {
+	switch {
+	case x.Fallback:
+		return "fallback"
+	case x.Err != nil:
+		return x.Err.Error()
+	}
+	return fmt.Sprintf("%.1f ns/op, %.1f allocs/op", x.NsPerOp, x.AllocsPerOp)
+}
+
+// Compare runs "corpus" through Conversions "a" and "b", reporting their performance and any differences in their results, one Comparison per dynamic type, in order of first appearance.
+// Supports replacing a Builder with an equivalent, such as a generated one in place of a reflection based one: results are compared as by RoundTrip, and failures only by whether they happen, as messages usually differ between implementations.
+// Types are measured as by Benchmark, through Conversion.Call, so that registered fast paths count.
+func Compare[T any](a, b *conv.Conversion[T], corpus []any) []Comparison {
+	var types []reflect.Type
+	samples := make(map[reflect.Type][]any)
+	for _, v := range corpus {
+		t := reflect.TypeOf(v)
+		if _, ok := samples[t]; !ok {
+			types = append(types, t)
+		}
+		samples[t] = append(samples[t], v)
+	}
+
+	o := make([]Comparison, len(types))
+	for i, t := range types {
+		o[i].Type = t
+		for _, v := range samples[t] {
+			ra, errA := a.Call(v)
+			rb, errB := b.Call(v)
+			if !sameResult(ra, rb, errA, errB) {
+				o[i].Diff = fmt.Sprintf("%#v converts to %#v, %v against %#v, %v", v, ra, errA, rb, errB)
+				o[i].Sample = v
+				break
+			}
+		}
+		o[i].A = measure(a, t, samples[t])
+		o[i].B = measure(b, t, samples[t])
+	}
+	return o
+}
+
+// Differences returns the Comparisons of "results" that differ.
+func Differences(results []Comparison) []Comparison {
+	var o []Comparison
+	for _, r := range results {
+		if r.Differ() {
+			o = append(o, r)
+		}
+	}
+	return o
+}
+
+// measure returns the Result of converting "samples", of type "t", through "c".
+func measure[T any](c *conv.Conversion[T], t reflect.Type, samples []any) Result {
+	o := Result{Type: t}
+	if t != nil {
+		_, ok := (*conv.Library[conv.Converter[T]])(c).Lookup(t)
+		o.Fallback = !ok
+	}
+	if o.Fallback {
+		return o
+	}
+	for _, v := range samples {
+		if _, err := c.Call(v); err != nil {
+			o.Err = err
+			return o
+		}
+	}
+
+	r := testing.Benchmark(func(b *testing.B) {
+		b.ReportAllocs()
+		for j := 0; j < b.N; j++ {
+			for _, v := range samples {
+				c.Call(v)
+			}
+		}
+	})
+	n := float64(r.N) * float64(len(samples))
+	o.NsPerOp = float64(r.T.Nanoseconds()) / n
+	o.AllocsPerOp = float64(r.MemAllocs) / n
+	return o
+}
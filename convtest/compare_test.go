@@ -0,0 +1,47 @@
+package convtest
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/blitz-frost/conv"
+)
+
+func TestCompare(t *testing.T) {
+	if testing.Short() {
+		t.Skip("runs benchmarks")
+	}
+
+	a := conv.NewConversion(conv.StrconvConverter)
+	// a hand written replacement, which formats bools differently
+	b := conv.NewConversion(conv.StrconvConverter)
+	conv.Register(b, func(v int) (string, error) {
+		return strconv.Itoa(v), nil
+	})
+	conv.Register(b, func(v bool) (string, error) {
+		if v {
+			return "yes", nil
+		}
+		return "no", nil
+	})
+
+	results := Compare(a, b, []any{1, 2, "s", false, true, []int{1}})
+	if len(results) != 4 {
+		t.Fatal("wrong result count", results)
+	}
+	for _, r := range results[:2] {
+		if r.Differ() || r.A.NsPerOp <= 0 || r.Speedup() <= 0 {
+			t.Error("wrong result", r)
+		}
+	}
+	if r := results[3]; r.Type != reflect.TypeOf([]int{}) || !r.A.Fallback || !r.B.Fallback || r.Differ() {
+		t.Error("wrong fallback result", r)
+	}
+
+	diffs := Differences(results)
+	if len(diffs) != 1 || diffs[0].Sample != false || !strings.Contains(diffs[0].String(), `false converts to "false", <nil> against "no", <nil>`) {
+		t.Error("wrong differences", diffs)
+	}
+}
@@ -0,0 +1,71 @@
+// Package convtest provides helpers for testing and measuring conversion Schemes, so that the effects of Builder changes are measurable, rather than anecdotal.
+package convtest
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/blitz-frost/conv"
+)
+
+// A Result is the measurement of the Converter built for one sample type.
+type Result struct {
+	Type        reflect.Type
+	NsPerOp     float64
+	AllocsPerOp float64
+	Fallback    bool  // the Builder doesn't cover the type, which falls back to the zero Converter; not measured
+	Err         error // first error returned by the Converter, if any; not measured
+}
+
+func (x Result) String() string {
+	switch {
+	case x.Fallback:
+		return fmt.Sprintf("%v: fallback", x.Type)
+	case x.Err != nil:
+		return fmt.Sprintf("%v: %v", x.Type, x.Err)
+	}
+	return fmt.Sprintf("%v: %.1f ns/op, %.1f allocs/op", x.Type, x.NsPerOp, x.AllocsPerOp)
+}
+
+// Benchmark measures the Converters that "b" builds for the dynamic types of "samples", in the manner of testing.Benchmark, one Result per sample.
+// Samples of the same type are measured separately, as costs often depend on values, such as string lengths.
+// Meant for comparing Builder changes against a fixed corpus, usually from a test or benchmark function.
+func Benchmark[T any](b conv.Builder[conv.Converter[T]], samples []any) []Result {
+	o := make([]Result, len(samples))
+	for i, sample := range samples {
+		v := reflect.ValueOf(sample)
+		o[i].Type = v.Type()
+
+		fn, ok := b(v.Type())
+		if !ok {
+			o[i].Fallback = true
+			continue
+		}
+		if _, err := fn(v); err != nil {
+			o[i].Err = err
+			continue
+		}
+
+		r := testing.Benchmark(func(b *testing.B) {
+			b.ReportAllocs()
+			for j := 0; j < b.N; j++ {
+				fn(v)
+			}
+		})
+		o[i].NsPerOp = float64(r.T.Nanoseconds()) / float64(r.N)
+		o[i].AllocsPerOp = float64(r.MemAllocs) / float64(r.N)
+	}
+	return o
+}
+
+// Fallbacks returns the types of "results" that fell back to the zero Converter.
+func Fallbacks(results []Result) []reflect.Type {
+	var o []reflect.Type
+	for _, r := range results {
+		if r.Fallback {
+			o = append(o, r.Type)
+		}
+	}
+	return o
+}
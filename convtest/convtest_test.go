@@ -0,0 +1,39 @@
+package convtest
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/blitz-frost/conv"
+)
+
+func TestBenchmark(t *testing.T) {
+	if testing.Short() {
+		t.Skip("runs benchmarks")
+	}
+
+	var s conv.Scheme[conv.Converter[string]]
+	s.Use(conv.StrconvConverter)
+	results := Benchmark(s.Build, []any{1, "s", 1.5, []int{1}})
+	if len(results) != 4 {
+		t.Fatal("wrong result count", len(results))
+	}
+	for _, r := range results[:3] {
+		if r.Fallback || r.Err != nil || r.NsPerOp <= 0 {
+			t.Error("wrong result", r)
+		}
+	}
+	if r := results[1]; r.AllocsPerOp != 0 {
+		t.Error("string conversion should not allocate", r)
+	}
+	if fb := Fallbacks(results); len(fb) != 1 || fb[0] != reflect.TypeOf([]int{}) {
+		t.Error("wrong fallbacks", fb)
+	}
+	if s := results[3].String(); s != "[]int: fallback" {
+		t.Error("wrong string", s)
+	}
+	if s := results[0].String(); !strings.HasPrefix(s, "int: ") || !strings.HasSuffix(s, " allocs/op") {
+		t.Error("wrong string", s)
+	}
+}
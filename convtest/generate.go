@@ -0,0 +1,159 @@
+package convtest
+
+import (
+	"math"
+	"math/rand"
+	"reflect"
+)
+
+// GenerateOptions bound the values produced by Generate. The zero value is valid.
+type GenerateOptions struct {
+	MaxLen   int  // maximum length of strings, slices and maps; 4 if not positive
+	MaxDepth int  // maximum nesting of pointers, slices and maps, beyond which they are left nil; 4 if not positive
+	NonNil   bool // never produce nil pointers, slices or maps above MaxDepth
+}
+
+// Generate returns a random value of type "t", drawn from "rng", for property based testing of Schemes.
+// Strings are valid UTF-8. Numbers cover their whole range, including extremes, as well as NaN and infinities for floats.
+// Interfaces, channels, functions and unsafe pointers are left zero, as are unexported struct fields, so that recursive types terminate.
+// The result is settable.
+func Generate(t reflect.Type, rng *rand.Rand, opts GenerateOptions) reflect.Value {
+	if opts.MaxLen <= 0 {
+		opts.MaxLen = 4
+	}
+	if opts.MaxDepth <= 0 {
+		opts.MaxDepth = 4
+	}
+	g := generator{
+		rng:  rng,
+		opts: opts,
+	}
+	o := reflect.New(t).Elem()
+	g.fill(o, 0)
+	return o
+}
+
+type generator struct {
+	rng  *rand.Rand
+	opts GenerateOptions
+}
+
+// fill sets settable value "v" to a random value, at nesting "depth".
+func (x generator) fill(v reflect.Value, depth int) {
+	switch k := v.Kind(); k {
+	case reflect.Bool:
+		v.SetBool(x.rng.Intn(2) == 0)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v.SetInt(x.signed(v.Type().Bits()))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		v.SetUint(x.unsigned(v.Type().Bits()))
+	case reflect.Float32, reflect.Float64:
+		v.SetFloat(x.float(v.Type().Bits()))
+	case reflect.Complex64, reflect.Complex128:
+		bits := v.Type().Bits() / 2
+		v.SetComplex(complex(x.float(bits), x.float(bits)))
+	case reflect.String:
+		runes := make([]rune, x.rng.Intn(x.opts.MaxLen+1))
+		for i := range runes {
+			runes[i] = x.rune()
+		}
+		v.SetString(string(runes))
+	case reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			x.fill(v.Index(i), depth)
+		}
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).IsExported() {
+				x.fill(v.Field(i), depth)
+			}
+		}
+	case reflect.Pointer, reflect.Slice, reflect.Map:
+		if depth >= x.opts.MaxDepth || (!x.opts.NonNil && x.rng.Intn(4) == 0) {
+			return
+		}
+		switch k {
+		case reflect.Pointer:
+			p := reflect.New(v.Type().Elem())
+			x.fill(p.Elem(), depth+1)
+			v.Set(p)
+		case reflect.Slice:
+			n := x.rng.Intn(x.opts.MaxLen + 1)
+			v.Set(reflect.MakeSlice(v.Type(), n, n))
+			for i := 0; i < n; i++ {
+				x.fill(v.Index(i), depth+1)
+			}
+		case reflect.Map:
+			n := x.rng.Intn(x.opts.MaxLen + 1)
+			v.Set(reflect.MakeMapWithSize(v.Type(), n))
+			for i := 0; i < n; i++ {
+				key, elem := reflect.New(v.Type().Key()).Elem(), reflect.New(v.Type().Elem()).Elem()
+				x.fill(key, depth+1)
+				x.fill(elem, depth+1)
+				v.SetMapIndex(key, elem)
+			}
+		}
+	}
+}
+
+// signed returns a random signed integer of "n" bits.
+// Small magnitudes and extremes are favored, as they are the usual edge cases.
+func (x generator) signed(n int) int64 {
+	switch x.rng.Intn(4) {
+	case 0:
+		return int64(x.rng.Intn(256)) - 128
+	case 1:
+		if x.rng.Intn(2) == 0 {
+			return -1 << (n - 1)
+		}
+		return 1<<(n-1) - 1
+	}
+	return int64(x.rng.Uint64()) >> (64 - n)
+}
+
+// unsigned is the unsigned counterpart of signed.
+func (x generator) unsigned(n int) uint64 {
+	switch x.rng.Intn(4) {
+	case 0:
+		return uint64(x.rng.Intn(256))
+	case 1:
+		if x.rng.Intn(2) == 0 {
+			return 0
+		}
+		return math.MaxUint64 >> (64 - n)
+	}
+	return x.rng.Uint64() >> (64 - n)
+}
+
+func (x generator) float(bits int) float64 {
+	switch x.rng.Intn(8) {
+	case 0:
+		return math.NaN()
+	case 1:
+		return math.Inf(x.rng.Intn(2)*2 - 1)
+	case 2:
+		if bits == 32 {
+			return math.MaxFloat32
+		}
+		return math.MaxFloat64
+	case 3, 4:
+		return float64(x.rng.Intn(2001)-1000) / 8
+	}
+	if bits == 32 {
+		return float64(math.Float32frombits(x.rng.Uint32()))
+	}
+	return math.Float64frombits(x.rng.Uint64())
+}
+
+// rune returns a random valid rune, mostly ASCII.
+func (x generator) rune() rune {
+	if x.rng.Intn(4) != 0 {
+		return rune(' ' + x.rng.Intn('~'-' '+1))
+	}
+	for {
+		r := rune(x.rng.Intn(0x10ffff + 1))
+		if r < 0xd800 || r > 0xdfff {
+			return r
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package convtest
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestGenerate(t *testing.T) {
+	type node struct {
+		Name     string
+		N        int8
+		U        uint16
+		F        float32
+		Tags     map[string]bool
+		Children []*node
+		Fixed    [2]int64
+		hidden   int
+	}
+
+	rng := rand.New(rand.NewSource(1))
+	var (
+		nonNil bool
+		depth  int
+		walk   func(v reflect.Value, d int)
+	)
+	walk = func(v reflect.Value, d int) {
+		if d > depth {
+			depth = d
+		}
+		switch v.Kind() {
+		case reflect.String:
+			if !utf8.ValidString(v.String()) || len([]rune(v.String())) > 3 {
+				t.Error("wrong string", v.String())
+			}
+		case reflect.Pointer:
+			if !v.IsNil() {
+				walk(v.Elem(), d+1)
+			}
+		case reflect.Slice:
+			if v.Len() > 3 {
+				t.Error("slice too long", v.Len())
+			}
+			for i := 0; i < v.Len(); i++ {
+				walk(v.Index(i), d+1)
+			}
+		case reflect.Map:
+			for iter := v.MapRange(); iter.Next(); {
+				walk(iter.Key(), d+1)
+			}
+		case reflect.Struct:
+			if v.Field(7).Int() != 0 {
+				t.Error("unexported field set")
+			}
+			if !v.Field(4).IsNil() && !v.Field(5).IsNil() {
+				nonNil = true
+			}
+			for i := 0; i < 7; i++ {
+				walk(v.Field(i), d)
+			}
+		}
+	}
+	for i := 0; i < 100; i++ {
+		v := Generate(reflect.TypeOf(node{}), rng, GenerateOptions{MaxLen: 3, MaxDepth: 4})
+		if !v.CanSet() {
+			t.Fatal("unsettable result")
+		}
+		walk(v, 0)
+	}
+	if depth > 4 || depth < 2 {
+		t.Error("wrong depth", depth)
+	}
+	if !nonNil {
+		t.Error("no populated collections")
+	}
+
+	// NonNil leaves nothing nil above the depth limit, while the fields of the pointed node are beyond it
+	v := Generate(reflect.TypeOf(&node{}), rng, GenerateOptions{MaxDepth: 1, NonNil: true})
+	if v.IsNil() || !v.Elem().Field(4).IsNil() {
+		t.Error("wrong NonNil result", v)
+	}
+
+	// extremes stay within range
+	seen := make(map[int8]bool)
+	for i := 0; i < 1000; i++ {
+		seen[int8(Generate(reflect.TypeOf(int8(0)), rng, GenerateOptions{}).Int())] = true
+	}
+	if !seen[-128] || !seen[127] {
+		t.Error("missing extremes")
+	}
+}
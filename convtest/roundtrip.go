@@ -0,0 +1,125 @@
+package convtest
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"reflect"
+	"strconv"
+
+	"github.com/blitz-frost/conv"
+)
+
+// RoundTripOptions configure RoundTrip. The zero value is valid.
+type RoundTripOptions struct {
+	Samples  int   // values generated per type; 100 if not positive
+	Seed     int64 // seed of the value generator, for reproducible failures
+	Generate GenerateOptions
+
+	Tolerance float64 // relative difference allowed between floats, for lossy numeric formats
+	NilEmpty  bool    // consider nil and empty slices and maps equal, for formats that don't tell them apart
+}
+
+// RoundTrip converts random values of each of "types" through "c", inverts them back through "inv", and compares the results with the originals.
+// Maps are compared by key, regardless of order, and NaN equals NaN.
+// Returns an error for the first failing value, naming its type and the path of the first difference inside it.
+func RoundTrip[T any](c *conv.Conversion[T], inv *conv.Inversion[T], types []reflect.Type, opts RoundTripOptions) error {
+	if opts.Samples <= 0 {
+		opts.Samples = 100
+	}
+	rng := rand.New(rand.NewSource(opts.Seed))
+	lib := (*conv.Library[conv.Inverter[T]])(inv)
+
+	for _, t := range types {
+		back := lib.Get(t)
+		for i := 0; i < opts.Samples; i++ {
+			v := Generate(t, rng, opts.Generate)
+			o, err := c.Call(v.Interface())
+			if err != nil {
+				return fmt.Errorf("%v: convert %#v: %w", t, v, err)
+			}
+			w, err := back(o)
+			if err != nil {
+				return fmt.Errorf("%v: invert %#v: %w", t, v, err)
+			}
+			if path, ok := equal(v, w, "", opts); !ok {
+				return fmt.Errorf("%v: %#v came back as %#v, differing at %q", t, v, w, path)
+			}
+		}
+	}
+	return nil
+}
+
+// equal compares "a" and "b" deeply, returning the path of the first difference.
+func equal(a, b reflect.Value, path string, opts RoundTripOptions) (string, bool) {
+	if !a.IsValid() || !b.IsValid() || a.Type() != b.Type() {
+		return path, a.IsValid() == b.IsValid() && !a.IsValid()
+	}
+
+	switch a.Kind() {
+	case reflect.Float32, reflect.Float64:
+		return path, floatEqual(a.Float(), b.Float(), opts.Tolerance)
+	case reflect.Complex64, reflect.Complex128:
+		x, y := a.Complex(), b.Complex()
+		return path, floatEqual(real(x), real(y), opts.Tolerance) && floatEqual(imag(x), imag(y), opts.Tolerance)
+	case reflect.Pointer, reflect.Interface:
+		if a.IsNil() || b.IsNil() {
+			return path, a.IsNil() == b.IsNil()
+		}
+		return equal(a.Elem(), b.Elem(), path, opts)
+	case reflect.Slice, reflect.Map:
+		if a.IsNil() != b.IsNil() && !(opts.NilEmpty && a.Len() == 0 && b.Len() == 0) {
+			return path, false
+		}
+		if a.Kind() == reflect.Map {
+			if a.Len() != b.Len() {
+				return path, false
+			}
+			for iter := a.MapRange(); iter.Next(); {
+				p := path + "[" + fmt.Sprint(iter.Key()) + "]"
+				w := b.MapIndex(iter.Key())
+				if !w.IsValid() {
+					return p, false
+				}
+				if p, ok := equal(iter.Value(), w, p, opts); !ok {
+					return p, false
+				}
+			}
+			return path, true
+		}
+		fallthrough
+	case reflect.Array:
+		if a.Len() != b.Len() {
+			return path, false
+		}
+		for i := 0; i < a.Len(); i++ {
+			if p, ok := equal(a.Index(i), b.Index(i), path+"["+strconv.Itoa(i)+"]", opts); !ok {
+				return p, false
+			}
+		}
+		return path, true
+	case reflect.Struct:
+		for i := 0; i < a.NumField(); i++ {
+			if !a.Type().Field(i).IsExported() {
+				continue
+			}
+			if p, ok := equal(a.Field(i), b.Field(i), path+"."+a.Type().Field(i).Name, opts); !ok {
+				return p, false
+			}
+		}
+		return path, true
+	case reflect.Func, reflect.Chan, reflect.UnsafePointer:
+		return path, a.IsNil() == b.IsNil()
+	}
+	return path, a.Interface() == b.Interface()
+}
+
+func floatEqual(a, b, tolerance float64) bool {
+	switch {
+	case a == b, a != a && b != b:
+		return true
+	case math.IsInf(a, 0) || math.IsInf(b, 0):
+		return false
+	}
+	return math.Abs(a-b) <= tolerance*math.Max(math.Abs(a), math.Abs(b))
+}
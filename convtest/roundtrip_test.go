@@ -0,0 +1,77 @@
+package convtest
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/blitz-frost/conv"
+)
+
+func TestRoundTrip(t *testing.T) {
+	type item struct {
+		Name  string
+		Count uint32
+		Score float64
+		Tags  []string
+		Attrs map[string]int16
+		Next  *item
+	}
+	types := []reflect.Type{reflect.TypeOf(0), reflect.TypeOf(""), reflect.TypeOf(item{}), reflect.TypeOf([]bool{})}
+
+	c := conv.NewConversion(conv.CBORConverter)
+	inv := conv.NewInversion(conv.CBORInverter)
+	if err := RoundTrip(c, inv, types, RoundTripOptions{NilEmpty: true}); err != nil {
+		t.Error(err)
+	}
+
+	// lossy float formatting only passes with a tolerance
+	lossy := conv.NewConversion(func(t reflect.Type) (conv.Converter[string], bool) {
+		return func(v reflect.Value) (string, error) {
+			return strconv.FormatFloat(v.Float(), 'g', 6, 64), nil
+		}, t.Kind() == reflect.Float64
+	})
+	parse := conv.NewInversion(conv.StrconvInverter)
+	floats := []reflect.Type{reflect.TypeOf(0.0)}
+	err := RoundTrip(lossy, parse, floats, RoundTripOptions{})
+	if err == nil || !strings.HasPrefix(err.Error(), "float64: ") {
+		t.Error("expected a float64 failure", err)
+	}
+	if err := RoundTrip(lossy, parse, floats, RoundTripOptions{Tolerance: 1e-5}); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestEqual(t *testing.T) {
+	type s struct {
+		A []int
+		M map[string]float64
+	}
+	a := s{[]int{1, 2}, map[string]float64{"x": 1}}
+	for _, tc := range []struct {
+		b    s
+		opts RoundTripOptions
+		path string
+		ok   bool
+	}{
+		{s{[]int{1, 2}, map[string]float64{"x": 1}}, RoundTripOptions{}, "", true},
+		{s{[]int{1, 3}, map[string]float64{"x": 1}}, RoundTripOptions{}, ".A[1]", false},
+		{s{[]int{1, 2}, map[string]float64{"x": 1.001}}, RoundTripOptions{}, ".M[x]", false},
+		{s{[]int{1, 2}, map[string]float64{"x": 1.001}}, RoundTripOptions{Tolerance: 0.01}, "", true},
+		{s{[]int{1, 2}, map[string]float64{"y": 1}}, RoundTripOptions{}, ".M[x]", false},
+	} {
+		path, ok := equal(reflect.ValueOf(a), reflect.ValueOf(tc.b), "", tc.opts)
+		if ok != tc.ok || (!ok && path != tc.path) {
+			t.Errorf("%v: expected %q %v, got %q %v", tc.b, tc.path, tc.ok, path, ok)
+		}
+	}
+
+	nilA, emptyB := reflect.ValueOf([]int(nil)), reflect.ValueOf([]int{})
+	if _, ok := equal(nilA, emptyB, "", RoundTripOptions{}); ok {
+		t.Error("nil and empty should differ")
+	}
+	if _, ok := equal(nilA, emptyB, "", RoundTripOptions{NilEmpty: true}); !ok {
+		t.Error("nil and empty should be equal with NilEmpty")
+	}
+}
@@ -0,0 +1,292 @@
+package convtest
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/blitz-frost/conv"
+)
+
+// SchemeOptions configure TestScheme. The zero value is valid.
+type SchemeOptions[T any] struct {
+	Samples  int   // values generated per type; 20 if not positive
+	Seed     int64 // seed of the value generator, for reproducible failures
+	Generate GenerateOptions
+
+	Inverter conv.Builder[conv.Inverter[T]] // counterpart of the scheme, enabling the numeric check if set
+	Classes  []error                        // failure classes accepted besides those of package conv
+}
+
+// classes are the failure classes of package conv, one of which all errors must wrap.
+var classes = []error{
+	conv.ErrInvalid,
+	conv.ErrOverflow,
+	conv.ErrPrecisionLoss,
+	conv.ErrNilSource,
+	conv.ErrUnsupportedKind,
+	conv.ErrMissingField,
+	conv.ErrUnknownField,
+	conv.ErrTooLarge,
+	conv.ErrCycle,
+	conv.ErrDepth,
+}
+
+// TestScheme checks Builder "b" against the invariants that the rest of package conv relies on, over random values of each of "types", as a subtest per invariant:
+//
+//   - Build: "b" is deterministic, building the same coverage and results each time, and Libraries return the same function for repeated lookups
+//   - Nil: typed nils don't panic, under either NilPolicy, nor when nil pointers are accepted through WithNilAccepted
+//   - Errors: failures wrap one of the failure classes of package conv, such as ErrInvalid or ErrOverflow, and no value panics
+//   - Numeric: values of numeric types convert losslessly, inverting back to the same value through Inverter, or fail with ErrOverflow, ErrPrecisionLoss or ErrInvalid; skipped if Inverter is not set
+//   - Concurrent: a Conversion shared by concurrent goroutines, while still building, produces the same results as a sequential one; best run with the race detector
+//
+// Meant for third party Builder packages, to prove that they behave as the package expects.
+func TestScheme[T any](t *testing.T, b conv.Builder[conv.Converter[T]], types []reflect.Type, opts SchemeOptions[T]) {
+	t.Helper()
+	for _, c := range schemeChecks(b, types, opts) {
+		c := c
+		t.Run(c.name, func(t *testing.T) {
+			if c.run == nil {
+				t.Skip("not configured")
+			}
+			for _, err := range c.run() {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+type schemeCheck struct {
+	name string
+	run  func() []error // nil if skipped
+}
+
+func schemeChecks[T any](b conv.Builder[conv.Converter[T]], types []reflect.Type, opts SchemeOptions[T]) []schemeCheck {
+	if opts.Samples <= 0 {
+		opts.Samples = 20
+	}
+	rng := rand.New(rand.NewSource(opts.Seed))
+	samples := make(map[reflect.Type][]reflect.Value, len(types))
+	for _, t := range types {
+		for i := 0; i < opts.Samples; i++ {
+			samples[t] = append(samples[t], Generate(t, rng, opts.Generate))
+		}
+	}
+	x := schemeRun[T]{
+		b:       b,
+		types:   types,
+		samples: samples,
+		classes: append(append([]error{}, classes...), opts.Classes...),
+		inv:     opts.Inverter,
+	}
+
+	o := []schemeCheck{
+		{"Build", x.build},
+		{"Nil", x.nils},
+		{"Errors", x.failures},
+		{"Numeric", nil},
+		{"Concurrent", x.concurrent},
+	}
+	if x.inv != nil {
+		o[3].run = x.numeric
+	}
+	return o
+}
+
+type schemeRun[T any] struct {
+	b       conv.Builder[conv.Converter[T]]
+	types   []reflect.Type
+	samples map[reflect.Type][]reflect.Value
+	classes []error
+	inv     conv.Builder[conv.Inverter[T]]
+}
+
+func (x schemeRun[T]) build() []error {
+	var o []error
+	lib := conv.NewLibrary[conv.Converter[T]](x.b, nil)
+	for _, t := range x.types {
+		f, ok := x.b(t)
+		g, ok2 := x.b(t)
+		if ok != ok2 {
+			o = append(o, fmt.Errorf("%v: covered on one build, but not the next", t))
+			continue
+		}
+		if a, b := lib.Get(t), lib.Get(t); reflect.ValueOf(a).Pointer() != reflect.ValueOf(b).Pointer() {
+			o = append(o, fmt.Errorf("%v: repeated Library lookups returned different functions", t))
+		}
+		if !ok {
+			continue
+		}
+		for _, v := range x.samples[t] {
+			a, errA, _ := convert(f, v)
+			b, errB, _ := convert(g, v)
+			if !sameResult(a, b, errA, errB) {
+				o = append(o, fmt.Errorf("%v: %#v converts to %#v, %v on one build, but %#v, %v on the next", t, v, a, errA, b, errB))
+				break
+			}
+		}
+	}
+	return o
+}
+
+func (x schemeRun[T]) nils() []error {
+	var o []error
+	for _, opt := range []conv.Option{conv.WithNil(conv.NilError), conv.WithNil(conv.NilZero), conv.WithNilAccepted(nil)} {
+		c := conv.NewConversion(x.b, opt)
+		for _, t := range x.types {
+			switch t.Kind() {
+			case reflect.Pointer, reflect.Slice, reflect.Map, reflect.Chan, reflect.Func:
+			default:
+				continue
+			}
+			_, err, panicked := call(c, reflect.Zero(t))
+			if panicked {
+				o = append(o, fmt.Errorf("%v: nil panics: %w", t, err))
+			} else if err != nil && !x.classified(err) {
+				o = append(o, fmt.Errorf("%v: nil fails with an unclassified error: %w", t, err))
+			}
+		}
+	}
+	return o
+}
+
+func (x schemeRun[T]) failures() []error {
+	var o []error
+	c := conv.NewConversion(x.b)
+	for _, t := range x.types {
+		for _, v := range x.samples[t] {
+			_, err, panicked := call(c, v)
+			if panicked {
+				o = append(o, fmt.Errorf("%v: %#v panics: %w", t, v, err))
+				break
+			}
+			if err != nil && !x.classified(err) {
+				o = append(o, fmt.Errorf("%v: %#v fails with an unclassified error: %w", t, v, err))
+				break
+			}
+		}
+	}
+	return o
+}
+
+func (x schemeRun[T]) numeric() []error {
+	var o []error
+	c := conv.NewConversion(x.b)
+	inv := conv.NewInversion(x.inv)
+	for _, t := range x.types {
+		switch t.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+			reflect.Float32, reflect.Float64:
+		default:
+			continue
+		}
+		for _, v := range x.samples[t] {
+			r, err, _ := call(c, v)
+			if err != nil {
+				if !errors.Is(err, conv.ErrOverflow) && !errors.Is(err, conv.ErrPrecisionLoss) && !errors.Is(err, conv.ErrInvalid) {
+					o = append(o, fmt.Errorf("%v: %#v fails with %w, rather than ErrOverflow or ErrPrecisionLoss", t, v, err))
+					break
+				}
+				continue
+			}
+			w, err := inv.Invert(t, r)
+			if err != nil {
+				o = append(o, fmt.Errorf("%v: %#v converts to %#v, which doesn't invert: %w", t, v, r, err))
+				break
+			}
+			if _, ok := equal(v, w, "", RoundTripOptions{}); !ok {
+				o = append(o, fmt.Errorf("%v: %#v converts to %#v, which inverts to %#v", t, v, r, w))
+				break
+			}
+		}
+	}
+	return o
+}
+
+func (x schemeRun[T]) concurrent() []error {
+	type result struct {
+		o   T
+		err error
+	}
+	var all []reflect.Value
+	for _, t := range x.types {
+		all = append(all, x.samples[t]...)
+	}
+	want := make([]result, len(all))
+	seq := conv.NewConversion(x.b)
+	for i, v := range all {
+		want[i].o, want[i].err, _ = call(seq, v)
+	}
+
+	const workers = 8
+	var (
+		o   []error
+		mux sync.Mutex
+		wg  sync.WaitGroup
+	)
+	c := conv.NewConversion(x.b)
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			// start at different points, so that goroutines build different types at once
+			for j := range all {
+				i := (j + w*len(all)/workers) % len(all)
+				r, err, _ := call(c, all[i])
+				if !sameResult(r, want[i].o, err, want[i].err) {
+					mux.Lock()
+					o = append(o, fmt.Errorf("%v: %#v converts to %#v, %v concurrently, but %#v, %v sequentially", all[i].Type(), all[i], r, err, want[i].o, want[i].err))
+					mux.Unlock()
+					return
+				}
+			}
+		}(w)
+	}
+	wg.Wait()
+	return o
+}
+
+// classified returns true if "err" wraps one of the accepted failure classes.
+func (x schemeRun[T]) classified(err error) bool {
+	for _, class := range x.classes {
+		if errors.Is(err, class) {
+			return true
+		}
+	}
+	return false
+}
+
+// call converts "v" through "c", reporting panics as errors.
+func call[T any](c *conv.Conversion[T], v reflect.Value) (o T, err error, panicked bool) {
+	defer func() {
+		if r := recover(); r != nil {
+			err, panicked = fmt.Errorf("panic: %v", r), true
+		}
+	}()
+	o, err = c.Call(v.Interface())
+	return
+}
+
+// convert is the Converter counterpart of call.
+func convert[T any](fn conv.Converter[T], v reflect.Value) (o T, err error, panicked bool) {
+	defer func() {
+		if r := recover(); r != nil {
+			err, panicked = fmt.Errorf("panic: %v", r), true
+		}
+	}()
+	o, err = fn(v)
+	return
+}
+
+// sameResult compares two conversion outcomes, as equal values, or as both failed.
+func sameResult[T any](a, b T, errA, errB error) bool {
+	if errA != nil || errB != nil {
+		return errA != nil && errB != nil
+	}
+	_, ok := equal(reflect.ValueOf(&a).Elem(), reflect.ValueOf(&b).Elem(), "", RoundTripOptions{})
+	return ok
+}
@@ -0,0 +1,75 @@
+package convtest
+
+import (
+	"errors"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/blitz-frost/conv"
+)
+
+func TestTestScheme(t *testing.T) {
+	type id int32
+	types := []reflect.Type{reflect.TypeOf(0), reflect.TypeOf(id(0)), reflect.TypeOf(0.0), reflect.TypeOf(""), reflect.TypeOf(true), reflect.TypeOf((*int)(nil)), reflect.TypeOf([]int{})}
+	TestScheme(t, conv.NumericConverter(conv.StrconvConverter), types, SchemeOptions[string]{
+		Inverter: conv.NumericInverter(conv.StrconvInverter),
+	})
+}
+
+func TestSchemeChecks(t *testing.T) {
+	failed := func(b conv.Builder[conv.Converter[string]], types []reflect.Type, opts SchemeOptions[string]) map[string]string {
+		o := make(map[string]string)
+		for _, c := range schemeChecks(b, types, opts) {
+			if c.run == nil {
+				continue
+			}
+			if errs := c.run(); len(errs) > 0 {
+				o[c.name] = errs[0].Error()
+			}
+		}
+		return o
+	}
+
+	// dereferences nil pointers, and fails with plain errors
+	careless := func(t reflect.Type) (conv.Converter[string], bool) {
+		if t.Kind() != reflect.Pointer {
+			return nil, false
+		}
+		return func(v reflect.Value) (string, error) {
+			if v.Elem().Int() < 0 {
+				return "", errors.New("negative")
+			}
+			return strconv.FormatInt(v.Elem().Int(), 10), nil
+		}, true
+	}
+	got := failed(careless, []reflect.Type{reflect.TypeOf((*int)(nil))}, SchemeOptions[string]{Generate: GenerateOptions{NonNil: true}})
+	if !strings.Contains(got["Nil"], "nil panics") || !strings.Contains(got["Errors"], "unclassified error: negative") || len(got) != 2 {
+		t.Error("wrong careless failures", got)
+	}
+
+	// rounds floats
+	lossy := func(t reflect.Type) (conv.Converter[string], bool) {
+		return func(v reflect.Value) (string, error) {
+			return strconv.FormatFloat(v.Float(), 'g', 4, 64), nil
+		}, t.Kind() == reflect.Float64
+	}
+	got = failed(lossy, []reflect.Type{reflect.TypeOf(0.0)}, SchemeOptions[string]{Inverter: conv.StrconvInverter})
+	if !strings.Contains(got["Numeric"], "float64: ") || len(got) != 1 {
+		t.Error("wrong lossy failures", got)
+	}
+
+	// covers a type on every other build
+	n := 0
+	flaky := func(t reflect.Type) (conv.Converter[string], bool) {
+		if n++; n%2 == 0 {
+			return nil, false
+		}
+		return conv.StrconvConverter(t)
+	}
+	got = failed(flaky, []reflect.Type{reflect.TypeOf("")}, SchemeOptions[string]{})
+	if !strings.Contains(got["Build"], "covered on one build, but not the next") {
+		t.Error("wrong flaky failures", got)
+	}
+}
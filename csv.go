@@ -0,0 +1,127 @@
+package conv
+
+import (
+	"fmt"
+	. "reflect"
+	"strconv"
+)
+
+// CSV is a set of Builders converting between structs and []string records, as used by the encoding/csv package.
+// Field values are formatted through Format and parsed through Parse, which would usually combine StrconvConverter and TextConverter, and their inverses.
+//
+// Columns are configured using the "csv" struct tag:
+//
+//	`csv:"name"`        column name, defaulting to the field name
+//	`csv:"name,col=2"`  column position
+//	`csv:"-"`           ignore the field
+//
+// Fields without an explicit position take the next one, in declaration order.
+// If Header is set, positions are resolved by name against it instead, and fields missing from it are ignored.
+type CSV struct {
+	Format *Conversion[string]
+	Parse  *Inversion[string]
+	Header []string
+}
+
+// CSVHeader returns the column names of struct type "t", in position order.
+// Useful for writing the header record, or as the Header of a CSV used for reading files written by another.
+func CSVHeader(t Type) []string {
+	cols, n := csvColumns(t, nil)
+	o := make([]string, n)
+	for _, c := range cols {
+		o[c.pos] = c.name
+	}
+	return o
+}
+
+// Converter is a Builder of record Converters for struct types.
+// Records are as long as the largest column position; unused positions are left empty.
+func (x *CSV) Converter(t Type) (Converter[[]string], bool) {
+	if t.Kind() != Struct {
+		return nil, false
+	}
+	cols, n := csvColumns(t, x.Header)
+	fmtLib := (*Library[Converter[string]])(x.Format)
+
+	return func(v Value) ([]string, error) {
+		o := make([]string, n)
+		for _, c := range cols {
+			s, err := fmtLib.Get(c.Type)(v.FieldByIndex(c.Index))
+			if err != nil {
+				return nil, fmt.Errorf("column %s: %w", c.name, err)
+			}
+			o[c.pos] = s
+		}
+		return o, nil
+	}, true
+}
+
+// Inverter is a Builder of record Inverters for struct types.
+// Fields whose positions lie beyond the end of a short record are left zero.
+func (x *CSV) Inverter(t Type) (Inverter[[]string], bool) {
+	if t.Kind() != Struct {
+		return nil, false
+	}
+	cols, _ := csvColumns(t, x.Header)
+	parseLib := (*Library[Inverter[string]])(x.Parse)
+
+	return func(v []string) (Value, error) {
+		o := New(t).Elem()
+		for _, c := range cols {
+			if c.pos >= len(v) {
+				continue
+			}
+			fv, err := parseLib.Get(c.Type)(v[c.pos])
+			if err != nil {
+				return Value{}, fmt.Errorf("column %s: %w", c.name, err)
+			}
+			o.FieldByIndex(c.Index).Set(fv)
+		}
+		return o, nil
+	}, true
+}
+
+type csvColumn struct {
+	taggedField
+	pos int
+}
+
+// csvColumns resolves the column positions of struct type "t", returning them along with the record length.
+func csvColumns(t Type, header []string) ([]csvColumn, int) {
+	var byName map[string]int
+	if header != nil {
+		byName = make(map[string]int, len(header))
+		for i, name := range header {
+			byName[name] = i
+		}
+	}
+
+	var (
+		o    []csvColumn
+		n    int
+		next int
+	)
+	for _, f := range taggedFields(t, "csv") {
+		pos := next
+		if header != nil {
+			var ok bool
+			if pos, ok = byName[f.name]; !ok {
+				continue
+			}
+		} else if s, ok := f.option("col"); ok {
+			if p, err := strconv.Atoi(s); err == nil && p >= 0 {
+				pos = p
+			}
+		}
+		next = pos + 1
+
+		o = append(o, csvColumn{
+			taggedField: f,
+			pos:         pos,
+		})
+		if pos >= n {
+			n = pos + 1
+		}
+	}
+	return o, n
+}
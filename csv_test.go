@@ -0,0 +1,58 @@
+package conv
+
+import (
+	. "reflect"
+	"testing"
+	"time"
+)
+
+func TestCSV(t *testing.T) {
+	type record struct {
+		Name  string    `csv:"name"`
+		Score float64   `csv:"score,col=3"`
+		At    time.Time `csv:"at"`
+		Skip  int       `csv:"-"`
+		Count uint8
+	}
+
+	x := &CSV{
+		Format: NewConversion(Scheme[Converter[string]]{TextConverter, StrconvConverter}.Build),
+		Parse:  NewInversion(Scheme[Inverter[string]]{TextInverter, StrconvInverter}.Build),
+	}
+	typ := TypeEval[record]()
+
+	header := CSVHeader(typ)
+	if !DeepEqual(header, []string{"name", "", "", "score", "at", "Count"}) {
+		t.Error("wrong header", header)
+	}
+
+	tm := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	v := record{"a", 1.5, tm, 9, 3}
+	c := NewConversion(x.Converter)
+	rec, err := c.Call(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !DeepEqual(rec, []string{"a", "", "", "1.5", "2020-01-01T00:00:00Z", "3"}) {
+		t.Error("wrong record", rec)
+	}
+
+	inv := NewInversion(x.Inverter)
+	o, err := As[record](inv, rec)
+	v.Skip = 0
+	if err != nil || o != v {
+		t.Error("wrong struct", o, err)
+	}
+
+	// reading a file with a different column order
+	y := *x
+	y.Header = []string{"Count", "name", "unknown"}
+	o, err = As[record](NewInversion(y.Inverter), []string{"7", "b", "?"})
+	if err != nil || o != (record{Name: "b", Count: 7}) {
+		t.Error("header mapping failed", o, err)
+	}
+
+	if _, err := As[record](inv, []string{"a", "", "", "x"}); err == nil {
+		t.Error("expected parse error")
+	}
+}
@@ -0,0 +1,233 @@
+package conv
+
+import (
+	"errors"
+	. "reflect"
+)
+
+var ErrDepth = errors.New("nesting too deep")
+
+// Deep is a set of Builders for Mappings between composite types, resolving element Mappings through Elems.
+// Elems will usually be the Mapper that the Deep builders are themselves part of.
+//
+// Pointers are tracked through the State, so that shared source pointers convert to shared destination pointers, and cyclic graphs convert to cyclic graphs instead of recursing forever.
+// Slices and maps are likewise shared if the Mapper enables it through SetSharing. Otherwise, as for source pointers mapped to values, cycles through them fail with ErrCycle.
+// Nested values count towards the depth limit of the Mapper, set through SetMaxDepth.
+type Deep struct {
+	Elems *Mapper
+}
+
+// NewDeepMapper returns a Mapper that recursively converts pointers, slices, arrays, maps and structs, falling back to Go assignment and conversion rules.
+// "sm" configures struct handling, and may be nil. Its Fields are set to the returned Mapper.
+// "extra" Builders take precedence over the standard ones.
+func NewDeepMapper(sm *StructMap, extra ...Builder[Mapping]) *Mapper {
+	if sm == nil {
+		sm = &StructMap{}
+	}
+	deep := &Deep{}
+
+	scheme := append(Scheme[Mapping]{}, extra...)
+	scheme.Use(AssignMapping)
+	scheme.Use(deep.Build)
+	scheme.Use(sm.Build)
+
+	o := NewMapper(scheme.Build)
+	deep.Elems = o
+	sm.Fields = o
+	return o
+}
+
+// Build combines the Pointer, Slice and Map Builders.
+func (x *Deep) Build(t Type) (Mapping, bool) {
+	if o, ok := x.Pointer(t); ok {
+		return o, true
+	}
+	if o, ok := x.Slice(t); ok {
+		return o, true
+	}
+	return x.Map(t)
+}
+
+// Pointer builds Mappings where either side is a pointer.
+// Nil source pointers produce zero destinations. Non-pointer sources are mapped into newly allocated destinations.
+func (x *Deep) Pointer(t Type) (Mapping, bool) {
+	tDst, tSrc := t.Out(0), t.In(0)
+	srcPtr, dstPtr := tSrc.Kind() == Pointer, tDst.Kind() == Pointer
+
+	switch {
+	case srcPtr && dstPtr:
+		ref := x.ref(tDst.Elem(), tSrc.Elem())
+		return func(dst, src Value, s *State) error {
+			if src.IsNil() {
+				dst.SetZero()
+				return nil
+			}
+			if o, ok := s.Visited(src, tDst); ok {
+				dst.Set(o)
+				return nil
+			}
+			if err := s.descend(); err != nil {
+				return err
+			}
+			defer s.ascend()
+			o := s.New(tDst.Elem())
+			s.Visit(src, o)
+			if err := ref.get()(o.Elem(), src.Elem(), s); err != nil {
+				return err
+			}
+			dst.Set(o)
+			return nil
+		}, true
+	case srcPtr:
+		ref := x.ref(tDst, tSrc.Elem())
+		return func(dst, src Value, s *State) error {
+			if src.IsNil() {
+				dst.SetZero()
+				return nil
+			}
+			if err := s.descend(); err != nil {
+				return err
+			}
+			defer s.ascend()
+			if err := s.enter(src); err != nil {
+				return err
+			}
+			defer s.leave(src)
+			return ref.get()(dst, src.Elem(), s)
+		}, true
+	case dstPtr:
+		ref := x.ref(tDst.Elem(), tSrc)
+		return func(dst, src Value, s *State) error {
+			if err := s.descend(); err != nil {
+				return err
+			}
+			defer s.ascend()
+			o := s.New(tDst.Elem())
+			if err := ref.get()(o.Elem(), src, s); err != nil {
+				return err
+			}
+			dst.Set(o)
+			return nil
+		}, true
+	}
+	return nil, false
+}
+
+// Slice builds Mappings between slices and arrays.
+// Nil source slices produce nil destination slices. Array destinations are filled up to their length, with excess elements left zero.
+func (x *Deep) Slice(t Type) (Mapping, bool) {
+	tDst, tSrc := t.Out(0), t.In(0)
+	kDst, kSrc := tDst.Kind(), tSrc.Kind()
+	if (kDst != Slice && kDst != Array) || (kSrc != Slice && kSrc != Array) {
+		return nil, false
+	}
+
+	ref := x.ref(tDst.Elem(), tSrc.Elem())
+	return func(dst, src Value, s *State) error {
+		n := src.Len()
+		if kDst == Slice {
+			if kSrc == Slice && src.IsNil() {
+				dst.SetZero()
+				return nil
+			}
+			tracked := kSrc == Slice && n > 0
+			if tracked && s.sharing() {
+				if o, ok := s.Visited(src, tDst); ok && o.Len() == n {
+					dst.Set(o)
+					return nil
+				}
+			}
+			o := MakeSlice(tDst, n, n)
+			dst.Set(o)
+			if tracked {
+				if s.sharing() {
+					s.Visit(src, o)
+				} else {
+					if err := s.enter(src); err != nil {
+						return err
+					}
+					defer s.leave(src)
+				}
+			}
+		} else {
+			dst.SetZero()
+			if l := tDst.Len(); l < n {
+				n = l
+			}
+		}
+
+		if err := s.descend(); err != nil {
+			return err
+		}
+		defer s.ascend()
+		fn := ref.get()
+		for i := 0; i < n; i++ {
+			if err := fn(dst.Index(i), src.Index(i), s); err != nil {
+				return err
+			}
+		}
+		return nil
+	}, true
+}
+
+// Map builds Mappings between maps, converting both keys and values.
+func (x *Deep) Map(t Type) (Mapping, bool) {
+	tDst, tSrc := t.Out(0), t.In(0)
+	if tDst.Kind() != Map || tSrc.Kind() != Map {
+		return nil, false
+	}
+
+	refKey := x.ref(tDst.Key(), tSrc.Key())
+	refElem := x.ref(tDst.Elem(), tSrc.Elem())
+	return func(dst, src Value, s *State) error {
+		if src.IsNil() {
+			dst.SetZero()
+			return nil
+		}
+
+		if err := s.descend(); err != nil {
+			return err
+		}
+		defer s.ascend()
+		if s.sharing() {
+			if o, ok := s.Visited(src, tDst); ok {
+				dst.Set(o)
+				return nil
+			}
+		}
+		fnKey, fnElem := refKey.get(), refElem.get()
+		o := MakeMapWithSize(tDst, src.Len())
+		if s.sharing() {
+			s.Visit(src, o)
+		} else {
+			if err := s.enter(src); err != nil {
+				return err
+			}
+			defer s.leave(src)
+		}
+		k, v := getScratch(tDst.Key()), getScratch(tDst.Elem())
+		defer putScratch(k)
+		defer putScratch(v)
+		for iter := src.MapRange(); iter.Next(); {
+			k.SetZero()
+			v.SetZero()
+			if err := fnKey(k, iter.Key(), s); err != nil {
+				return err
+			}
+			if err := fnElem(v, iter.Value(), s); err != nil {
+				return err
+			}
+			o.SetMapIndex(k, v)
+		}
+		dst.Set(o)
+		return nil
+	}, true
+}
+
+func (x *Deep) ref(tDst, tSrc Type) *mappingRef {
+	return &mappingRef{
+		m:   x.Elems,
+		dst: tDst,
+		src: tSrc,
+	}
+}
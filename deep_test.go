@@ -0,0 +1,160 @@
+package conv
+
+import (
+	"errors"
+	. "reflect"
+	"testing"
+)
+
+func TestDeep(t *testing.T) {
+	type node struct {
+		Val  int
+		Prev *node
+		Next *node
+	}
+	type nodeOut struct {
+		Val  int64
+		Prev *nodeOut
+		Next *nodeOut
+	}
+	type graph struct {
+		Head   *node
+		Shared []*node
+		Index  map[string]*node
+	}
+	type graphOut struct {
+		Head   *nodeOut
+		Shared [2]*nodeOut
+		Index  map[string]nodeOut
+	}
+
+	a := &node{Val: 1}
+	b := &node{Val: 2, Prev: a}
+	a.Next = b
+	b.Next = a // cycle
+
+	m := NewDeepMapper(nil)
+	var o graphOut
+	err := m.Map(&o, graph{
+		Head:   a,
+		Shared: []*node{a, b, a},
+		Index:  map[string]*node{"b": b},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	oa := o.Head
+	ob := oa.Next
+	if oa.Val != 1 || ob.Val != 2 || ob.Prev != oa || ob.Next != oa {
+		t.Error("graph structure not preserved")
+	}
+	if o.Shared[0] != oa || o.Shared[1] != ob {
+		t.Error("shared pointers not preserved")
+	}
+	if o.Index["b"].Val != 2 || o.Index["b"].Next != oa {
+		t.Error("map failed", o.Index)
+	}
+
+	var i int
+	if err := m.Map(&i, (*int)(nil)); err != nil || i != 0 {
+		t.Error("nil pointer should produce zero")
+	}
+}
+
+func TestDeepLimits(t *testing.T) {
+	type tree struct {
+		Kids []*tree
+	}
+	type treeOut struct {
+		Kids []treeOut
+	}
+
+	m := NewDeepMapper(nil)
+
+	// shared, but acyclic pointers are fine as values
+	leaf := &tree{}
+	var o treeOut
+	if err := m.Map(&o, tree{Kids: []*tree{leaf, leaf}}); err != nil || len(o.Kids) != 2 {
+		t.Error("shared pointers should map", err)
+	}
+
+	root := &tree{}
+	root.Kids = []*tree{{}, root}
+	if err := m.Map(&o, root); !errors.Is(err, ErrCycle) {
+		t.Error("expected cycle", err)
+	}
+
+	type node struct {
+		Next *node
+	}
+	var head *node
+	for i := 0; i < 10; i++ {
+		head = &node{head}
+	}
+	type nodeOut struct {
+		Next *nodeOut
+	}
+	var out *nodeOut
+	if err := m.Map(&out, head); err != nil {
+		t.Error("unlimited depth should pass", err)
+	}
+	m.SetMaxDepth(5)
+	if err := m.Map(&out, head); !errors.Is(err, ErrDepth) {
+		t.Error("expected depth error", err)
+	}
+	m.SetMaxDepth(10)
+	if err := m.Map(&out, head); err != nil {
+		t.Error("depth within limit should pass", err)
+	}
+}
+
+func TestDeepSharing(t *testing.T) {
+	type pair struct {
+		A, B []int
+		M, N map[string]int
+	}
+	type pairOut struct {
+		A, B []int64
+		M, N map[string]int64
+	}
+	s := []int{1, 2}
+	mp := map[string]int{"x": 1}
+	in := pair{s, s, mp, mp}
+
+	m := NewDeepMapper(nil)
+	var o pairOut
+	if err := m.Map(&o, in); err != nil {
+		t.Fatal(err)
+	}
+	if &o.A[0] == &o.B[0] || ValueOf(o.M).Pointer() == ValueOf(o.N).Pointer() {
+		t.Error("references should not be shared by default")
+	}
+
+	type loop map[string]loop
+	type loopOut map[string]loopOut
+	l := loop{}
+	l["self"] = l
+	var lo loopOut
+	if err := m.Map(&lo, l); !errors.Is(err, ErrCycle) {
+		t.Error("expected cycle", err)
+	}
+
+	m.SetSharing(true)
+	o = pairOut{}
+	in.B = s[:1] // different length
+	if err := m.Map(&o, in); err != nil {
+		t.Fatal(err)
+	}
+	if &o.A[0] == &o.B[0] || len(o.B) != 1 || ValueOf(o.M).Pointer() != ValueOf(o.N).Pointer() {
+		t.Error("wrong sharing", o)
+	}
+	in.B = s
+	if err := m.Map(&o, in); err != nil || &o.A[0] != &o.B[0] {
+		t.Error("slices should be shared", err)
+	}
+
+	if err := m.Map(&lo, l); err != nil || ValueOf(lo["self"]).Pointer() != ValueOf(lo).Pointer() {
+		t.Error("cycle should be preserved", err)
+	}
+}
@@ -0,0 +1,46 @@
+package conv
+
+import (
+	"fmt"
+	. "reflect"
+)
+
+var (
+	typeStringer  = TypeEval[fmt.Stringer]()
+	typeFormatter = TypeEval[fmt.Formatter]()
+)
+
+// Display is a set of Builders of string Converters for display purposes, so that schemes turning anything into text don't fail on types without dedicated handling.
+// Types implementing fmt.Stringer, either directly or through their pointer, use their String method.
+// Types implementing fmt.Formatter, and all other types, are formatted through fmt.Sprintf with the Fallback format, "%v" if empty.
+type Display struct {
+	Fallback string
+	Strict   bool // only cover fmt.Stringer and fmt.Formatter types
+}
+
+// Converter builds string Converters, as described by Display.
+func (x *Display) Converter(t Type) (Converter[string], bool) {
+	if o, ok := StringerConverter(t); ok {
+		return o, true
+	}
+	if x.Strict && !implements(t, typeFormatter) {
+		return nil, false
+	}
+	format := x.Fallback
+	if format == "" {
+		format = "%v"
+	}
+	return func(v Value) (string, error) {
+		return fmt.Sprintf(format, v.Interface()), nil
+	}, true
+}
+
+// StringerConverter is a Builder of string Converters for types that implement fmt.Stringer, either directly or through their pointer.
+func StringerConverter(t Type) (Converter[string], bool) {
+	if !implements(t, typeStringer) {
+		return nil, false
+	}
+	return func(v Value) (string, error) {
+		return methods[fmt.Stringer](v).String(), nil
+	}, true
+}
@@ -0,0 +1,52 @@
+package conv
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+type displayPtr struct {
+	n int
+}
+
+func (x *displayPtr) String() string {
+	return fmt.Sprintf("ptr %d", x.n)
+}
+
+type displayFormatter struct{}
+
+func (x displayFormatter) Format(f fmt.State, verb rune) {
+	fmt.Fprintf(f, "formatted %c", verb)
+}
+
+func TestDisplay(t *testing.T) {
+	c := NewConversion((&Display{}).Converter, WithNil(NilZero))
+	for _, tc := range []struct {
+		v   any
+		exp string
+	}{
+		{time.Second, "1s"},
+		{displayPtr{3}, "ptr 3"},
+		{(*displayPtr)(nil), ""},
+		{displayFormatter{}, "formatted v"},
+		{[]int{1, 2}, "[1 2]"},
+		{struct{ A int }{1}, "{1}"},
+	} {
+		if s, err := c.Call(tc.v); err != nil || s != tc.exp {
+			t.Errorf("%T: expected %q, got %q", tc.v, tc.exp, s)
+		}
+	}
+
+	c = NewConversion((&Display{Fallback: "%+v", Strict: true}).Converter)
+	if s, err := c.Call(displayFormatter{}); err != nil || s != "formatted v" {
+		t.Error("wrong formatter output", s, err)
+	}
+	if _, err := c.Call(struct{ A int }{1}); err == nil {
+		t.Error("strict display accepted plain struct")
+	}
+	c = NewConversion((&Display{Fallback: "%+v"}).Converter)
+	if s, _ := c.Call(struct{ A int }{1}); s != "{A:1}" {
+		t.Error("wrong fallback format", s)
+	}
+}
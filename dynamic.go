@@ -0,0 +1,76 @@
+package conv
+
+import (
+	. "reflect"
+	"sync/atomic"
+)
+
+// dynamic holds the Mapper of Convert and ConvertTo, along with the provider registration it was built from.
+var dynamic atomic.Pointer[dynamicMapper]
+
+type dynamicMapper struct {
+	m   *Mapper
+	gen uint64
+}
+
+// dynamicGet returns the Mapper of Convert and ConvertTo, building a new one if providers were registered since the last one.
+func dynamicGet() *Mapper {
+	gen := providers.gen.Load()
+	if d := dynamic.Load(); d != nil && d.gen == gen {
+		return d.m
+	}
+	var scheme Scheme[Mapping]
+	scheme.UseProviders()
+	scheme.Use(NumericMapping)
+	d := &dynamicMapper{
+		m:   NewDeepMapper(nil, scheme...),
+		gen: gen,
+	}
+	dynamic.Store(d)
+	return d.m
+}
+
+// Convert converts "src" into the value pointed to by "dst", through a default scheme, for simple conversions that don't warrant assembling a Mapper:
+//
+//   - assignable and convertible types, by Go rules, except integers into strings
+//   - numeric types, failing with ErrOverflow or ErrPrecisionLoss rather than losing information, as NumericMapping does
+//   - strings and byte slices, both ways
+//   - pointers, slices, arrays and maps, element by element
+//   - structs, field by field, matching fields by name, as StructMap does
+//
+// Conversions go through a shared Mapper, made of the Builders of all registered providers of Builder[Mapping], in name order, ahead of NumericMapping and the standard Builders of NewDeepMapper. It is rebuilt, dropping the Mappings cached so far, whenever a provider is registered.
+// Programs that need other behavior should build their own Mapper.
+//
+// Nil converts to the zero value of pointer, slice, map, interface, channel and function types, and fails with ErrNilSource for others.
+// Fails with ErrInvalid if "dst" is not a non nil pointer.
+func Convert(dst, src any) error {
+	d := ValueOf(dst)
+	if d.Kind() != Pointer || d.IsNil() {
+		return ErrInvalid
+	}
+	return convertInto(d.Elem(), src)
+}
+
+// ConvertTo converts "v" into a new value of type "dst", for callers that only learn destination types at run time, such as template evaluators, query planners and script bindings, and so can't use the APIs parameterized by type.
+// Converts as Convert does.
+func ConvertTo(dst Type, v any) (Value, error) {
+	o := New(dst).Elem()
+	if err := convertInto(o, v); err != nil {
+		return Value{}, err
+	}
+	return o, nil
+}
+
+// convertInto converts "v" into settable value "dst", through the shared Mapper.
+func convertInto(dst Value, v any) error {
+	if v == nil {
+		switch dst.Kind() {
+		case Pointer, Slice, Map, Interface, Chan, Func:
+			dst.SetZero()
+			return nil
+		}
+		return ErrNilSource
+	}
+	src := ValueOf(v)
+	return dynamicGet().Get(dst.Type(), src.Type())(dst, src, &State{})
+}
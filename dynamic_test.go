@@ -0,0 +1,103 @@
+package conv
+
+import (
+	"errors"
+	. "reflect"
+	"testing"
+	"time"
+)
+
+func TestConvertTo(t *testing.T) {
+	type point struct {
+		X, Y int
+	}
+	type row struct {
+		X, Y int64
+	}
+	v, err := ConvertTo(TypeEval[[]row](), []point{{1, 2}})
+	if err != nil || !DeepEqual(v.Interface(), []row{{1, 2}}) {
+		t.Error("wrong rows", v, err)
+	}
+	if _, err := ConvertTo(TypeEval[time.Duration](), "1s"); !errors.Is(err, ErrInvalid) {
+		t.Error("expected uncovered conversion", err)
+	}
+
+	// providers registered later are picked up
+	RegisterBuilderProvider("test.dynamic.duration", func() Builder[Mapping] {
+		return func(t Type) (Mapping, bool) {
+			if t.Out(0) != TypeEval[time.Duration]() || t.In(0).Kind() != String {
+				return nil, false
+			}
+			return func(dst, src Value, s *State) error {
+				d, err := time.ParseDuration(src.String())
+				if err != nil {
+					return ErrInvalid
+				}
+				dst.SetInt(int64(d))
+				return nil
+			}, true
+		}
+	})
+	if v, err := ConvertTo(TypeEval[time.Duration](), "1s"); err != nil || v.Interface() != time.Second {
+		t.Error("wrong duration", v, err)
+	}
+
+	if v, err := ConvertTo(TypeEval[*int](), nil); err != nil || !v.IsNil() {
+		t.Error("nil should convert to nil pointer", v, err)
+	}
+	if _, err := ConvertTo(TypeEval[int](), nil); !errors.Is(err, ErrNilSource) {
+		t.Error("nil should not convert to int", err)
+	}
+}
+
+func TestConvert(t *testing.T) {
+	type celsius float64
+	type user struct {
+		Name string
+		Age  int
+		Tags []string
+		Meta map[string]int
+	}
+	type row struct {
+		Name []byte
+		Age  int8
+		Tags [][]byte
+		Meta map[string]float64
+	}
+
+	var r row
+	if err := Convert(&r, user{"ann", 30, []string{"a"}, map[string]int{"x": 1}}); err != nil || string(r.Name) != "ann" || r.Age != 30 || string(r.Tags[0]) != "a" || r.Meta["x"] != 1 {
+		t.Error("wrong row", r, err)
+	}
+	var u user
+	if err := Convert(&u, row{Age: 127}); err != nil || u.Age != 127 {
+		t.Error("wrong user", u, err)
+	}
+	if err := Convert(&r, user{Age: 300}); !errors.Is(err, ErrOverflow) {
+		t.Error("expected overflow", err)
+	}
+
+	var c celsius
+	if err := Convert(&c, 21); err != nil || c != 21 {
+		t.Error("wrong widening", c, err)
+	}
+	var n int
+	if err := Convert(&n, 1.5); !errors.Is(err, ErrPrecisionLoss) {
+		t.Error("expected precision loss", n, err)
+	}
+	var s string
+	if err := Convert(&s, 65); !errors.Is(err, ErrInvalid) {
+		t.Error("integers should not convert to strings", s, err)
+	}
+
+	p := &n
+	if err := Convert(&p, nil); err != nil || p != nil {
+		t.Error("nil should clear pointer", err)
+	}
+	if err := Convert(&n, nil); !errors.Is(err, ErrNilSource) {
+		t.Error("nil should not convert to int", err)
+	}
+	if err := Convert(n, 1); !errors.Is(err, ErrInvalid) {
+		t.Error("non pointer destination should fail", err)
+	}
+}
@@ -0,0 +1,182 @@
+package conv
+
+import (
+	"fmt"
+	. "reflect"
+	"strconv"
+	"sync"
+)
+
+// An EnumPolicy decides how Enums handle values without a registered name, and names without a registered value.
+type EnumPolicy uint8
+
+const (
+	EnumError       EnumPolicy = iota // fail with ErrInvalid; the default
+	EnumZero                          // use the zero value: the empty string, or the zero enum value
+	EnumPassthrough                   // use the decimal form of the value, and parse unknown names as decimal values
+)
+
+// Enums is a registry of the names of enum types, which are integer kinds with named constants.
+// It builds Mappings, Converters and Inverters between registered enum types and strings, usable in any Scheme.
+// Names are matched exactly.
+//
+// Names are registered through RegisterEnum, either by hand, or through the registration function that conv-gen -enum generates from the constants of a package.
+// Built functions keep the names of their type as registered when first built, so all names, and Unknown, should be set before use.
+type Enums struct {
+	Unknown EnumPolicy
+
+	types map[Type]*enumTable
+	mux   sync.RWMutex
+}
+
+type enumTable struct {
+	names  map[int64]string // unsigned values are stored by their bits
+	values map[string]int64
+}
+
+// An EnumInt is an integer type, which enum types are based on.
+type EnumInt interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 | ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+}
+
+// RegisterEnum registers the names of the values of enum type E. Registering a type again adds to its names.
+// Panics if a name is used for two values, as that is a programming error. Values may have more than one name; the last registered one is used for strings.
+func RegisterEnum[E EnumInt](x *Enums, names map[E]string) {
+	t := TypeEval[E]()
+
+	x.mux.Lock()
+	defer x.mux.Unlock()
+
+	if x.types == nil {
+		x.types = make(map[Type]*enumTable)
+	}
+	e := x.types[t]
+	if e == nil {
+		e = &enumTable{
+			names:  make(map[int64]string),
+			values: make(map[string]int64),
+		}
+		x.types[t] = e
+	}
+	for v, name := range names {
+		n := enumBits(ValueOf(v))
+		if old, ok := e.values[name]; ok && old != n {
+			panic(fmt.Sprintf("conv: enum %v name %q used for two values", t, name))
+		}
+		e.names[n] = name
+		e.values[name] = n
+	}
+}
+
+func (x *Enums) table(t Type) (*enumTable, bool) {
+	x.mux.RLock()
+	defer x.mux.RUnlock()
+	e, ok := x.types[t]
+	return e, ok
+}
+
+// Build is a Builder of Mappings between registered enum types and string kinds, in both directions.
+func (x *Enums) Build(t Type) (Mapping, bool) {
+	tDst, tSrc := t.Out(0), t.In(0)
+	if tDst.Kind() == String {
+		if e, ok := x.table(tSrc); ok {
+			return func(dst, src Value, s *State) error {
+				name, err := x.name(e, src)
+				if err != nil {
+					return err
+				}
+				dst.SetString(name)
+				return nil
+			}, true
+		}
+	}
+	if tSrc.Kind() == String {
+		if e, ok := x.table(tDst); ok {
+			return func(dst, src Value, s *State) error {
+				return x.value(e, dst, src.String())
+			}, true
+		}
+	}
+	return nil, false
+}
+
+// Converter is a Builder of Converters from registered enum types to their names.
+func (x *Enums) Converter(t Type) (Converter[string], bool) {
+	e, ok := x.table(t)
+	if !ok {
+		return nil, false
+	}
+	return func(v Value) (string, error) {
+		return x.name(e, v)
+	}, true
+}
+
+// Inverter is a Builder of Inverters from names to registered enum types.
+func (x *Enums) Inverter(t Type) (Inverter[string], bool) {
+	e, ok := x.table(t)
+	if !ok {
+		return nil, false
+	}
+	return func(s string) (Value, error) {
+		o := New(t).Elem()
+		if err := x.value(e, o, s); err != nil {
+			return Value{}, err
+		}
+		return o, nil
+	}, true
+}
+
+// name returns the name of enum value "v", according to the Unknown policy.
+func (x *Enums) name(e *enumTable, v Value) (string, error) {
+	n := enumBits(v)
+	if name, ok := e.names[n]; ok {
+		return name, nil
+	}
+	switch x.Unknown {
+	case EnumZero:
+		return "", nil
+	case EnumPassthrough:
+		if v.CanInt() {
+			return strconv.FormatInt(n, 10), nil
+		}
+		return strconv.FormatUint(uint64(n), 10), nil
+	}
+	return "", fmt.Errorf("%s value %v: %w", describeType(v.Type()), v, ErrInvalid)
+}
+
+// value sets settable enum value "dst" to the value named "s", according to the Unknown policy.
+func (x *Enums) value(e *enumTable, dst Value, s string) error {
+	n, ok := e.values[s]
+	if !ok && x.Unknown == EnumZero {
+		dst.SetZero()
+		return nil
+	}
+	if !ok && x.Unknown == EnumPassthrough {
+		var err error
+		if dst.CanInt() {
+			n, err = strconv.ParseInt(s, 10, dst.Type().Bits())
+		} else {
+			var u uint64
+			u, err = strconv.ParseUint(s, 10, dst.Type().Bits())
+			n = int64(u)
+		}
+		ok = err == nil
+	}
+	if !ok {
+		return fmt.Errorf("%s name %q: %w", describeType(dst.Type()), s, ErrInvalid)
+	}
+	if dst.CanInt() {
+		dst.SetInt(n)
+	} else {
+		dst.SetUint(uint64(n))
+	}
+	return nil
+}
+
+// enumBits returns the value of integer "v", with unsigned values kept by their bits.
+func enumBits(v Value) int64 {
+	if v.CanInt() {
+		return v.Int()
+	}
+	return int64(v.Uint())
+}
@@ -0,0 +1,93 @@
+package conv
+
+import (
+	"errors"
+	"testing"
+)
+
+type enumColor uint8
+
+const (
+	enumRed enumColor = iota + 1
+	enumGreen
+)
+
+func TestEnums(t *testing.T) {
+	e := &Enums{}
+	RegisterEnum(e, map[enumColor]string{enumRed: "red", enumGreen: "green"})
+
+	c := NewConversion(e.Converter)
+	if o, err := c.Call(enumGreen); err != nil || o != "green" {
+		t.Error("wrong name", o, err)
+	}
+	if _, err := c.Call(enumColor(9)); !errors.Is(err, ErrInvalid) {
+		t.Error("unknown value should fail", err)
+	}
+	if _, err := c.Call(3); !errors.Is(err, ErrInvalid) {
+		t.Error("unregistered types should not be covered", err)
+	}
+
+	inv := NewInversion(e.Inverter)
+	if o, err := As[enumColor](inv, "red"); err != nil || o != enumRed {
+		t.Error("wrong value", o, err)
+	}
+	if _, err := As[enumColor](inv, "blue"); !errors.Is(err, ErrInvalid) {
+		t.Error("unknown name should fail", err)
+	}
+
+	type shirt struct {
+		Color enumColor
+	}
+	type row struct {
+		Color string
+	}
+	m := NewDeepMapper(nil, e.Build)
+	var r row
+	if err := m.Map(&r, shirt{enumRed}); err != nil || r.Color != "red" {
+		t.Error("wrong row", r, err)
+	}
+	var s shirt
+	if err := m.Map(&s, row{"green"}); err != nil || s.Color != enumGreen {
+		t.Error("wrong shirt", s, err)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("reused name should panic")
+		}
+	}()
+	RegisterEnum(e, map[enumColor]string{3: "red"})
+}
+
+func TestEnumsUnknown(t *testing.T) {
+	type level int8
+	e := &Enums{Unknown: EnumZero}
+	RegisterEnum(e, map[level]string{-1: "low", 1: "high"})
+	c := NewConversion(e.Converter)
+	inv := NewInversion(e.Inverter)
+
+	if o, err := c.Call(level(5)); err != nil || o != "" {
+		t.Error("unknown value should be empty", o, err)
+	}
+	if o, err := As[level](inv, "mid"); err != nil || o != 0 {
+		t.Error("unknown name should be zero", o, err)
+	}
+
+	// the policy is read on each call
+	e.Unknown = EnumPassthrough
+	if o, err := c.Call(level(-5)); err != nil || o != "-5" {
+		t.Error("unknown value should pass through", o, err)
+	}
+	if o, err := As[level](inv, "low"); err != nil || o != -1 {
+		t.Error("wrong value", o, err)
+	}
+	if o, err := As[level](inv, "-7"); err != nil || o != -7 {
+		t.Error("decimal name should pass through", o, err)
+	}
+	if _, err := As[level](inv, "300"); !errors.Is(err, ErrInvalid) {
+		t.Error("out of range decimal should fail", err)
+	}
+	if _, err := As[level](inv, "mid"); !errors.Is(err, ErrInvalid) {
+		t.Error("non decimal unknown name should fail", err)
+	}
+}
@@ -0,0 +1,269 @@
+package conv
+
+import (
+	"fmt"
+	"os"
+	. "reflect"
+	"strings"
+)
+
+// ErrRequired is an ErrMissingField.
+var ErrRequired = fmt.Errorf("required variable: %w", ErrMissingField)
+
+// Env is a set of Builders converting between structs and environment snapshots (map[string]string), for configuration loading.
+// Variables are parsed through Parse and formatted through Format, which would usually combine StrconvInverter and TextInverter, and their inverses.
+//
+// Fields are configured using the "env" struct tag:
+//
+//	`env:"NAME"`               variable name, defaulting to the upper cased field name
+//	`env:"NAME,default=8080"`  value to use when the variable is missing
+//	`env:"NAME,required"`      fail when the variable is missing and has no default
+//	`env:"-"`                  ignore the field
+//
+// The default option must come last, as it takes the rest of the tag verbatim, commas included, so that slice defaults can list several elements.
+// Slices of parsable types are read from comma separated lists. Other struct fields, or pointers to them, are nested, with their variable names prefixed by the parent name and an underscore.
+// Nested pointer fields are only allocated, and their defaults and requirements applied, if any of their variables are present.
+// All names are additionally prefixed by Prefix.
+type Env struct {
+	Parse  *Inversion[string]
+	Format *Conversion[string]
+	Prefix string
+}
+
+// Environ returns a snapshot of the process environment.
+func Environ() map[string]string {
+	env := os.Environ()
+	o := make(map[string]string, len(env))
+	for _, kv := range env {
+		if k, v, ok := strings.Cut(kv, "="); ok {
+			o[k] = v
+		}
+	}
+	return o
+}
+
+type envField struct {
+	index    []int
+	name     string
+	ptr      bool
+	slice    bool
+	typ      Type // after pointer and slice indirection
+	def      string
+	hasDef   bool
+	required bool
+	isNested bool
+	nested   []envField
+}
+
+// Inverter is a Builder of environment Inverters for struct types.
+func (x *Env) Inverter(t Type) (Inverter[map[string]string], bool) {
+	if t.Kind() != Struct {
+		return nil, false
+	}
+	lib := (*Library[Inverter[string]])(x.Parse)
+	plan := envPlan(t, x.Prefix, func(t Type) bool {
+		_, ok := lib.Lookup(t)
+		return ok
+	}, nil)
+
+	return func(v map[string]string) (Value, error) {
+		o := New(t).Elem()
+		if err := x.decode(v, o, plan); err != nil {
+			return Value{}, err
+		}
+		return o, nil
+	}, true
+}
+
+// Converter is a Builder of environment Converters for struct types, the inverse of Inverter.
+// Nil pointer fields are omitted.
+func (x *Env) Converter(t Type) (Converter[map[string]string], bool) {
+	if t.Kind() != Struct {
+		return nil, false
+	}
+	lib := (*Library[Converter[string]])(x.Format)
+	plan := envPlan(t, x.Prefix, func(t Type) bool {
+		_, ok := lib.Lookup(t)
+		return ok
+	}, nil)
+
+	return func(v Value) (map[string]string, error) {
+		o := make(map[string]string)
+		if err := x.encode(o, v, plan); err != nil {
+			return nil, err
+		}
+		return o, nil
+	}, true
+}
+
+func envPlan(t Type, prefix string, covers func(Type) bool, stack []Type) []envField {
+	for _, st := range stack {
+		if st == t {
+			return nil
+		}
+	}
+	stack = append(stack, t)
+
+	var o []envField
+	for _, f := range taggedFields(t, "env") {
+		name := f.name
+		if tagName, _ := parseTag(f.Tag.Get("env")); tagName == "" {
+			name = strings.ToUpper(name)
+		}
+		ef := envField{
+			index: f.Index,
+			name:  prefix + name,
+			typ:   f.Type,
+		}
+		ef.def, ef.hasDef = envDefault(&f)
+		ef.required = f.flag("required")
+		if ef.typ.Kind() == Pointer {
+			ef.ptr = true
+			ef.typ = ef.typ.Elem()
+		}
+
+		switch {
+		case covers(ef.typ):
+		case ef.typ.Kind() == Slice && !ef.ptr && covers(ef.typ.Elem()):
+			ef.slice = true
+			ef.typ = ef.typ.Elem()
+		case ef.typ.Kind() == Struct:
+			ef.isNested = true
+			ef.nested = envPlan(ef.typ, ef.name+"_", covers, stack)
+		default:
+			continue
+		}
+		o = append(o, ef)
+	}
+	return o
+}
+
+// envDefault returns the value of the default option of "f", which extends to the end of the tag, and removes it from the options of "f".
+func envDefault(f *taggedField) (string, bool) {
+	for i, opt := range f.opts {
+		if v, ok := strings.CutPrefix(opt, "default="); ok {
+			o := strings.Join(append([]string{v}, f.opts[i+1:]...), ",")
+			f.opts = f.opts[:i]
+			return o, true
+		}
+	}
+	return "", false
+}
+
+// decode fills "v" from "src".
+func (x *Env) decode(src map[string]string, v Value, plan []envField) error {
+	lib := (*Library[Inverter[string]])(x.Parse)
+	for _, f := range plan {
+		var o Value
+		if f.isNested {
+			if !f.ptr {
+				if err := x.decode(src, v.FieldByIndex(f.index), f.nested); err != nil {
+					return err
+				}
+				continue
+			}
+			// optional sections only apply their defaults and requirements when present
+			if !envPresent(src, f.nested) {
+				continue
+			}
+			o = New(f.typ).Elem()
+			if err := x.decode(src, o, f.nested); err != nil {
+				return err
+			}
+		} else {
+			s, ok := src[f.name]
+			if !ok {
+				if f.hasDef {
+					s = f.def
+				} else if f.required {
+					return fmt.Errorf("%s: %w", f.name, ErrRequired)
+				} else {
+					continue
+				}
+			}
+
+			fn := lib.Get(f.typ)
+			if f.slice {
+				var parts []string
+				if s != "" {
+					parts = strings.Split(s, ",")
+				}
+				o = MakeSlice(SliceOf(f.typ), len(parts), len(parts))
+				for i, part := range parts {
+					ev, err := fn(strings.TrimSpace(part))
+					if err != nil {
+						return fmt.Errorf("%s: %w", f.name, err)
+					}
+					o.Index(i).Set(ev)
+				}
+			} else {
+				var err error
+				if o, err = fn(s); err != nil {
+					return fmt.Errorf("%s: %w", f.name, err)
+				}
+			}
+		}
+
+		fv := v.FieldByIndex(f.index)
+		if f.ptr {
+			p := New(f.typ)
+			p.Elem().Set(o)
+			fv.Set(p)
+		} else {
+			fv.Set(o)
+		}
+	}
+	return nil
+}
+
+// envPresent returns true if any variable of "plan" is present in "src".
+func envPresent(src map[string]string, plan []envField) bool {
+	for _, f := range plan {
+		if f.isNested {
+			if envPresent(src, f.nested) {
+				return true
+			}
+		} else if _, ok := src[f.name]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+func (x *Env) encode(dst map[string]string, v Value, plan []envField) error {
+	lib := (*Library[Converter[string]])(x.Format)
+	for _, f := range plan {
+		fv := v.FieldByIndex(f.index)
+		if f.ptr {
+			if fv.IsNil() {
+				continue
+			}
+			fv = fv.Elem()
+		}
+
+		switch {
+		case f.isNested:
+			if err := x.encode(dst, fv, f.nested); err != nil {
+				return err
+			}
+		case f.slice:
+			fn := lib.Get(f.typ)
+			parts := make([]string, fv.Len())
+			for i := range parts {
+				s, err := fn(fv.Index(i))
+				if err != nil {
+					return fmt.Errorf("%s: %w", f.name, err)
+				}
+				parts[i] = s
+			}
+			dst[f.name] = strings.Join(parts, ",")
+		default:
+			s, err := lib.Get(f.typ)(fv)
+			if err != nil {
+				return fmt.Errorf("%s: %w", f.name, err)
+			}
+			dst[f.name] = s
+		}
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package conv
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestEnv(t *testing.T) {
+	type db struct {
+		Host    string `env:"HOST,default=localhost"`
+		Port    int    `env:"PORT,required"`
+		Timeout time.Duration
+	}
+	type config struct {
+		Debug bool
+		Tags  []string `env:"TAGS"`
+		DB    db       `env:"DB"`
+		Cache *db      `env:"CACHE"`
+		Skip  string   `env:"-"`
+	}
+
+	x := &Env{
+		Parse:  NewInversion(Scheme[Inverter[string]]{TextInverter, StrconvInverter}.Build),
+		Format: NewConversion(Scheme[Converter[string]]{TextConverter, StrconvConverter}.Build),
+		Prefix: "APP_",
+	}
+	inv := NewInversion(x.Inverter)
+
+	env := map[string]string{
+		"APP_DEBUG":   "true",
+		"APP_TAGS":    "a, b",
+		"APP_DB_PORT": "5432",
+		"SKIP":        "x",
+	}
+	o, err := As[config](inv, env)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !o.Debug || len(o.Tags) != 2 || o.Tags[1] != "b" || o.DB.Host != "localhost" || o.DB.Port != 5432 || o.Cache != nil {
+		t.Error("wrong config", o)
+	}
+
+	delete(env, "APP_DB_PORT")
+	if _, err := As[config](inv, env); !errors.Is(err, ErrRequired) || !errors.Is(err, ErrMissingField) {
+		t.Error("expected required error", err)
+	}
+
+	c := NewConversion(x.Converter)
+	out, err := c.Call(o)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out["APP_TAGS"] != "a,b" || out["APP_DB_HOST"] != "localhost" || out["APP_DB_TIMEOUT"] != "0" || len(out) != 5 {
+		t.Error("wrong environment", out)
+	}
+}
+
+func TestEnvSliceDefault(t *testing.T) {
+	type config struct {
+		Hosts []string `env:"HOSTS,required,default=a,b"`
+		Port  int      `env:"PORT,default=80"`
+	}
+	x := &Env{Parse: NewInversion(StrconvInverter)}
+	inv := NewInversion(x.Inverter)
+
+	o, err := As[config](inv, map[string]string{})
+	if err != nil || len(o.Hosts) != 2 || o.Hosts[0] != "a" || o.Hosts[1] != "b" || o.Port != 80 {
+		t.Error("wrong defaults", o, err)
+	}
+	if o, err := As[config](inv, map[string]string{"HOSTS": "c"}); err != nil || len(o.Hosts) != 1 || o.Hosts[0] != "c" {
+		t.Error("variable should override the default", o, err)
+	}
+}
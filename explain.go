@@ -0,0 +1,113 @@
+package conv
+
+import (
+	"fmt"
+	. "reflect"
+	"runtime"
+	"strings"
+)
+
+// A Report explains how a type was, or failed to be, built for.
+type Report struct {
+	Type     Type
+	Covered  bool
+	Builder  string   // name of the Builder that covered the type, if any
+	Declined []string // names of the Builders that declined the type, in order
+
+	// Uncovered holds the component types, such as elements or fields, that the Builders don't cover either, when the type itself is not covered.
+	// Only the innermost ones are listed, as the likely causes: a struct with an uncovered field is left out, in favor of the field type.
+	Uncovered []Type
+}
+
+func (x Report) String() string {
+	if x.Covered {
+		return fmt.Sprintf("%v: built by %s", x.Type, x.Builder)
+	}
+	o := fmt.Sprintf("%v: declined by %s", x.Type, strings.Join(x.Declined, ", "))
+	if len(x.Declined) == 0 {
+		o = fmt.Sprintf("%v: no builders", x.Type)
+	}
+	if len(x.Uncovered) > 0 {
+		parts := make([]string, len(x.Uncovered))
+		for i, t := range x.Uncovered {
+			parts[i] = t.String()
+		}
+		o += "; uncovered components: " + strings.Join(parts, ", ")
+	}
+	return o
+}
+
+// Explain runs the members of the Scheme in order for type "t", reporting which declined it and which built it.
+// If none do, the components of "t" are checked as well, to point at the element or field types that are likely at fault.
+func (x Scheme[T]) Explain(t Type) Report {
+	o := Report{Type: t}
+	for _, b := range x {
+		if _, ok := b(t); ok {
+			o.Covered, o.Builder = true, builderName(b)
+			return o
+		}
+		o.Declined = append(o.Declined, builderName(b))
+	}
+	o.Uncovered = uncovered(t, func(t Type) bool {
+		_, ok := x.Build(t)
+		return ok
+	}, map[Type]bool{t: true})
+	return o
+}
+
+// Explain is the same as Scheme.Explain, treating the Builder of the Conversion as a single member.
+// Use Scheme.Explain for the details of each Scheme member.
+func (x *Conversion[T]) Explain(t Type) Report {
+	lib := (*Library[Converter[T]])(x)
+	o := Report{Type: t}
+	if _, ok := lib.Lookup(t); ok {
+		o.Covered, o.Builder = true, builderName(lib.b)
+		return o
+	}
+	o.Declined = []string{builderName(lib.b)}
+	o.Uncovered = uncovered(t, func(t Type) bool {
+		_, ok := lib.Lookup(t)
+		return ok
+	}, map[Type]bool{t: true})
+	return o
+}
+
+// uncovered returns the innermost component types of "t" that "covered" returns false for.
+// "seen" guards against recursive types.
+func uncovered(t Type, covered func(Type) bool, seen map[Type]bool) []Type {
+	var o []Type
+	for _, c := range components(t) {
+		if seen[c] || covered(c) {
+			continue
+		}
+		seen[c] = true
+		if inner := uncovered(c, covered, seen); len(inner) > 0 {
+			o = append(o, inner...)
+		} else {
+			o = append(o, c)
+		}
+	}
+	return o
+}
+
+// components returns the types that "t" is directly made of, as visited by Check.
+func components(t Type) []Type {
+	var o []Type
+	Check(t, func(c Type) bool {
+		if c != t {
+			o = append(o, c)
+		}
+		return true
+	})
+	return o
+}
+
+// builderName returns the function name of "b", for display.
+func builderName[T any](b Builder[T]) string {
+	f := runtime.FuncForPC(ValueOf(b).Pointer())
+	if f == nil {
+		return "?"
+	}
+	name := strings.TrimSuffix(f.Name(), "-fm")
+	return name[strings.LastIndex(name, "/")+1:]
+}
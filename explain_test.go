@@ -0,0 +1,49 @@
+package conv
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestExplain(t *testing.T) {
+	type item struct {
+		N int
+		C chan int
+	}
+	scheme := Scheme[Converter[string]]{StrconvConverter, UUIDConverter}
+
+	r := scheme.Explain(TypeEval[int]())
+	if !r.Covered || r.Builder != "conv.StrconvConverter" || r.Declined != nil {
+		t.Error("wrong covered report", r)
+	}
+	if s := r.String(); s != "int: built by conv.StrconvConverter" {
+		t.Error("wrong string", s)
+	}
+
+	r = scheme.Explain(TypeEval[[]item]())
+	if r.Covered || len(r.Declined) != 2 || r.Declined[1] != "conv.UUIDConverter" {
+		t.Error("wrong declined report", r)
+	}
+	// the channel field is at fault, rather than the slice or struct holding it
+	if len(r.Uncovered) != 1 || r.Uncovered[0] != TypeEval[chan int]() {
+		t.Error("wrong uncovered types", r.Uncovered)
+	}
+	if s := r.String(); !strings.HasSuffix(s, "; uncovered components: chan int") {
+		t.Error("wrong string", s)
+	}
+
+	c := NewConversion(scheme.Build)
+	if r := c.Explain(TypeEval[[]item]()); r.Covered || len(r.Declined) != 1 || len(r.Uncovered) != 1 {
+		t.Error("wrong conversion report", r)
+	}
+	if r := c.Explain(TypeEval[string]()); !r.Covered {
+		t.Error("string should be covered", r)
+	}
+
+	type node struct {
+		Next *node
+	}
+	if r := scheme.Explain(TypeEval[node]()); r.Covered || len(r.Uncovered) != 1 {
+		t.Error("wrong recursive report", r)
+	}
+}
@@ -0,0 +1,60 @@
+package conv
+
+import (
+	"strings"
+)
+
+// A FieldError is the failure of a single field of a struct conversion.
+// Path locates the field from the outermost struct, with the names of nested fields joined by dots, such as "Address.Zip". Elements of slices and maps don't add to the path.
+type FieldError struct {
+	Path string
+	Err  error
+}
+
+func (x *FieldError) Error() string {
+	return "field " + x.Path + ": " + x.Err.Error()
+}
+
+func (x *FieldError) Unwrap() error {
+	return x.Err
+}
+
+// FieldErrors is the error of struct Mappings, such as those of StructMap and Tree, holding the failures of all failed fields, in field order.
+// Like the errors of errors.Join, it unwraps into its FieldErrors, so that errors.Is and errors.As match failures of any field, while callers that need them one by one, such as handlers reporting per field validation messages, can use errors.As to obtain the FieldErrors itself.
+type FieldErrors []*FieldError
+
+func (x FieldErrors) Error() string {
+	s := make([]string, len(x))
+	for i, err := range x {
+		s[i] = err.Error()
+	}
+	return strings.Join(s, "\n")
+}
+
+func (x FieldErrors) Unwrap() []error {
+	o := make([]error, len(x))
+	for i, err := range x {
+		o[i] = err
+	}
+	return o
+}
+
+// add appends the failure "err" of field "name", merging nested FieldErrors under its path.
+func (x FieldErrors) add(name string, err error) FieldErrors {
+	nested, ok := err.(FieldErrors)
+	if !ok {
+		return append(x, &FieldError{Path: name, Err: err})
+	}
+	for _, e := range nested {
+		x = append(x, &FieldError{Path: name + "." + e.Path, Err: e.Err})
+	}
+	return x
+}
+
+// err returns "x" as an error, or nil if empty.
+func (x FieldErrors) err() error {
+	if len(x) == 0 {
+		return nil
+	}
+	return x
+}
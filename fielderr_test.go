@@ -0,0 +1,77 @@
+package conv
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestFieldErrors(t *testing.T) {
+	type address struct {
+		Zip  int
+		City string
+	}
+	type form struct {
+		Name    string
+		Age     int
+		Address address
+	}
+	type input struct {
+		Name    chan int
+		Age     int
+		Address struct {
+			Zip  chan int
+			City string
+		}
+	}
+
+	m := NewDeepMapper(nil)
+	var o form
+	err := m.Map(&o, input{Age: 3, Address: struct {
+		Zip  chan int
+		City string
+	}{City: "x"}})
+	var errs FieldErrors
+	if !errors.As(err, &errs) || len(errs) != 2 || errs[0].Path != "Name" || errs[1].Path != "Address.Zip" {
+		t.Fatal("wrong field errors", err)
+	}
+	if !errors.Is(err, ErrInvalid) || !errors.Is(errs[1], ErrInvalid) {
+		t.Error("field errors should unwrap", err)
+	}
+	if o.Age != 3 || o.Address.City != "x" {
+		t.Error("failures should not stop other fields", o)
+	}
+
+	type required struct {
+		A int `conv:",required"`
+		B int `conv:",required"`
+		C int
+	}
+	if err := m.Map(&required{}, struct{ C int }{}); !errors.As(err, &errs) || len(errs) != 2 || errs[1].Path != "B" || !errors.Is(err, ErrMissingField) {
+		t.Error("wrong missing fields", err)
+	}
+}
+
+func TestFieldErrorsTree(t *testing.T) {
+	type item struct {
+		Count int `json:"count"`
+	}
+	type order struct {
+		ID    int    `json:"id"`
+		Note  string `json:"note"`
+		Items []item `json:"items"`
+	}
+	m := NewTreeMapper(&Tree{Key: "json"}, nil)
+	var o order
+	err := m.Map(&o, map[string]any{
+		"items": []any{map[string]any{"count": []any{}}},
+		"note":  "n",
+		"id":    map[string]any{},
+	})
+	var errs FieldErrors
+	if !errors.As(err, &errs) || len(errs) != 2 || errs[0].Path != "id" || errs[1].Path != "items.count" {
+		t.Fatal("wrong field errors", err)
+	}
+	if o.Note != "n" {
+		t.Error("failures should not stop other keys", o)
+	}
+}
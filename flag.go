@@ -0,0 +1,113 @@
+package conv
+
+import (
+	"flag"
+	"fmt"
+	. "reflect"
+	"strings"
+)
+
+// Flags adapts string conversions to command line flags of arbitrary types.
+// Values are parsed through Parse, and formatted through Format for usage messages; Format may be nil, in which case values are printed with fmt.
+// Slices whose element type is covered by Parse, but not the slice type itself, are repeatable flags: each occurrence appends an element, with the first one replacing the default.
+type Flags struct {
+	Parse  *Inversion[string]
+	Format *Conversion[string]
+}
+
+// Value returns a flag.Value setting the value pointed to by "dst".
+// The returned value also implements flag.Getter. Returns ErrInvalid if "dst" is not a non-nil pointer, or if Parse doesn't cover its type.
+func (x *Flags) Value(dst any) (flag.Value, error) {
+	v := ValueOf(dst)
+	if v.Kind() != Pointer || v.IsNil() {
+		return nil, fmt.Errorf("flag destination %T: %w", dst, ErrInvalid)
+	}
+	v = v.Elem()
+	t := v.Type()
+
+	lib := (*Library[Inverter[string]])(x.Parse)
+	o := &flagValue{
+		x: x,
+		v: v,
+	}
+	var ok bool
+	if o.parse, ok = lib.Lookup(t); !ok {
+		if t.Kind() != Slice {
+			return nil, fmt.Errorf("flag type %s: %w", describeType(t), ErrInvalid)
+		}
+		if o.parse, ok = lib.Lookup(t.Elem()); !ok {
+			return nil, fmt.Errorf("flag type %s: %w", describeType(t), ErrInvalid)
+		}
+		o.repeat = true
+	}
+	return o, nil
+}
+
+// Var defines a flag in "fs", or flag.CommandLine if nil, using Value.
+func (x *Flags) Var(fs *flag.FlagSet, dst any, name, usage string) error {
+	v, err := x.Value(dst)
+	if err != nil {
+		return err
+	}
+	if fs == nil {
+		fs = flag.CommandLine
+	}
+	fs.Var(v, name, usage)
+	return nil
+}
+
+type flagValue struct {
+	x      *Flags
+	v      Value
+	parse  Inverter[string]
+	repeat bool
+	set    bool // repeatable flags have been set at least once
+}
+
+func (x *flagValue) String() string {
+	// the flag package calls String on zero values, to detect zero defaults
+	if x == nil || !x.v.IsValid() {
+		return ""
+	}
+	if x.repeat {
+		n := x.v.Len()
+		s := make([]string, n)
+		for i := 0; i < n; i++ {
+			s[i] = x.format(x.v.Index(i))
+		}
+		return strings.Join(s, ",")
+	}
+	return x.format(x.v)
+}
+
+func (x *flagValue) format(v Value) string {
+	if x.x.Format != nil {
+		if fn, ok := (*Library[Converter[string]])(x.x.Format).Lookup(v.Type()); ok {
+			if s, err := fn(v); err == nil {
+				return s
+			}
+		}
+	}
+	return fmt.Sprint(v.Interface())
+}
+
+func (x *flagValue) Set(s string) error {
+	o, err := x.parse(s)
+	if err != nil {
+		return err
+	}
+	if !x.repeat {
+		x.v.Set(o)
+		return nil
+	}
+	if !x.set {
+		x.v.SetZero()
+		x.set = true
+	}
+	x.v.Set(Append(x.v, o))
+	return nil
+}
+
+func (x *flagValue) Get() any {
+	return x.v.Interface()
+}
@@ -0,0 +1,49 @@
+package conv
+
+import (
+	"flag"
+	"io"
+	"net"
+	. "reflect"
+	"testing"
+)
+
+func TestFlags(t *testing.T) {
+	x := &Flags{
+		Parse:  NewInversion(Scheme[Inverter[string]]{TextInverter, StrconvInverter}.Build),
+		Format: NewConversion(Scheme[Converter[string]]{TextConverter, StrconvConverter}.Build),
+	}
+
+	var (
+		ip    = net.IPv4(127, 0, 0, 1)
+		level = uint8(3)
+		ports = []int{80}
+	)
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	for name, dst := range map[string]any{"ip": &ip, "level": &level, "port": &ports} {
+		if err := x.Var(fs, dst, name, ""); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if s := fs.Lookup("port").DefValue; s != "80" {
+		t.Error("wrong default", s)
+	}
+
+	if err := fs.Parse([]string{"-ip", "10.0.0.1", "-level", "7", "-port", "8080", "-port", "8443"}); err != nil {
+		t.Fatal(err)
+	}
+	if ip.String() != "10.0.0.1" || level != 7 || !DeepEqual(ports, []int{8080, 8443}) {
+		t.Error("wrong values", ip, level, ports)
+	}
+	if v := fs.Lookup("level").Value.(flag.Getter).Get(); v != uint8(7) {
+		t.Error("wrong getter value", v)
+	}
+
+	if err := fs.Parse([]string{"-level", "300"}); err == nil {
+		t.Error("expected parse error")
+	}
+	if _, err := x.Value(&struct{}{}); err == nil {
+		t.Error("expected unsupported type error")
+	}
+}
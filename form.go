@@ -0,0 +1,232 @@
+package conv
+
+import (
+	"fmt"
+	"net/url"
+	. "reflect"
+)
+
+// Form is a set of Builders converting between structs and url.Values, for query string and form binding.
+// Values are converted as with CSV, through Format and Parse.
+//
+// Fields are configured using the "form" struct tag:
+//
+//	`form:"name"`            key name, defaulting to the field name
+//	`form:"name,omitempty"`  don't encode zero values
+//	`form:"-"`               ignore the field
+//
+// Fields whose types are covered by Format (or Parse) are single values. Slices of such types are repeated values.
+// Other struct fields, or pointers to them, are nested: their keys are prefixed by the parent key, as "parent.child", or "parent[child]" if Brackets is set.
+// Nil pointer fields are not encoded, and are only allocated on decode if any of their keys are present.
+type Form struct {
+	Format   *Conversion[string]
+	Parse    *Inversion[string]
+	Brackets bool
+}
+
+type formKind uint8
+
+const (
+	formValue formKind = iota
+	formSlice
+	formNested
+)
+
+type formField struct {
+	index     []int
+	key       string
+	kind      formKind
+	ptr       bool
+	typ       Type // the value type, slice element type or nested struct type, after pointer indirection
+	omitEmpty bool
+	nested    []formField
+}
+
+// Converter is a Builder of url.Values Converters for struct types.
+func (x *Form) Converter(t Type) (Converter[url.Values], bool) {
+	if t.Kind() != Struct {
+		return nil, false
+	}
+	lib := (*Library[Converter[string]])(x.Format)
+	plan := x.plan(t, "", func(t Type) bool {
+		_, ok := lib.Lookup(t)
+		return ok
+	}, nil)
+
+	return func(v Value) (url.Values, error) {
+		o := make(url.Values)
+		if err := x.encode(o, v, plan); err != nil {
+			return nil, err
+		}
+		return o, nil
+	}, true
+}
+
+// Inverter is a Builder of url.Values Inverters for struct types.
+func (x *Form) Inverter(t Type) (Inverter[url.Values], bool) {
+	if t.Kind() != Struct {
+		return nil, false
+	}
+	lib := (*Library[Inverter[string]])(x.Parse)
+	plan := x.plan(t, "", func(t Type) bool {
+		_, ok := lib.Lookup(t)
+		return ok
+	}, nil)
+
+	return func(v url.Values) (Value, error) {
+		o := New(t).Elem()
+		if _, err := x.decode(v, o, plan); err != nil {
+			return Value{}, err
+		}
+		return o, nil
+	}, true
+}
+
+// plan resolves the fields of struct type "t", using "covers" to determine which types are single values.
+// "stack" holds the struct types being planned, so that recursive types stop nesting.
+func (x *Form) plan(t Type, prefix string, covers func(Type) bool, stack []Type) []formField {
+	for _, st := range stack {
+		if st == t {
+			return nil
+		}
+	}
+	stack = append(stack, t)
+
+	var o []formField
+	for _, f := range taggedFields(t, "form") {
+		ff := formField{
+			index:     f.Index,
+			key:       x.join(prefix, f.name),
+			typ:       f.Type,
+			omitEmpty: f.flag("omitempty"),
+		}
+		if ff.typ.Kind() == Pointer {
+			ff.ptr = true
+			ff.typ = ff.typ.Elem()
+		}
+
+		switch {
+		case covers(ff.typ):
+			ff.kind = formValue
+		case ff.typ.Kind() == Slice && !ff.ptr && covers(ff.typ.Elem()):
+			ff.kind = formSlice
+			ff.typ = ff.typ.Elem()
+		case ff.typ.Kind() == Struct:
+			ff.kind = formNested
+			ff.nested = x.plan(ff.typ, ff.key, covers, stack)
+		default:
+			continue
+		}
+		o = append(o, ff)
+	}
+	return o
+}
+
+func (x *Form) join(prefix, name string) string {
+	switch {
+	case prefix == "":
+		return name
+	case x.Brackets:
+		return prefix + "[" + name + "]"
+	}
+	return prefix + "." + name
+}
+
+func (x *Form) encode(dst url.Values, v Value, plan []formField) error {
+	lib := (*Library[Converter[string]])(x.Format)
+	for _, f := range plan {
+		fv := v.FieldByIndex(f.index)
+		if f.ptr {
+			if fv.IsNil() {
+				continue
+			}
+			fv = fv.Elem()
+		}
+		if f.omitEmpty && fv.IsZero() {
+			continue
+		}
+
+		switch f.kind {
+		case formValue:
+			s, err := lib.Get(f.typ)(fv)
+			if err != nil {
+				return fmt.Errorf("key %s: %w", f.key, err)
+			}
+			dst.Set(f.key, s)
+		case formSlice:
+			fn := lib.Get(f.typ)
+			for i, n := 0, fv.Len(); i < n; i++ {
+				s, err := fn(fv.Index(i))
+				if err != nil {
+					return fmt.Errorf("key %s: %w", f.key, err)
+				}
+				dst.Add(f.key, s)
+			}
+		case formNested:
+			if err := x.encode(dst, fv, f.nested); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// decode fills "v" from "src", returning true if any key was present.
+func (x *Form) decode(src url.Values, v Value, plan []formField) (bool, error) {
+	lib := (*Library[Inverter[string]])(x.Parse)
+	var found bool
+	for _, f := range plan {
+		var (
+			o   Value
+			ok  bool
+			err error
+		)
+		switch f.kind {
+		case formValue:
+			vals := src[f.key]
+			if len(vals) == 0 {
+				continue
+			}
+			if o, err = lib.Get(f.typ)(vals[0]); err != nil {
+				return false, fmt.Errorf("key %s: %w", f.key, err)
+			}
+		case formSlice:
+			vals, present := src[f.key]
+			if !present {
+				continue
+			}
+			fn := lib.Get(f.typ)
+			o = MakeSlice(SliceOf(f.typ), len(vals), len(vals))
+			for i, s := range vals {
+				ev, err := fn(s)
+				if err != nil {
+					return false, fmt.Errorf("key %s: %w", f.key, err)
+				}
+				o.Index(i).Set(ev)
+			}
+		case formNested:
+			o = New(f.typ).Elem()
+			if !f.ptr {
+				// decode in place, to keep any existing values
+				o = v.FieldByIndex(f.index)
+			}
+			if ok, err = x.decode(src, o, f.nested); err != nil {
+				return false, err
+			}
+			if !ok {
+				continue
+			}
+		}
+
+		found = true
+		fv := v.FieldByIndex(f.index)
+		if f.ptr {
+			p := New(f.typ)
+			p.Elem().Set(o)
+			fv.Set(p)
+		} else if f.kind != formNested {
+			fv.Set(o)
+		}
+	}
+	return found, nil
+}
@@ -0,0 +1,58 @@
+package conv
+
+import (
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestForm(t *testing.T) {
+	type filter struct {
+		Since time.Time `form:"since"`
+		Tags  []string  `form:"tag"`
+	}
+	type query struct {
+		Q      string  `form:"q"`
+		Page   int     `form:"page,omitempty"`
+		Filter filter  `form:"f"`
+		Opt    *filter `form:"opt"`
+		Self   *query
+		Skip   bool `form:"-"`
+	}
+
+	x := &Form{
+		Format: NewConversion(Scheme[Converter[string]]{TextConverter, StrconvConverter}.Build),
+		Parse:  NewInversion(Scheme[Inverter[string]]{TextInverter, StrconvInverter}.Build),
+	}
+	c := NewConversion(x.Converter)
+	inv := NewInversion(x.Inverter)
+
+	tm := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	v := query{Q: "go", Filter: filter{tm, []string{"a", "b"}}, Skip: true}
+	vals, err := c.Call(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := "f.since=2020-01-01T00%3A00%3A00Z&f.tag=a&f.tag=b&q=go"
+	if s := vals.Encode(); s != exp {
+		t.Error("wrong encoding", s)
+	}
+
+	o, err := As[query](inv, vals)
+	if err != nil || o.Q != "go" || !o.Filter.Since.Equal(tm) || len(o.Filter.Tags) != 2 || o.Opt != nil || o.Skip {
+		t.Error("wrong decoding", o, err)
+	}
+
+	x.Brackets = true
+	inv = NewInversion(x.Inverter)
+	vals, _ = url.ParseQuery("q=x&page=2&opt[tag]=c")
+	o, err = As[query](inv, vals)
+	if err != nil || o.Page != 2 || o.Opt == nil || o.Opt.Tags[0] != "c" {
+		t.Error("wrong bracket decoding", o, err)
+	}
+
+	vals, _ = url.ParseQuery("page=x")
+	if _, err := As[query](inv, vals); err == nil {
+		t.Error("expected parse error")
+	}
+}
@@ -0,0 +1,248 @@
+package gen
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"path/filepath"
+	"strconv"
+)
+
+const convPath = "github.com/blitz-frost/conv"
+
+// A Call is a conversion call site:
+//
+//   - Call, calling Conversion.Call; Type is the argument type
+//   - As, calling the As function; Type is its first type parameter
+//   - Map, calling Mapper.Map; Type is the destination type, and Src the source type
+//
+// Calls are checked against their scheme, the package level variable holding the Conversion, Inversion or Mapper.
+// Reason explains why a Call cannot be checked, such as schemes held in local variables, or arguments of interface type, whose dynamic type is not known statically.
+type Call struct {
+	Pos    token.Position
+	Kind   string
+	Scheme string
+	Type   types.Type
+	Src    types.Type
+	Reason string
+
+	lib types.Type // Conversion, Inversion or Mapper type
+}
+
+// Calls returns the conversion call sites of the package, in source order.
+func (x *Package) Calls() []Call {
+	var o []Call
+	for _, f := range x.Files {
+		ast.Inspect(f, func(n ast.Node) bool {
+			if e, ok := n.(*ast.CallExpr); ok {
+				if c, ok := x.call(e); ok {
+					o = append(o, c)
+				}
+			}
+			return true
+		})
+	}
+	return o
+}
+
+func (x *Package) call(e *ast.CallExpr) (Call, bool) {
+	fun := unparen(e.Fun)
+	switch f := fun.(type) {
+	case *ast.IndexExpr:
+		fun = f.X
+	case *ast.IndexListExpr:
+		fun = f.X
+	}
+	var id *ast.Ident
+	switch f := unparen(fun).(type) {
+	case *ast.Ident:
+		id = f
+	case *ast.SelectorExpr:
+		id = f.Sel
+	default:
+		return Call{}, false
+	}
+	fn, ok := x.Info.Uses[id].(*types.Func)
+	if !ok || fn.Pkg() == nil || fn.Pkg().Path() != convPath {
+		return Call{}, false
+	}
+
+	o := Call{Pos: x.Fset.Position(e.Pos())}
+	var recv ast.Expr
+	if sig := fn.Type().(*types.Signature); sig.Recv() != nil {
+		sel, ok := unparen(fun).(*ast.SelectorExpr)
+		if !ok {
+			return Call{}, false
+		}
+		o.lib, recv = sig.Recv().Type(), sel.X
+		name := receiverName(o.lib)
+		switch {
+		case name == "Conversion" && fn.Name() == "Call" && len(e.Args) == 1:
+			o.Kind, o.Type = "Call", x.Info.TypeOf(e.Args[0])
+		case name == "Mapper" && fn.Name() == "Map" && len(e.Args) == 2:
+			o.Kind, o.Src = "Map", x.Info.TypeOf(e.Args[1])
+			if p, ok := x.Info.TypeOf(e.Args[0]).Underlying().(*types.Pointer); ok {
+				o.Type = p.Elem()
+			} else {
+				o.Reason = "destination is not a pointer"
+			}
+		default:
+			return Call{}, false
+		}
+	} else {
+		if fn.Name() != "As" || len(e.Args) != 2 {
+			return Call{}, false
+		}
+		inst, ok := x.Info.Instances[id]
+		if !ok {
+			return Call{}, false
+		}
+		o.Kind, o.Type, recv = "As", inst.TypeArgs.At(0), e.Args[0]
+		o.lib = x.Info.TypeOf(e.Args[0])
+	}
+
+	if o.Reason == "" {
+		o.Reason = x.checkable(&o, recv)
+	}
+	return o, true
+}
+
+// checkable resolves the scheme of "c", held by "recv", returning the reason if the Call cannot be checked.
+func (x *Package) checkable(c *Call, recv ast.Expr) string {
+	if id, ok := unparen(recv).(*ast.Ident); ok {
+		if v, ok := x.Info.Uses[id].(*types.Var); ok && v.Parent() == x.Types.Scope() {
+			if _, ok := v.Type().Underlying().(*types.Pointer); ok {
+				c.Scheme = v.Name()
+			}
+		}
+	}
+	if c.Scheme == "" {
+		return "scheme is not a package level pointer variable"
+	}
+	for _, t := range [2]types.Type{c.Type, c.Src} {
+		switch {
+		case t == nil:
+		case types.IsInterface(t):
+			return "dynamic type of " + types.TypeString(t, types.RelativeTo(x.Types)) + " is not known"
+		case !nameable(t, x.Types, make(map[types.Type]bool)):
+			return types.TypeString(t, nil) + " cannot be named outside its package"
+		}
+	}
+	return ""
+}
+
+// CoverageTest returns the formatted source of a test file for the package, failing for each checkable Call whose type its scheme cannot build a function for.
+// Schemes that are still nil after package initialization are not checked.
+func (x *Package) CoverageTest(calls []Call) ([]byte, error) {
+	g := newGenerator(x.Types)
+	conv := g.importName(convPath, "conv")
+	testing := g.importName("testing", "testing")
+
+	var body bytes.Buffer
+	fmt.Fprintf(&body, "\nfunc TestConvCoverage(t *%s.T) {\n", testing)
+	for _, c := range calls {
+		if c.Reason != "" {
+			continue
+		}
+		var lib, typ string
+		switch c.Kind {
+		case "Call":
+			lib = conv + ".Library[" + conv + ".Converter[" + g.typ(typeArg(c.lib)) + "]]"
+			typ = conv + ".TypeEval[" + g.typ(c.Type) + "]()"
+		case "As":
+			lib = conv + ".Library[" + conv + ".Inverter[" + g.typ(typeArg(c.lib)) + "]]"
+			typ = conv + ".TypeEval[" + g.typ(c.Type) + "]()"
+		case "Map":
+			lib = conv + ".Library[" + conv + ".Mapping]"
+			typ = conv + ".MappingType(" + conv + ".TypeEval[" + g.typ(c.Type) + "](), " + conv + ".TypeEval[" + g.typ(c.Src) + "]())"
+		}
+		pos := filepath.Base(c.Pos.Filename) + ":" + strconv.Itoa(c.Pos.Line) + ":" + strconv.Itoa(c.Pos.Column)
+		msg := fmt.Sprintf("%s: %s cannot %s %s", pos, c.Scheme, c.Kind, g.typ(c.Type))
+		if c.Src != nil {
+			msg += " from " + g.typ(c.Src)
+		}
+		fmt.Fprintf(&body, "if %s != nil {\n", c.Scheme)
+		fmt.Fprintf(&body, "if _, ok := (*%s)(%s).Lookup(%s); !ok {\n", lib, c.Scheme, typ)
+		fmt.Fprintf(&body, "t.Error(%s)\n}\n}\n", strconv.Quote(msg))
+	}
+	body.WriteString("}\n")
+	return g.file("conv-cover", body.Bytes())
+}
+
+// receiverName returns the type name of method receiver type "t".
+func receiverName(t types.Type) string {
+	if p, ok := t.(*types.Pointer); ok {
+		t = p.Elem()
+	}
+	if n, ok := t.(*types.Named); ok {
+		return n.Obj().Name()
+	}
+	return ""
+}
+
+// typeArg returns the type argument of generic scheme type "t", such as T in *Conversion[T].
+func typeArg(t types.Type) types.Type {
+	if p, ok := t.(*types.Pointer); ok {
+		t = p.Elem()
+	}
+	return t.(*types.Named).TypeArgs().At(0)
+}
+
+// nameable returns true if "t" can be written in a file of package "pkg".
+func nameable(t types.Type, pkg *types.Package, seen map[types.Type]bool) bool {
+	if seen[t] {
+		return true
+	}
+	seen[t] = true
+	switch t := t.(type) {
+	case *types.Named:
+		if obj := t.Obj(); obj.Pkg() != nil && obj.Pkg() != pkg && !obj.Exported() {
+			return false
+		}
+		if args := t.TypeArgs(); args != nil {
+			for i := 0; i < args.Len(); i++ {
+				if !nameable(args.At(i), pkg, seen) {
+					return false
+				}
+			}
+		}
+		return true
+	case *types.Pointer:
+		return nameable(t.Elem(), pkg, seen)
+	case *types.Slice:
+		return nameable(t.Elem(), pkg, seen)
+	case *types.Array:
+		return nameable(t.Elem(), pkg, seen)
+	case *types.Chan:
+		return nameable(t.Elem(), pkg, seen)
+	case *types.Map:
+		return nameable(t.Key(), pkg, seen) && nameable(t.Elem(), pkg, seen)
+	case *types.Struct:
+		for i := 0; i < t.NumFields(); i++ {
+			if f := t.Field(i); (f.Pkg() != pkg && !f.Exported()) || !nameable(f.Type(), pkg, seen) {
+				return false
+			}
+		}
+	case *types.Signature:
+		for _, tuple := range [2]*types.Tuple{t.Params(), t.Results()} {
+			for i := 0; i < tuple.Len(); i++ {
+				if !nameable(tuple.At(i).Type(), pkg, seen) {
+					return false
+				}
+			}
+		}
+	}
+	return true
+}
+
+func unparen(e ast.Expr) ast.Expr {
+	for {
+		p, ok := e.(*ast.ParenExpr)
+		if !ok {
+			return e
+		}
+		e = p.X
+	}
+}
@@ -0,0 +1,97 @@
+package gen
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const coverSource = `package app
+
+import "github.com/blitz-frost/conv"
+
+var (
+	enc = conv.NewConversion(conv.StrconvConverter)
+	dec = conv.NewInversion(conv.StrconvInverter)
+	m   = conv.NewDeepMapper(nil)
+)
+
+type Point struct{ X int }
+
+func Use(p Point, v any) {
+	enc.Call(1)
+	enc.Call(p)
+	enc.Call(v)
+	conv.As[int](dec, "1")
+	conv.As[Point](dec, "1")
+	var d Point
+	m.Map(&d, struct{ X int8 }{})
+	local := conv.NewConversion(conv.StrconvConverter)
+	local.Call(p)
+}
+`
+
+func TestCoverage(t *testing.T) {
+	gobin, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go command not available")
+	}
+	dir := t.TempDir()
+	root, _ := filepath.Abs("..")
+	write := func(name, content string) {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0666); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("go.mod", "module example.com/app\n\ngo 1.20\n\nrequire github.com/blitz-frost/conv v0.0.0\n\nreplace github.com/blitz-frost/conv => "+root+"\n")
+	write("app.go", coverSource)
+	t.Setenv("GOFLAGS", "-mod=mod")
+	t.Setenv("GOPROXY", "off")
+
+	wd, _ := os.Getwd()
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(wd)
+	pkg, err := Load(".")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got []string
+	for _, c := range pkg.Calls() {
+		got = append(got, c.Kind+" "+c.Scheme+" "+c.Reason)
+	}
+	exp := []string{
+		"Call enc ",
+		"Call enc ",
+		"Call enc dynamic type of any is not known",
+		"As dec ",
+		"As dec ",
+		"Map m ",
+		"Call  scheme is not a package level pointer variable",
+	}
+	if strings.Join(got, "\n") != strings.Join(exp, "\n") {
+		t.Errorf("wrong calls:\n%s", strings.Join(got, "\n"))
+	}
+
+	src, err := pkg.CoverageTest(pkg.Calls())
+	if err != nil {
+		t.Fatal(err)
+	}
+	write("conv_cover_test.go", string(src))
+	out, err := exec.Command(gobin, "test", ".").CombinedOutput()
+	if err == nil {
+		t.Fatalf("expected failing coverage test:\n%s", out)
+	}
+	for _, s := range []string{"app.go:15:2: enc cannot Call Point", "app.go:18:2: dec cannot As Point"} {
+		if !strings.Contains(string(out), s) {
+			t.Errorf("missing %q in:\n%s", s, out)
+		}
+	}
+	if n := strings.Count(string(out), "cannot"); n != 2 {
+		t.Errorf("expected 2 failures, got %d:\n%s", n, out)
+	}
+}
@@ -0,0 +1,279 @@
+package gen
+
+import (
+	"bytes"
+	"fmt"
+	"go/token"
+	"hash/fnv"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/blitz-frost/conv"
+)
+
+// Declarations returns the formatted source of a file for the package, declaring a named type for each of "offers", as recorded from conv.Handshake.Offer.
+// This freezes types constructed at run time, such as through reflect.StructOf in dynamic pipelines, into static code with the same bases.
+//
+// Types are named after their offer names, converted to exported identifiers, such as UserCreated for "user.created". Recursive parts nested inside them are declared separately, named with a numbered suffix.
+// The file also declares an expectTypes(*conv.Handshake) function, registering the declared types under their offer names.
+//
+// Fails with conv.ErrBase if a base is malformed or doesn't match its fingerprint.
+func (x *Package) Declarations(offers []conv.TypeOffer) ([]byte, error) {
+	g := newGenerator(x.Types)
+	handshake := g.importName(convPath, "conv") + ".Handshake"
+
+	var body, expect bytes.Buffer
+	for _, offer := range offers {
+		h := fnv.New64a()
+		h.Write([]byte(offer.Base))
+		if h.Sum64() != offer.Fingerprint {
+			return nil, fmt.Errorf("%s: fingerprint mismatch: %w", offer.Name, conv.ErrBase)
+		}
+		name := exportedName(offer.Name)
+		if name == "" || g.taken(name) || g.names[name] {
+			return nil, fmt.Errorf("%s: cannot declare as %q", offer.Name, name)
+		}
+		g.names[name] = true
+
+		p := basePrinter{
+			g:    g,
+			s:    offer.Base,
+			w:    &body,
+			name: name,
+		}
+		text, err := p.print()
+		if err != nil || p.pos != len(p.s) {
+			return nil, fmt.Errorf("%s: %w", offer.Name, conv.ErrBase)
+		}
+		fmt.Fprintf(&body, "\ntype %s %s\n", name, text)
+		fmt.Fprintf(&expect, "h.Expect(%q, %s.TypeEval[%s]())\n", offer.Name, g.imports[convPath], name)
+	}
+
+	fmt.Fprintf(&body, "\n// expectTypes registers the declared types with \"h\", under their recorded names.\nfunc expectTypes(h *%s) {\n%s}\n", handshake, expect.Bytes())
+	return g.file("conv-gen", body.Bytes())
+}
+
+// exportedName converts offer name "s" into an exported identifier, capitalizing its letter and digit sequences.
+func exportedName(s string) string {
+	var o strings.Builder
+	for _, part := range strings.FieldsFunc(s, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		r := []rune(part)
+		r[0] = unicode.ToUpper(r[0])
+		o.WriteString(string(r))
+	}
+	name := o.String()
+	if name != "" && !token.IsExported(name) {
+		name = "T" + name
+	}
+	return name
+}
+
+// A basePrinter converts a conv.Base description into Go type syntax.
+// Back references to nested composite types are resolved by declaring those types separately, to "w".
+type basePrinter struct {
+	g   *generator
+	s   string
+	pos int
+	w   *bytes.Buffer
+
+	name string   // root type name
+	ref  []string // names of the composite types currently being printed, by depth; empty until referenced, except for the root
+	ptr  []bool   // whether the composite types currently being printed are pointers
+}
+
+func (x *basePrinter) consume(prefix string) bool {
+	if strings.HasPrefix(x.s[x.pos:], prefix) {
+		x.pos += len(prefix)
+		return true
+	}
+	return false
+}
+
+// until returns the text up to the first of the "stop" bytes, excluding it.
+func (x *basePrinter) until(stop string) string {
+	i := strings.IndexAny(x.s[x.pos:], stop)
+	if i < 0 {
+		i = len(x.s) - x.pos
+	}
+	o := x.s[x.pos : x.pos+i]
+	x.pos += i
+	return o
+}
+
+// print returns the Go syntax of the type at the current position.
+func (x *basePrinter) print() (o string, err error) {
+	if x.consume("@") {
+		n, err := strconv.Atoi(x.until("[]*(){};, "))
+		if err != nil || n < 0 || n >= len(x.ref) {
+			return "", conv.ErrBase
+		}
+		if n > 0 && x.ptr[n] && n+1 < len(x.ref) {
+			// name the pointed type rather than the pointer, as it is usually a struct; the root keeps its own name
+			n++
+			defer func() {
+				o = "*" + o
+			}()
+		}
+		if x.ref[n] == "" {
+			x.ref[n] = x.fresh()
+		}
+		return x.ref[n], nil
+	}
+
+	composite := false
+	for _, prefix := range [...]string{"[", "*", "map[", "<-chan ", "chan", "func", "interface{", "struct{"} {
+		composite = composite || strings.HasPrefix(x.s[x.pos:], prefix)
+	}
+	if !composite {
+		switch name := x.until("[]*(){};, "); name {
+		case "bool", "int", "int8", "int16", "int32", "int64", "uint", "uint8", "uint16", "uint32", "uint64", "uintptr", "float32", "float64", "complex64", "complex128", "string":
+			return name, nil
+		case "unsafe.Pointer":
+			return x.g.importName("unsafe", "unsafe") + ".Pointer", nil
+		}
+		return "", conv.ErrBase
+	}
+
+	// the root composite is referenced by its declared name
+	depth := len(x.ref)
+	if x.pos == 0 {
+		x.ref = append(x.ref, x.name)
+	} else {
+		x.ref = append(x.ref, "")
+	}
+	x.ptr = append(x.ptr, strings.HasPrefix(x.s[x.pos:], "*"))
+	o, err = x.composite()
+	ref := x.ref[depth]
+	x.ref, x.ptr = x.ref[:depth], x.ptr[:depth]
+	if err != nil {
+		return "", err
+	}
+	if depth > 0 && ref != "" {
+		fmt.Fprintf(x.w, "\ntype %s %s\n", ref, o)
+		return ref, nil
+	}
+	return o, nil
+}
+
+func (x *basePrinter) composite() (string, error) {
+	elem := func(prefix string) (string, error) {
+		e, err := x.print()
+		return prefix + e, err
+	}
+
+	switch {
+	case x.consume("[]"):
+		return elem("[]")
+	case x.consume("["):
+		n := x.until("]")
+		if _, err := strconv.Atoi(n); err != nil || !x.consume("]") {
+			return "", conv.ErrBase
+		}
+		return elem("[" + n + "]")
+	case x.consume("*"):
+		return elem("*")
+	case x.consume("map["):
+		key, err := x.print()
+		if err != nil || !x.consume("]") {
+			return "", conv.ErrBase
+		}
+		return elem("map[" + key + "]")
+	case x.consume("<-chan "):
+		return elem("<-chan ")
+	case x.consume("chan<- "):
+		return elem("chan<- ")
+	case x.consume("chan "):
+		if x.consume("(") {
+			e, err := x.print()
+			if err != nil || !x.consume(")") {
+				return "", conv.ErrBase
+			}
+			return "chan (" + e + ")", nil
+		}
+		return elem("chan ")
+	case x.consume("func"):
+		sig, err := x.signature()
+		return "func" + sig, err
+	case x.consume("interface{"):
+		var methods []string
+		for !x.consume("}") {
+			if len(methods) > 0 && !x.consume(";") {
+				return "", conv.ErrBase
+			}
+			name := x.until("(")
+			if !token.IsIdentifier(name) {
+				return "", conv.ErrBase
+			}
+			sig, err := x.signature()
+			if err != nil {
+				return "", err
+			}
+			methods = append(methods, name+sig)
+		}
+		return "interface{" + strings.Join(methods, "; ") + "}", nil
+	case x.consume("struct{"):
+		var fields []string
+		for !x.consume("}") {
+			if len(fields) > 0 && !x.consume(";") {
+				return "", conv.ErrBase
+			}
+			name := x.until(" ")
+			if !token.IsIdentifier(name) || !x.consume(" ") {
+				return "", conv.ErrBase
+			}
+			t, err := x.print()
+			if err != nil {
+				return "", err
+			}
+			fields = append(fields, name+" "+t)
+		}
+		if fields == nil {
+			return "struct{}", nil
+		}
+		return "struct {\n" + strings.Join(fields, "\n") + "\n}", nil
+	}
+	return "", conv.ErrBase
+}
+
+// signature prints the parameter and result lists of a func or method.
+func (x *basePrinter) signature() (string, error) {
+	var o strings.Builder
+	for range [2]struct{}{} {
+		if !x.consume("(") {
+			return "", conv.ErrBase
+		}
+		o.WriteByte('(')
+		for n := 0; !x.consume(")"); n++ {
+			if n > 0 {
+				if !x.consume(",") {
+					return "", conv.ErrBase
+				}
+				o.WriteString(", ")
+			}
+			if x.consume("...") {
+				o.WriteString("...")
+			}
+			t, err := x.print()
+			if err != nil {
+				return "", err
+			}
+			o.WriteString(t)
+		}
+		o.WriteByte(')')
+	}
+	return o.String(), nil
+}
+
+// fresh returns an unused top level name, made of the root name and a number.
+func (x *basePrinter) fresh() string {
+	for i := 1; ; i++ {
+		name := x.name + strconv.Itoa(i)
+		if !x.g.taken(name) && !x.g.names[name] {
+			x.g.names[name] = true
+			return name
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package gen
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/blitz-frost/conv"
+)
+
+type declOuter struct {
+	In *declInner
+	F  func(int, ...string) (bool, error)
+	C  chan (<-chan int)
+	p  uintptr
+}
+
+type declInner struct {
+	Self *declInner
+	Up   *declOuter
+	Arr  [2]map[string]any
+}
+
+type declList *struct {
+	Next declList
+}
+
+const declMain = `package main
+
+import (
+	"fmt"
+
+	"github.com/blitz-frost/conv"
+)
+
+func main() {
+	h := &conv.Handshake{}
+	expectTypes(h)
+	for _, o := range h.Offer() {
+		fmt.Println(o.Name, o.Fingerprint)
+	}
+}
+`
+
+func TestDeclarations(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0666); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("doc.go", "package main\n")
+	pkg, err := Load(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var h conv.Handshake
+	h.Expect("node", reflect.TypeOf(declOuter{}))
+	h.Expect("list", conv.TypeEval[declList]())
+	h.Expect("user.created", reflect.StructOf([]reflect.StructField{
+		{Name: "ID", Type: reflect.TypeOf(int64(0))},
+		{Name: "Tags", Type: reflect.TypeOf([]string{})},
+	}))
+	offers := h.Offer()
+
+	src, err := pkg.Declarations(offers)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, s := range []string{"type Node struct {", "type Node1 struct {", "Self *Node1", "Up   *Node", "type UserCreated struct {", "type List *struct {"} {
+		if !strings.Contains(string(src), s) {
+			t.Errorf("missing %q:\n%s", s, src)
+		}
+	}
+
+	bad := offers[2]
+	bad.Base = "struct{ID int64}"
+	if _, err := pkg.Declarations([]conv.TypeOffer{bad}); !errors.Is(err, conv.ErrBase) {
+		t.Error("expected fingerprint mismatch, got", err)
+	}
+
+	gobin, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go command not available")
+	}
+	root, _ := filepath.Abs("..")
+	write("types.go", string(src))
+	write("main.go", declMain)
+	write("go.mod", "module example.com/decltest\n\ngo 1.20\n\nrequire github.com/blitz-frost/conv v0.0.0\n\nreplace github.com/blitz-frost/conv => "+root+"\n")
+	cmd := exec.Command(gobin, "run", ".")
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GOFLAGS=-mod=mod", "GOPROXY=off")
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("%v\n%s\n%s", err, out, src)
+	}
+	var exp strings.Builder
+	for _, o := range offers {
+		fmt.Fprintln(&exp, o.Name, o.Fingerprint)
+	}
+	if string(out) != exp.String() {
+		t.Errorf("declared types differ from offers:\n%s\nexpected:\n%s\n%s", out, exp.String(), src)
+	}
+}
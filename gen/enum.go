@@ -0,0 +1,61 @@
+package gen
+
+import (
+	"bytes"
+	"fmt"
+	"go/types"
+	"sort"
+)
+
+// Enums returns the formatted source of a file for the package, registering the names of each of the named enum types with a conv.Enums.
+// Enum types must be integer types of the package; their names are the identifiers of the package level constants of that type, in declaration order. Constants repeating the value of a previous one are left out.
+//
+// The file declares:
+//
+//	// registerEnums registers the names of the enum types of the package with "e".
+//	func registerEnums(e *conv.Enums)
+func (x *Package) Enums(names []string) ([]byte, error) {
+	g := newGenerator(x.Types)
+	conv := g.importName(convPath, "conv")
+	if g.taken("registerEnums") {
+		return nil, fmt.Errorf("registerEnums is already declared")
+	}
+
+	var body bytes.Buffer
+	fmt.Fprintf(&body, "\n// registerEnums registers the names of the enum types of the package with \"e\".\nfunc registerEnums(e *%s.Enums) {\n", conv)
+	for _, name := range names {
+		obj, ok := x.Types.Scope().Lookup(name).(*types.TypeName)
+		if !ok {
+			return nil, fmt.Errorf("%s: not a type of the package", name)
+		}
+		if b, ok := obj.Type().Underlying().(*types.Basic); !ok || b.Info()&types.IsInteger == 0 {
+			return nil, fmt.Errorf("%s: not an integer type", name)
+		}
+
+		var consts []*types.Const
+		for _, n := range x.Types.Scope().Names() {
+			if c, ok := x.Types.Scope().Lookup(n).(*types.Const); ok && types.Identical(c.Type(), obj.Type()) && c.Name() != "_" {
+				consts = append(consts, c)
+			}
+		}
+		if len(consts) == 0 {
+			return nil, fmt.Errorf("%s: no constants", name)
+		}
+		sort.Slice(consts, func(i, j int) bool {
+			return consts[i].Pos() < consts[j].Pos()
+		})
+
+		fmt.Fprintf(&body, "%s.RegisterEnum(e, map[%s]string{\n", conv, g.typ(obj.Type()))
+		seen := make(map[string]bool)
+		for _, c := range consts {
+			// duplicate constant keys don't compile
+			if v := c.Val().ExactString(); !seen[v] {
+				seen[v] = true
+				fmt.Fprintf(&body, "%s: %q,\n", c.Name(), c.Name())
+			}
+		}
+		body.WriteString("})\n")
+	}
+	body.WriteString("}\n")
+	return g.file("conv-gen", body.Bytes())
+}
@@ -0,0 +1,99 @@
+package gen
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const enumTypes = `package main
+
+type Color uint8
+
+const (
+	Red Color = iota + 1
+	Green
+	Blue
+	Crimson = Red // alias, left out
+)
+
+type Size int
+
+const (
+	Small Size = -1
+	Large Size = 1
+)
+
+const other = 5
+`
+
+const enumMain = `package main
+
+import (
+	"fmt"
+
+	"github.com/blitz-frost/conv"
+)
+
+func main() {
+	e := &conv.Enums{}
+	registerEnums(e)
+	c := conv.NewConversion(e.Converter)
+	s, err := c.Call(Green)
+	inv := conv.NewInversion(e.Inverter)
+	n, err2 := conv.As[Size](inv, "Small")
+	fmt.Println(s, err, n, err2)
+}
+`
+
+func TestEnums(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0666); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("types.go", enumTypes)
+	pkg, err := Load(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	src, err := pkg.Enums([]string{"Color", "Size"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, s := range []string{"func registerEnums(e *conv.Enums) {", "conv.RegisterEnum(e, map[Color]string{\n\t\tRed:   \"Red\",", "Small: \"Small\","} {
+		if !strings.Contains(string(src), s) {
+			t.Errorf("missing %q:\n%s", s, src)
+		}
+	}
+	if strings.Contains(string(src), "Crimson") {
+		t.Errorf("aliases should be left out:\n%s", src)
+	}
+	for _, bad := range []string{"Missing", "other"} {
+		if _, err := pkg.Enums([]string{bad}); err == nil {
+			t.Error("expected error for", bad)
+		}
+	}
+
+	gobin, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go command not available")
+	}
+	root, _ := filepath.Abs("..")
+	write("conv_enums.go", string(src))
+	write("main.go", enumMain)
+	write("go.mod", "module example.com/enumtest\n\ngo 1.20\n\nrequire github.com/blitz-frost/conv v0.0.0\n\nreplace github.com/blitz-frost/conv => "+root+"\n")
+	cmd := exec.Command(gobin, "run", ".")
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GOFLAGS=-mod=mod", "GOPROXY=off")
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("%v\n%s\n%s", err, out, src)
+	}
+	if got := strings.TrimSpace(string(out)); got != "Green <nil> -1 <nil>" {
+		t.Errorf("wrong output %q", got)
+	}
+}
@@ -0,0 +1,213 @@
+package gen
+
+import (
+	"bytes"
+	"fmt"
+	"go/types"
+	"strings"
+)
+
+// FuzzTests returns the formatted source of a test file for the package, with a fuzz test for each pair of "pairs" whose reverse pair is also declared.
+// Each test converts random source values, generated by testing/quick from the fuzzed seed, there and back again, and checks that the original value is restored.
+//
+// If the round trip is lossy, as determined statically, the losses are listed in the test documentation, and the test only checks that a second round trip restores the result of the first.
+// Pairs whose source type has unexported fields cannot be generated, and are noted instead.
+func (x *Package) FuzzTests(pairs []Pair) ([]byte, error) {
+	g := newGenerator(x.Types)
+	var body bytes.Buffer
+	for _, p := range pairs {
+		tSrc, err := x.Type(p.Src)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", p.Name, err)
+		}
+		tDst, err := x.Type(p.Dst)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", p.Name, err)
+		}
+		var back *Pair
+		for i, q := range pairs {
+			qSrc, err1 := x.Type(q.Src)
+			qDst, err2 := x.Type(q.Dst)
+			if err1 == nil && err2 == nil && types.Identical(qSrc, tDst) && types.Identical(qDst, tSrc) {
+				back = &pairs[i]
+				break
+			}
+		}
+		if back == nil {
+			continue
+		}
+		if !fuzzable(tSrc, make(map[types.Type]bool)) {
+			fmt.Fprintf(&body, "\n// %s is not fuzzed, as %s values cannot be generated.\n", p.Name, p.Src)
+			continue
+		}
+
+		losses := g.losses("", tDst, tSrc, make(map[string]bool))
+		testing, quick, rand, reflect := g.importName("testing", "testing"), g.importName("testing/quick", "quick"), g.importName("math/rand", "rand"), g.importName("reflect", "reflect")
+		src := g.typ(tSrc)
+
+		name := "Fuzz" + strings.ToUpper(p.Name[:1]) + p.Name[1:]
+		fmt.Fprintf(&body, "\n// %s checks that %s inverts %s.\n", name, back.Name, p.Name)
+		if losses != nil {
+			body.WriteString("//\n// The round trip is lossy:\n//\n")
+			for _, l := range losses {
+				fmt.Fprintf(&body, "//   - %s\n", strings.TrimPrefix(l, "."))
+			}
+			body.WriteString("//\n// so it is only checked to be stable after the first pass.\n")
+		}
+		fmt.Fprintf(&body, "func %s(f *%s.F) {\n", name, testing)
+		fmt.Fprintf(&body, "round := func(t *%s.T, v %s) %s {\n", testing, src, src)
+		fmt.Fprintf(&body, "mid, err := %s(v)\nif err != nil {\nt.Fatal(err)\n}\n", p.Name)
+		fmt.Fprintf(&body, "o, err := %s(mid)\nif err != nil {\nt.Fatal(err)\n}\nreturn o\n}\n\n", back.Name)
+		body.WriteString("f.Add(int64(0))\n")
+		fmt.Fprintf(&body, "f.Fuzz(func(t *%s.T, seed int64) {\n", testing)
+		fmt.Fprintf(&body, "v, ok := %s.Value(%s.TypeOf((*%s)(nil)).Elem(), %s.New(%s.NewSource(seed)))\n", quick, reflect, src, rand, rand)
+		fmt.Fprintf(&body, "if !ok {\nt.Skip(%q)\n}\n", "cannot generate "+p.Src+" values")
+		fmt.Fprintf(&body, "src := v.Interface().(%s)\n", src)
+		if losses == nil {
+			body.WriteString("if back := round(t, src); !" + reflect + ".DeepEqual(back, src) {\n")
+			body.WriteString("t.Errorf(\"round trip of %+v: got %+v\", src, back)\n}\n")
+		} else {
+			body.WriteString("first := round(t, src)\n")
+			body.WriteString("if back := round(t, first); !" + reflect + ".DeepEqual(back, first) {\n")
+			body.WriteString("t.Errorf(\"round trip of %+v: got %+v\", first, back)\n}\n")
+		}
+		body.WriteString("})\n}\n")
+	}
+	return g.file("conv-gen", body.Bytes())
+}
+
+// losses describes the information lost when converting "tSrc" into "tDst" and back, at "path", such as ".Items[].N".
+// "seen" guards against recursive struct pairs.
+func (x *generator) losses(path string, tDst, tSrc types.Type, seen map[string]bool) []string {
+	uDst, uSrc := tDst.Underlying(), tSrc.Underlying()
+	pDst, dstPtr := uDst.(*types.Pointer)
+	pSrc, srcPtr := uSrc.(*types.Pointer)
+	at := func(s string) []string {
+		if path == "" {
+			return []string{s}
+		}
+		return []string{path + ": " + s}
+	}
+
+	switch {
+	case types.AssignableTo(tSrc, tDst):
+		return nil
+	case convertible(tDst, tSrc):
+		bDst, ok1 := uDst.(*types.Basic)
+		bSrc, ok2 := uSrc.(*types.Basic)
+		if ok1 && ok2 && basicLoss(bDst, bSrc) {
+			return at(x.typ(tSrc) + " to " + x.typ(tDst))
+		}
+		return nil
+	case srcPtr && dstPtr:
+		return x.losses(path, pDst.Elem(), pSrc.Elem(), seen)
+	case srcPtr:
+		return append(at("nil becomes a zero value"), x.losses(path, tDst, pSrc.Elem(), seen)...)
+	case dstPtr:
+		return x.losses(path, pDst.Elem(), tSrc, seen)
+	case sequence(uDst) && sequence(uSrc):
+		var o []string
+		if a, ok := uDst.(*types.Array); ok {
+			if b, ok := uSrc.(*types.Array); !ok || b.Len() > a.Len() {
+				o = at(fmt.Sprintf("length limited to %d", a.Len()))
+			}
+		}
+		return append(o, x.losses(path+"[]", elem(uDst), elem(uSrc), seen)...)
+	case isMap(uDst) && isMap(uSrc):
+		mDst, mSrc := uDst.(*types.Map), uSrc.(*types.Map)
+		return append(x.losses(path+"[key]", mDst.Key(), mSrc.Key(), seen), x.losses(path+"[]", mDst.Elem(), mSrc.Elem(), seen)...)
+	case isStruct(uDst) && isStruct(uSrc):
+		key := types.TypeString(tDst, nil) + "\x00" + types.TypeString(tSrc, nil)
+		if seen[key] {
+			return nil
+		}
+		seen[key] = true
+
+		var o []string
+		for _, f := range visibleFields(tSrc) {
+			sf := f.v
+			if !sf.Exported() || (sf.Embedded() && isStruct(sf.Type().Underlying())) {
+				continue
+			}
+			name := path + "." + sf.Name()
+			obj, index, indirect := lookupField(tDst, x.pkg, fieldName(f))
+			df, ok := obj.(*types.Var)
+			if f.indirect || ignored(f.tag) || !ok || !df.IsField() || !df.Exported() || indirect || ignored(fieldTag(tDst, index)) {
+				o = append(o, name+": dropped")
+				continue
+			}
+			o = append(o, x.losses(name, df.Type(), sf.Type(), seen)...)
+		}
+		return o
+	}
+	return at("not convertible")
+}
+
+// basicLoss returns true if converting basic type "src" into "dst" loses information.
+// int, uint and uintptr are assumed to be 64 bits wide.
+func basicLoss(dst, src *types.Basic) bool {
+	di, si := dst.Info(), src.Info()
+	ds, ss := basicSize(dst), basicSize(src)
+	switch {
+	case si&types.IsInteger != 0 && di&types.IsInteger != 0:
+		su, du := si&types.IsUnsigned != 0, di&types.IsUnsigned != 0
+		switch {
+		case su == du:
+			return ds < ss
+		case su:
+			return ds <= ss
+		}
+		return true
+	case si&types.IsInteger != 0 && di&types.IsFloat != 0:
+		bits := 8 * ss
+		if si&types.IsUnsigned == 0 {
+			bits--
+		}
+		mantissa := int64(53)
+		if ds == 4 {
+			mantissa = 24
+		}
+		return bits > mantissa
+	case (si&types.IsFloat != 0 && di&types.IsFloat != 0) || (si&types.IsComplex != 0 && di&types.IsComplex != 0):
+		return ds < ss
+	case si&types.IsString != 0 && di&types.IsString != 0, si&types.IsBoolean != 0 && di&types.IsBoolean != 0:
+		return false
+	}
+	return true
+}
+
+func basicSize(t *types.Basic) int64 {
+	switch t.Kind() {
+	case types.Int, types.Uint, types.Uintptr:
+		return 8
+	}
+	return types.SizesFor("gc", "amd64").Sizeof(t)
+}
+
+// fuzzable returns true if testing/quick can generate values of type "t".
+func fuzzable(t types.Type, seen map[types.Type]bool) bool {
+	if seen[t] {
+		return true
+	}
+	seen[t] = true
+	switch u := t.Underlying().(type) {
+	case *types.Basic:
+		return u.Kind() != types.UnsafePointer
+	case *types.Pointer:
+		return fuzzable(u.Elem(), seen)
+	case *types.Slice:
+		return fuzzable(u.Elem(), seen)
+	case *types.Array:
+		return fuzzable(u.Elem(), seen)
+	case *types.Map:
+		return fuzzable(u.Key(), seen) && fuzzable(u.Elem(), seen)
+	case *types.Struct:
+		for i := 0; i < u.NumFields(); i++ {
+			if f := u.Field(i); !f.Exported() || !fuzzable(f.Type(), seen) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
@@ -0,0 +1,559 @@
+// Package gen generates static conversion functions, following the rules of conv.NewDeepMapper without its runtime reflection:
+//
+//   - Go assignment and conversion rules, excluding integer to string and slice to array conversions
+//   - pointers, with nil sources producing zero destinations
+//   - slices, arrays and maps, element by element; array destinations are filled up to their length
+//   - structs, matching exported fields by name, including promoted ones, or by their `conv:"name"` tag; fields tagged `conv:"-"` on either side are ignored
+//
+// Unlike the Mapper, generated code doesn't track pointer identities, so shared source pointers produce distinct destinations, and cyclic values recurse forever.
+// Type pairs that the rules cannot convert are reported at generation time, instead of failing with conv.ErrInvalid at run time.
+package gen
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/format"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	pathpkg "path"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+var ErrUnsupported = errors.New("unsupported conversion")
+
+// A Pair declares a generated function, converting values of type Src into Dst.
+// Types are expressions evaluated in the package scope, such as "Foo", "[]Foo" or "map[string]*Foo".
+type Pair struct {
+	Name     string
+	Src, Dst string
+}
+
+// A Package is a type checked Go package, that conversion functions are generated for.
+type Package struct {
+	Dir   string
+	Fset  *token.FileSet
+	Files []*ast.File
+	Types *types.Package
+	Info  *types.Info
+
+	Template *template.Template // used by Generate; defaults to DefaultTemplate
+}
+
+// Load parses and type checks the package in directory "dir", for the current build context.
+// Files named in "exclude", such as previously generated output, are left out.
+func Load(dir string, exclude ...string) (*Package, error) {
+	bp, err := build.ImportDir(dir, 0)
+	if err != nil {
+		return nil, err
+	}
+	skip := make(map[string]bool, len(exclude))
+	for _, name := range exclude {
+		skip[filepath.Base(name)] = true
+	}
+
+	o := &Package{
+		Dir:  dir,
+		Fset: token.NewFileSet(),
+		Info: &types.Info{
+			Types:      make(map[ast.Expr]types.TypeAndValue),
+			Defs:       make(map[*ast.Ident]types.Object),
+			Uses:       make(map[*ast.Ident]types.Object),
+			Selections: make(map[*ast.SelectorExpr]*types.Selection),
+			Instances:  make(map[*ast.Ident]types.Instance),
+		},
+	}
+	for _, name := range bp.GoFiles {
+		if skip[name] {
+			continue
+		}
+		f, err := parser.ParseFile(o.Fset, filepath.Join(dir, name), nil, parser.ParseComments)
+		if err != nil {
+			return nil, err
+		}
+		o.Files = append(o.Files, f)
+	}
+
+	conf := types.Config{Importer: importer.ForCompiler(o.Fset, "source", nil)}
+	o.Types, err = conf.Check(bp.ImportPath, o.Fset, o.Files, o.Info)
+	if err != nil {
+		return nil, err
+	}
+	return o, nil
+}
+
+// Type evaluates type expression "expr" in the package scope.
+func (x *Package) Type(expr string) (types.Type, error) {
+	tv, err := types.Eval(x.Fset, x.Types, token.NoPos, expr)
+	if err != nil {
+		return nil, err
+	}
+	if !tv.IsType() {
+		return nil, fmt.Errorf("%s is not a type", expr)
+	}
+	return tv.Type, nil
+}
+
+// Generate returns the formatted source of a file belonging to the package, declaring the functions of "pairs", as defined by Template:
+//
+//	func Name(src Src) (Dst, error)
+//
+// The error result is currently always nil, and is reserved for conversions that can fail.
+func (x *Package) Generate(pairs []Pair) ([]byte, error) {
+	g := newGenerator(x.Types)
+	tmpl := x.Template
+	if tmpl == nil {
+		tmpl = defaultTemplate()
+	}
+	tmpl, err := tmpl.Clone()
+	if err != nil {
+		return nil, err
+	}
+	tmpl.Funcs(template.FuncMap{
+		"import": func(path string) string {
+			return g.importName(path, pathpkg.Base(path))
+		},
+	})
+
+	var body bytes.Buffer
+	for _, p := range pairs {
+		tSrc, err := x.Type(p.Src)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", p.Name, err)
+		}
+		tDst, err := x.Type(p.Dst)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", p.Name, err)
+		}
+		g.names[p.Name] = true
+
+		var stmts bytes.Buffer
+		if err := g.assign(&stmts, "dst", "src", tDst, tSrc); err != nil {
+			return nil, fmt.Errorf("%s: %w", p.Name, err)
+		}
+		if err := tmpl.ExecuteTemplate(&body, "func", FuncData{
+			Pair: p,
+			Src:  g.typ(tSrc),
+			Dst:  g.typ(tDst),
+			Body: stmts.String(),
+		}); err != nil {
+			return nil, err
+		}
+	}
+	// helpers may queue further helpers
+	for i := 0; i < len(g.queue); i++ {
+		h := g.queue[i]
+		var stmts bytes.Buffer
+		if err := g.fields(&stmts, h.dst, h.src); err != nil {
+			return nil, fmt.Errorf("%s: %w", h.name, err)
+		}
+		if err := tmpl.ExecuteTemplate(&body, "helper", HelperData{
+			Name: h.name,
+			Src:  g.typ(h.src),
+			Dst:  g.typ(h.dst),
+			Body: stmts.String(),
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	return g.file("conv-gen", body.Bytes())
+}
+
+type generator struct {
+	pkg     *types.Package
+	imports map[string]string // path to name
+	helpers map[string]string // type pair to function name
+	names   map[string]bool   // used top level names
+	queue   []helper
+	tmp     int
+}
+
+func newGenerator(pkg *types.Package) *generator {
+	return &generator{
+		pkg:     pkg,
+		imports: make(map[string]string),
+		helpers: make(map[string]string),
+		names:   make(map[string]bool),
+	}
+}
+
+// file returns the formatted source of a generated file with contents "body", made by command "cmd".
+func (x *generator) file(cmd string, body []byte) ([]byte, error) {
+	var o bytes.Buffer
+	fmt.Fprintf(&o, "// Code generated by %s. DO NOT EDIT.\n\n", cmd)
+	fmt.Fprintf(&o, "package %s\n", x.pkg.Name())
+	if len(x.imports) > 0 {
+		paths := make([]string, 0, len(x.imports))
+		for path := range x.imports {
+			paths = append(paths, path)
+		}
+		sort.Strings(paths)
+		o.WriteString("\nimport (\n")
+		for _, path := range paths {
+			if name := x.imports[path]; name != pathpkg.Base(path) {
+				o.WriteString(name + " ")
+			}
+			o.WriteString(strconv.Quote(path) + "\n")
+		}
+		o.WriteString(")\n")
+	}
+	o.Write(body)
+	return format.Source(o.Bytes())
+}
+
+// A helper is a function converting struct fields.
+type helper struct {
+	name     string
+	dst, src types.Type
+}
+
+// typ returns the source form of "t", qualified for use in the generated file.
+func (x *generator) typ(t types.Type) string {
+	return types.TypeString(t, func(p *types.Package) string {
+		if p == x.pkg {
+			return ""
+		}
+		return x.importName(p.Path(), p.Name())
+	})
+}
+
+// importName returns the name under which the package at "path" is imported by the generated file, adding the import if needed.
+func (x *generator) importName(path, name string) string {
+	if o, ok := x.imports[path]; ok {
+		return o
+	}
+	for x.taken(name) {
+		name += "_"
+	}
+	x.imports[path] = name
+	return name
+}
+
+func (x *generator) taken(name string) bool {
+	for _, n := range x.imports {
+		if n == name {
+			return true
+		}
+	}
+	return x.pkg.Scope().Lookup(name) != nil
+}
+
+// local returns a new local variable name.
+func (x *generator) local(prefix string) string {
+	x.tmp++
+	return prefix + strconv.Itoa(x.tmp)
+}
+
+// assign writes statements setting "dst", an addressable zero value of type "tDst", from expression "src" of type "tSrc".
+// "src" may be evaluated more than once.
+func (x *generator) assign(w *bytes.Buffer, dst, src string, tDst, tSrc types.Type) error {
+	uDst, uSrc := tDst.Underlying(), tSrc.Underlying()
+	pDst, dstPtr := uDst.(*types.Pointer)
+	pSrc, srcPtr := uSrc.(*types.Pointer)
+
+	switch {
+	case types.AssignableTo(tSrc, tDst):
+		fmt.Fprintf(w, "%s = %s\n", dst, src)
+	case convertible(tDst, tSrc):
+		fmt.Fprintf(w, "%s = %s(%s)\n", dst, x.conversion(tDst), src)
+
+	case srcPtr && dstPtr:
+		p := x.local("p")
+		fmt.Fprintf(w, "if %s != nil {\n%s := new(%s)\n", src, p, x.typ(pDst.Elem()))
+		if err := x.assign(w, "(*"+p+")", "(*"+src+")", pDst.Elem(), pSrc.Elem()); err != nil {
+			return err
+		}
+		fmt.Fprintf(w, "%s = %s\n}\n", dst, p)
+	case srcPtr:
+		fmt.Fprintf(w, "if %s != nil {\n", src)
+		if err := x.assign(w, dst, "(*"+src+")", tDst, pSrc.Elem()); err != nil {
+			return err
+		}
+		w.WriteString("}\n")
+	case dstPtr:
+		p := x.local("p")
+		fmt.Fprintf(w, "%s := new(%s)\n", p, x.typ(pDst.Elem()))
+		if err := x.assign(w, "(*"+p+")", src, pDst.Elem(), tSrc); err != nil {
+			return err
+		}
+		fmt.Fprintf(w, "%s = %s\n", dst, p)
+
+	case sequence(uDst) && sequence(uSrc):
+		eDst, eSrc := elem(uDst), elem(uSrc)
+		i := x.local("i")
+		var e bytes.Buffer
+		if err := x.assign(&e, dst+"["+i+"]", src+"["+i+"]", eDst, eSrc); err != nil {
+			return fmt.Errorf("element: %w", err)
+		}
+		_, sliceDst := uDst.(*types.Slice)
+		_, sliceSrc := uSrc.(*types.Slice)
+		switch {
+		case sliceDst && sliceSrc:
+			fmt.Fprintf(w, "if %s != nil {\n%s = make(%s, len(%s))\nfor %s := range %s {\n%s}\n}\n", src, dst, x.typ(tDst), src, i, src, e.Bytes())
+		case sliceDst:
+			fmt.Fprintf(w, "%s = make(%s, len(%s))\nfor %s := range %s {\n%s}\n", dst, x.typ(tDst), src, i, src, e.Bytes())
+		default:
+			fmt.Fprintf(w, "for %s := 0; %s < len(%s) && %s < len(%s); %s++ {\n%s}\n", i, i, src, i, dst, i, e.Bytes())
+		}
+
+	case isMap(uDst) && isMap(uSrc):
+		mDst, mSrc := uDst.(*types.Map), uSrc.(*types.Map)
+		m, k, v, dk, dv := x.local("m"), x.local("k"), x.local("v"), x.local("k"), x.local("v")
+		fmt.Fprintf(w, "if %s != nil {\n%s := make(%s, len(%s))\nfor %s, %s := range %s {\n", src, m, x.typ(tDst), src, k, v, src)
+		fmt.Fprintf(w, "var %s %s\n", dk, x.typ(mDst.Key()))
+		if err := x.assign(w, dk, k, mDst.Key(), mSrc.Key()); err != nil {
+			return fmt.Errorf("key: %w", err)
+		}
+		fmt.Fprintf(w, "var %s %s\n", dv, x.typ(mDst.Elem()))
+		if err := x.assign(w, dv, v, mDst.Elem(), mSrc.Elem()); err != nil {
+			return fmt.Errorf("element: %w", err)
+		}
+		fmt.Fprintf(w, "%s[%s] = %s\n}\n%s = %s\n}\n", m, dk, dv, dst, m)
+
+	case isStruct(uDst) && isStruct(uSrc):
+		fmt.Fprintf(w, "%s(%s, %s)\n", x.helper(tDst, tSrc), addr(dst), src)
+
+	default:
+		return fmt.Errorf("%s into %s: %w", x.typ(tSrc), x.typ(tDst), ErrUnsupported)
+	}
+	return nil
+}
+
+// conversion returns the source form of "t", parenthesized if needed to be used in a conversion.
+func (x *generator) conversion(t types.Type) string {
+	s := x.typ(t)
+	if strings.HasPrefix(s, "*") || strings.HasPrefix(s, "<-") || strings.HasPrefix(s, "func") {
+		return "(" + s + ")"
+	}
+	return s
+}
+
+// helper returns the name of the function converting struct "src" into "dst", queueing it for generation if new.
+func (x *generator) helper(tDst, tSrc types.Type) string {
+	key := types.TypeString(tDst, nil) + "\x00" + types.TypeString(tSrc, nil)
+	if name, ok := x.helpers[key]; ok {
+		return name
+	}
+
+	name := "convert" + x.ident(tDst) + "From" + x.ident(tSrc)
+	for x.names[name] || x.pkg.Scope().Lookup(name) != nil {
+		name += "_"
+	}
+	x.names[name] = true
+	x.helpers[key] = name
+	x.queue = append(x.queue, helper{
+		name: name,
+		dst:  tDst,
+		src:  tSrc,
+	})
+	return name
+}
+
+// fields writes the body of a helper, converting the matching fields of struct "tSrc" into "tDst".
+func (x *generator) fields(w *bytes.Buffer, tDst, tSrc types.Type) error {
+	for _, f := range visibleFields(tDst) {
+		df := f.v
+		if !df.Exported() || f.indirect || (df.Embedded() && isStruct(df.Type().Underlying())) || ignored(f.tag) {
+			continue
+		}
+		obj, index, _ := lookupField(tSrc, x.pkg, fieldName(f))
+		sf, ok := obj.(*types.Var)
+		if !ok || !sf.IsField() || !sf.Exported() || ignored(fieldTag(tSrc, index)) {
+			continue
+		}
+
+		// guard fields promoted through embedded pointers
+		var guards []string
+		expr, t := "src", tSrc
+		for _, i := range index[:len(index)-1] {
+			if p, ok := t.Underlying().(*types.Pointer); ok {
+				t = p.Elem()
+			}
+			field := t.Underlying().(*types.Struct).Field(i)
+			expr += "." + field.Name()
+			t = field.Type()
+			if _, ok := t.Underlying().(*types.Pointer); ok {
+				guards = append(guards, expr+" != nil")
+			}
+		}
+
+		if guards != nil {
+			fmt.Fprintf(w, "if %s {\n", strings.Join(guards, " && "))
+		}
+		if err := x.assign(w, "dst."+df.Name(), "src."+sf.Name(), df.Type(), sf.Type()); err != nil {
+			return fmt.Errorf("field %s: %w", df.Name(), err)
+		}
+		if guards != nil {
+			w.WriteString("}\n")
+		}
+	}
+	return nil
+}
+
+// A visibleField is a field accessible through a struct type, possibly promoted.
+type visibleField struct {
+	v        *types.Var
+	tag      string
+	indirect bool // promoted through an embedded pointer
+}
+
+// visibleFields returns the fields accessible through struct type "t", in declaration order, with promoted fields following the field they are embedded by.
+// Like reflect.VisibleFields, fields hidden by shallower ones, or ambiguous at the same depth, are left out.
+func visibleFields(t types.Type) []visibleField {
+	var o []visibleField
+	var walk func(t types.Type, visited map[types.Type]bool)
+	walk = func(t types.Type, visited map[types.Type]bool) {
+		if p, ok := t.Underlying().(*types.Pointer); ok {
+			t = p.Elem()
+		}
+		st, ok := t.Underlying().(*types.Struct)
+		if !ok || visited[t] {
+			return
+		}
+		visited[t] = true
+		for i := 0; i < st.NumFields(); i++ {
+			f := st.Field(i)
+			o = append(o, visibleField{v: f, tag: st.Tag(i)})
+			if f.Embedded() {
+				walk(f.Type(), visited)
+			}
+		}
+	}
+	walk(t, make(map[types.Type]bool))
+
+	// resolve shadowing and ambiguity the way the compiler does
+	visible := o[:0]
+	seen := make(map[*types.Var]bool)
+	for _, f := range o {
+		obj, _, indirect := types.LookupFieldOrMethod(t, false, f.v.Pkg(), f.v.Name())
+		if obj == f.v && !seen[f.v] {
+			seen[f.v] = true
+			f.indirect = indirect
+			visible = append(visible, f)
+		}
+	}
+	return visible
+}
+
+// fieldTag returns the tag of the field at "index" in struct type "t".
+func fieldTag(t types.Type, index []int) string {
+	var tag string
+	for _, i := range index {
+		if p, ok := t.Underlying().(*types.Pointer); ok {
+			t = p.Elem()
+		}
+		st := t.Underlying().(*types.Struct)
+		t, tag = st.Field(i).Type(), st.Tag(i)
+	}
+	return tag
+}
+
+// fieldName returns the name that field "f" is matched by: its conv tag name, or its field name if untagged or ignored, as for conv.StructMap.
+func fieldName(f visibleField) string {
+	name, _, _ := strings.Cut(reflect.StructTag(f.tag).Get("conv"), ",")
+	if name == "" || name == "-" {
+		return f.v.Name()
+	}
+	return name
+}
+
+// lookupField returns the exported field of struct type "t" matched by "name", as types.LookupFieldOrMethod returns fields by their field name.
+func lookupField(t types.Type, pkg *types.Package, name string) (types.Object, []int, bool) {
+	for _, f := range visibleFields(t) {
+		if f.v.Exported() && !(f.v.Embedded() && isStruct(f.v.Type().Underlying())) && fieldName(f) == name {
+			return types.LookupFieldOrMethod(t, false, pkg, f.v.Name())
+		}
+	}
+	return nil, nil, false
+}
+
+// ignored returns true for fields tagged `conv:"-"`.
+func ignored(tag string) bool {
+	name, _, _ := strings.Cut(reflect.StructTag(tag).Get("conv"), ",")
+	return name == "-"
+}
+
+// convertible mirrors the conversion rules of conv.AssignMapping.
+func convertible(tDst, tSrc types.Type) bool {
+	uDst, uSrc := tDst.Underlying(), tSrc.Underlying()
+	if b, ok := uSrc.(*types.Basic); ok && b.Info()&types.IsInteger != 0 {
+		if b, ok := uDst.(*types.Basic); ok && b.Info()&types.IsString != 0 {
+			return false
+		}
+	}
+	if _, ok := uSrc.(*types.Slice); ok {
+		switch uDst.(type) {
+		case *types.Array, *types.Pointer:
+			return false
+		}
+	}
+	return types.ConvertibleTo(tSrc, tDst)
+}
+
+func sequence(t types.Type) bool {
+	switch t.(type) {
+	case *types.Slice, *types.Array:
+		return true
+	}
+	return false
+}
+
+func elem(t types.Type) types.Type {
+	switch t := t.(type) {
+	case *types.Slice:
+		return t.Elem()
+	case *types.Array:
+		return t.Elem()
+	}
+	return nil
+}
+
+func isMap(t types.Type) bool {
+	_, ok := t.(*types.Map)
+	return ok
+}
+
+func isStruct(t types.Type) bool {
+	_, ok := t.(*types.Struct)
+	return ok
+}
+
+// addr returns the address of addressable expression "v".
+func addr(v string) string {
+	if strings.HasPrefix(v, "(*") && strings.HasSuffix(v, ")") {
+		return v[2 : len(v)-1]
+	}
+	return "&" + v
+}
+
+// ident returns an identifier fragment naming type "t".
+func (x *generator) ident(t types.Type) string {
+	var b strings.Builder
+	upper := true
+	for _, c := range types.TypeString(t, types.RelativeTo(x.pkg)) {
+		switch {
+		case c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9'):
+			if upper && c >= 'a' && c <= 'z' {
+				c -= 'a' - 'A'
+			}
+			b.WriteRune(c)
+			upper = false
+		default:
+			upper = true
+		}
+	}
+	if b.Len() == 0 {
+		return "Struct"
+	}
+	return b.String()
+}
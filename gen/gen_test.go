@@ -0,0 +1,212 @@
+package gen
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testTypes = `package main
+
+type Base struct{ ID int64 }
+
+type Row struct {
+	Base
+	Name   string
+	Age    int32
+	Tags   []string
+	Scores map[string]float32
+	Next   *Row
+	Secret string ` + "`conv:\"-\"`" + `
+	Handle string ` + "`conv:\"Nick\"`" + `
+	Items  []Item
+	Arr    [3]int
+	Ptr    *int
+}
+
+type Item struct{ N int }
+
+type Label string
+
+type User struct {
+	ID     int
+	Name   string
+	Age    float64
+	Tags   []Label
+	Scores map[Label]float64
+	Next   *User
+	Secret string
+	Nick   string
+	Items  []*Item2
+	Arr    []int
+	Ptr    int
+	Extra  bool
+}
+
+type Item2 struct{ N uint8 }
+`
+
+// testMain compares the generated functions with a deep Mapper.
+const testMain = `package main
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/blitz-frost/conv"
+)
+
+func main() {
+	n := 7
+	rows := []Row{
+		{
+			Base:   Base{ID: 1},
+			Name:   "a",
+			Age:    30,
+			Tags:   []string{"x", "y"},
+			Scores: map[string]float32{"m": 1.5},
+			Next:   &Row{Name: "b", Secret: "s"},
+			Secret: "s",
+			Handle: "h",
+			Items:  []Item{{N: 1}, {N: 300}},
+			Arr:    [3]int{1, 2, 3},
+			Ptr:    &n,
+		},
+		{},
+	}
+
+	m := conv.NewDeepMapper(nil)
+	var exp []User
+	if err := m.Map(&exp, rows); err != nil {
+		panic(err)
+	}
+	got, err := UsersFromRows(rows)
+	if err != nil {
+		panic(err)
+	}
+	one, _ := UserFromRow(rows[0])
+	fmt.Println(reflect.DeepEqual(got, exp), reflect.DeepEqual(one, exp[0]))
+}
+`
+
+func TestGenerate(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0666); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("types.go", testTypes)
+
+	pkg, err := Load(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pairs := []Pair{
+		{Name: "UserFromRow", Src: "Row", Dst: "User"},
+		{Name: "UsersFromRows", Src: "[]Row", Dst: "[]User"},
+		{Name: "RowFromUser", Src: "User", Dst: "Row"},
+		{Name: "ItemFromItem2", Src: "Item2", Dst: "Item"},
+		{Name: "Item2FromItem", Src: "Item", Dst: "Item2"},
+	}
+	src, err := pkg.Generate(pairs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fuzz, err := pkg.FuzzTests(pairs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, s := range []string{
+		"func FuzzUserFromRow(f *testing.F)",
+		"//   - Secret: dropped\n//   - Items[].N: int to uint8\n//   - Ptr: nil becomes a zero value\n",
+		"func FuzzRowFromUser(f *testing.F)",
+		"//   - Extra: dropped\n",
+		"// FuzzItem2FromItem checks",
+	} {
+		if !strings.Contains(string(fuzz), s) {
+			t.Errorf("missing %q:\n%s", s, fuzz)
+		}
+	}
+	if strings.Contains(string(fuzz), "FuzzUsersFromRows") || !strings.Contains(string(fuzz), "if back := round(t, src); !reflect.DeepEqual(back, src)") {
+		t.Errorf("unexpected fuzz tests:\n%s", fuzz)
+	}
+	if !strings.HasPrefix(string(src), "// Code generated by conv-gen. DO NOT EDIT.") || strings.Contains(string(src), "Secret") {
+		t.Errorf("unexpected output:\n%s", src)
+	}
+
+	if _, err := pkg.Generate([]Pair{{Name: "Bad", Src: "Row", Dst: "map[string]int"}}); !errors.Is(err, ErrUnsupported) {
+		t.Error("expected unsupported pair, got", err)
+	}
+
+	gobin, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go command not available")
+	}
+	root, _ := filepath.Abs("..")
+	write("conv_gen.go", string(src))
+	write("main.go", testMain)
+	write("conv_gen_test.go", string(fuzz))
+	write("go.mod", "module example.com/gentest\n\ngo 1.20\n\nrequire github.com/blitz-frost/conv v0.0.0\n\nreplace github.com/blitz-frost/conv => "+root+"\n")
+	cmd := exec.Command(gobin, "run", ".")
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GOFLAGS=-mod=mod", "GOPROXY=off")
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("%v\n%s\n%s", err, out, src)
+	}
+	if strings.TrimSpace(string(out)) != "true true" {
+		t.Errorf("generated conversion differs from Mapper: %s\n%s", out, src)
+	}
+
+	// fuzz seed corpus
+	cmd = exec.Command(gobin, "test", ".")
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GOFLAGS=-mod=mod", "GOPROXY=off")
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("%v\n%s\n%s", err, out, fuzz)
+	}
+}
+
+func TestTemplate(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "types.go"), []byte(testTypes), 0666); err != nil {
+		t.Fatal(err)
+	}
+	pkg, err := Load(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pkg.Template, err = ParseTemplate(`{{define "func"}}
+// {{.Name}} logs and converts {{.Pair.Src}} values.
+func {{.Name}}(src {{.Src}}) (dst {{.Dst}}, err error) {
+	{{import "log"}}.Printf("{{.Name}}: %v", src)
+{{.Body}}	return
+}
+{{end}}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	src, err := pkg.Generate([]Pair{{Name: "UserFromRow", Src: "Row", Dst: "User"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, s := range []string{`import (
+	"log"
+)`, `log.Printf("UserFromRow: %v", src)`, "func convertUserFromRow(dst *User, src Row) {"} {
+		if !strings.Contains(string(src), s) {
+			t.Errorf("missing %q:\n%s", s, src)
+		}
+	}
+
+	// the output belongs to the package
+	if err := os.WriteFile(filepath.Join(dir, "conv_gen.go"), src, 0666); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(dir); err != nil {
+		t.Error(err)
+	}
+}
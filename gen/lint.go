@@ -0,0 +1,255 @@
+package gen
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"sort"
+)
+
+// A Diagnostic is a likely mistake in a Builder, Converter, Inverter or Mapping implementation, found by Lint.
+type Diagnostic struct {
+	Pos     token.Position
+	Check   string
+	Message string
+}
+
+// Lint checks the functions of the package that have the shape of a Builder, Converter, Inverter or Mapping, whether declared or literal, for common mistakes:
+//
+//   - nilfunc: a Builder returning true along with a nil function, which panics when called
+//   - addressable: a Converter, or the source of a Mapping, calling a reflect.Value method that requires an addressable or settable Value, such as Addr or SetInt, without checking CanAddr or CanSet first; source Values are usually not addressable
+//   - inverter: an Inverter returning reflect.ValueOf of a pointer, which is not addressable; destinations should be allocated through reflect.New
+//
+// Diagnostics are ordered by position.
+func (x *Package) Lint() []Diagnostic {
+	var o []Diagnostic
+	for _, f := range x.Files {
+		ast.Inspect(f, func(n ast.Node) bool {
+			var (
+				sig  *types.Signature
+				typ  *ast.FuncType
+				body *ast.BlockStmt
+			)
+			switch n := n.(type) {
+			case *ast.FuncDecl:
+				if obj, ok := x.Info.Defs[n.Name].(*types.Func); ok && n.Body != nil {
+					sig, typ, body = obj.Type().(*types.Signature), n.Type, n.Body
+				}
+			case *ast.FuncLit:
+				sig, _ = x.Info.TypeOf(n).(*types.Signature)
+				typ, body = n.Type, n.Body
+			}
+			if sig != nil && sig.Recv() == nil {
+				o = append(o, x.lint(sig, typ, body)...)
+			}
+			return true
+		})
+	}
+	sort.SliceStable(o, func(i, j int) bool {
+		a, b := o[i].Pos, o[j].Pos
+		if a.Filename != b.Filename {
+			return a.Filename < b.Filename
+		}
+		return a.Offset < b.Offset
+	})
+	return o
+}
+
+func (x *Package) lint(sig *types.Signature, typ *ast.FuncType, body *ast.BlockStmt) []Diagnostic {
+	params, results := sig.Params(), sig.Results()
+	var o []Diagnostic
+	diag := func(n ast.Node, check, msg string) {
+		o = append(o, Diagnostic{
+			Pos:     x.Fset.Position(n.Pos()),
+			Check:   check,
+			Message: msg,
+		})
+	}
+
+	switch {
+	// Builder
+	case params.Len() == 1 && isReflect(params.At(0).Type(), "Type") && results.Len() == 2 && isFunc(results.At(0).Type()) && isBool(results.At(1).Type()):
+		returns(body, func(r *ast.ReturnStmt) {
+			if len(r.Results) == 2 && x.isNil(r.Results[0]) && x.isTrue(r.Results[1]) {
+				diag(r, "nilfunc", "builder returns true with a nil function")
+			}
+		})
+
+	// Converter
+	case params.Len() == 1 && isReflect(params.At(0).Type(), "Value") && results.Len() == 2 && isError(results.At(1).Type()):
+		o = append(o, x.lintAddressable(paramVar(x.Info, typ, 0), body, "converter")...)
+
+	// Mapping
+	case params.Len() == 3 && isReflect(params.At(0).Type(), "Value") && isReflect(params.At(1).Type(), "Value") && results.Len() == 1 && isError(results.At(0).Type()):
+		o = append(o, x.lintAddressable(paramVar(x.Info, typ, 1), body, "mapping source")...)
+
+	// Inverter
+	case params.Len() == 1 && results.Len() == 2 && isReflect(results.At(0).Type(), "Value") && isError(results.At(1).Type()):
+		returns(body, func(r *ast.ReturnStmt) {
+			if len(r.Results) != 2 {
+				return
+			}
+			call, ok := unparen(r.Results[0]).(*ast.CallExpr)
+			if !ok || len(call.Args) != 1 || !x.isReflectFunc(call.Fun, "ValueOf") {
+				return
+			}
+			if t := x.Info.TypeOf(call.Args[0]); t != nil {
+				if _, ok := t.Underlying().(*types.Pointer); ok {
+					diag(r, "inverter", "inverter returns an unaddressable pointer Value; allocate it with reflect.New(t).Elem() and Set it instead")
+				}
+			}
+		})
+	}
+	return o
+}
+
+// settableMethods are the reflect.Value methods that require an addressable or settable Value.
+var settableMethods = map[string]bool{
+	"Addr": true, "UnsafeAddr": true, "Set": true, "SetBool": true, "SetBytes": true, "SetComplex": true, "SetFloat": true, "SetInt": true,
+	"SetIterKey": true, "SetIterValue": true, "SetLen": true, "SetCap": true, "SetPointer": true, "SetString": true, "SetUint": true, "SetZero": true,
+}
+
+// lintAddressable reports calls to settableMethods on parameter "v", or on fields of it, unless its addressability is checked somewhere in "body".
+func (x *Package) lintAddressable(v *types.Var, body *ast.BlockStmt, role string) []Diagnostic {
+	if v == nil {
+		return nil
+	}
+	var (
+		calls   []*ast.CallExpr
+		checked bool
+	)
+	ast.Inspect(body, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		sel, ok := unparen(call.Fun).(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+		if !x.derived(sel.X, v) {
+			return true
+		}
+		switch name := sel.Sel.Name; {
+		case name == "CanAddr" || name == "CanSet":
+			checked = true
+		case settableMethods[name]:
+			calls = append(calls, call)
+		}
+		return true
+	})
+	if checked {
+		return nil
+	}
+
+	o := make([]Diagnostic, len(calls))
+	for i, call := range calls {
+		sel := unparen(call.Fun).(*ast.SelectorExpr)
+		o[i] = Diagnostic{
+			Pos:     x.Fset.Position(call.Pos()),
+			Check:   "addressable",
+			Message: role + " calls " + sel.Sel.Name + " on " + v.Name() + ", which may not be addressable; check CanAddr or CanSet, or copy it first",
+		}
+	}
+	return o
+}
+
+// derived returns true if "e" is "v", or a field of it, which shares its addressability.
+func (x *Package) derived(e ast.Expr, v *types.Var) bool {
+	for {
+		switch t := unparen(e).(type) {
+		case *ast.Ident:
+			return x.Info.Uses[t] == v
+		case *ast.CallExpr:
+			sel, ok := unparen(t.Fun).(*ast.SelectorExpr)
+			if !ok {
+				return false
+			}
+			switch sel.Sel.Name {
+			case "Field", "FieldByName", "FieldByIndex":
+				e = sel.X
+			default:
+				return false
+			}
+		default:
+			return false
+		}
+	}
+}
+
+func (x *Package) isNil(e ast.Expr) bool {
+	tv, ok := x.Info.Types[e]
+	return ok && tv.IsNil()
+}
+
+func (x *Package) isTrue(e ast.Expr) bool {
+	tv, ok := x.Info.Types[e]
+	return ok && tv.Value != nil && tv.Value.Kind() == constant.Bool && constant.BoolVal(tv.Value)
+}
+
+// isReflectFunc returns true if "e" refers to function "name" of package reflect.
+func (x *Package) isReflectFunc(e ast.Expr, name string) bool {
+	var id *ast.Ident
+	switch e := unparen(e).(type) {
+	case *ast.Ident:
+		id = e
+	case *ast.SelectorExpr:
+		id = e.Sel
+	default:
+		return false
+	}
+	fn, ok := x.Info.Uses[id].(*types.Func)
+	return ok && fn.Name() == name && fn.Pkg() != nil && fn.Pkg().Path() == "reflect"
+}
+
+// returns calls "fn" for the return statements of "body", excluding those of nested function literals.
+func returns(body *ast.BlockStmt, fn func(*ast.ReturnStmt)) {
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.ReturnStmt:
+			fn(n)
+		}
+		return true
+	})
+}
+
+// paramVar returns the variable of the i-th parameter of "typ", or nil if it is unnamed.
+func paramVar(info *types.Info, typ *ast.FuncType, i int) *types.Var {
+	for _, field := range typ.Params.List {
+		if len(field.Names) == 0 {
+			if i == 0 {
+				return nil
+			}
+			i--
+			continue
+		}
+		if i < len(field.Names) {
+			v, _ := info.Defs[field.Names[i]].(*types.Var)
+			return v
+		}
+		i -= len(field.Names)
+	}
+	return nil
+}
+
+func isReflect(t types.Type, name string) bool {
+	n, ok := t.(*types.Named)
+	return ok && n.Obj().Name() == name && n.Obj().Pkg() != nil && n.Obj().Pkg().Path() == "reflect"
+}
+
+func isFunc(t types.Type) bool {
+	_, ok := t.Underlying().(*types.Signature)
+	return ok
+}
+
+func isBool(t types.Type) bool {
+	b, ok := t.Underlying().(*types.Basic)
+	return ok && b.Kind() == types.Bool
+}
+
+func isError(t types.Type) bool {
+	return types.Identical(t, types.Universe.Lookup("error").Type())
+}
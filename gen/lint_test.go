@@ -0,0 +1,83 @@
+package gen
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const lintSource = `package app
+
+import (
+	"reflect"
+	. "reflect"
+)
+
+type Converter func(reflect.Value) (string, error)
+
+func nilBuilder(t reflect.Type) (Converter, bool) {
+	if t.Kind() != reflect.Int {
+		return nil, false
+	}
+	return nil, true
+}
+
+func setter(t Type) (func(Value) (int, error), bool) {
+	return func(v Value) (int, error) {
+		v.Field(0).SetInt(1)
+		return 0, nil
+	}, true
+}
+
+func checked(v Value) (int, error) {
+	if !v.CanSet() {
+		v = reflect.New(v.Type()).Elem()
+	}
+	v.SetInt(1)
+	return 0, nil
+}
+
+func mapping(dst, src Value, s *struct{}) error {
+	dst.Set(src.Addr())
+	return nil
+}
+
+func inverter(v string) (Value, error) {
+	p := &v
+	return ValueOf(p), nil
+}
+
+func goodInverter(v string) (Value, error) {
+	return ValueOf(&v).Elem(), nil
+}
+`
+
+func TestLint(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "app.go"), []byte(lintSource), 0666); err != nil {
+		t.Fatal(err)
+	}
+	pkg, err := Load(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []struct {
+		line  int
+		check string
+	}{
+		{14, "nilfunc"},
+		{19, "addressable"},
+		{33, "addressable"},
+		{39, "inverter"},
+	}
+	got := pkg.Lint()
+	if len(got) != len(want) {
+		t.Fatalf("wrong diagnostics: %+v", got)
+	}
+	for i, d := range got {
+		if d.Pos.Line != want[i].line || d.Check != want[i].check {
+			t.Errorf("expected %s at line %d, got %+v", want[i].check, want[i].line, d)
+		}
+	}
+}
@@ -0,0 +1,131 @@
+package gen
+
+import (
+	"fmt"
+	"go/types"
+
+	"github.com/blitz-frost/conv"
+)
+
+// A Report lists the losses of the function generated for a Pair, in the terms of conv.StructMap.Analyze.
+type Report struct {
+	Pair
+	Losses []conv.Loss
+}
+
+// Report returns the Reports of "pairs", including lossless ones, for review in code audits.
+func (x *Package) Report(pairs []Pair) ([]Report, error) {
+	g := newGenerator(x.Types)
+	o := make([]Report, len(pairs))
+	for i, p := range pairs {
+		tSrc, err := x.Type(p.Src)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", p.Name, err)
+		}
+		tDst, err := x.Type(p.Dst)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", p.Name, err)
+		}
+		o[i] = Report{
+			Pair:   p,
+			Losses: g.analyze("", tDst, tSrc, make(map[string]bool)),
+		}
+	}
+	return o, nil
+}
+
+// analyze mirrors conv.StructMap.Analyze for the generation rules, reporting the losses of converting "tSrc" into "tDst" at "path".
+// "active" holds the struct pairs currently being analyzed, guarding against recursive types.
+func (x *generator) analyze(path string, tDst, tSrc types.Type, active map[string]bool) []conv.Loss {
+	uDst, uSrc := tDst.Underlying(), tSrc.Underlying()
+	pDst, dstPtr := uDst.(*types.Pointer)
+	pSrc, srcPtr := uSrc.(*types.Pointer)
+	at := func(k conv.LossKind, detail string) []conv.Loss {
+		return []conv.Loss{{
+			Path:   path,
+			Kind:   k,
+			Detail: detail,
+		}}
+	}
+
+	switch {
+	case types.AssignableTo(tSrc, tDst):
+		return nil
+	case convertible(tDst, tSrc):
+		bDst, ok1 := uDst.(*types.Basic)
+		bSrc, ok2 := uSrc.(*types.Basic)
+		if ok1 && ok2 && basicLoss(bDst, bSrc) {
+			return at(conv.LossNarrowing, x.typ(tSrc)+" to "+x.typ(tDst))
+		}
+		return nil
+	case srcPtr && dstPtr:
+		return x.analyze(path, pDst.Elem(), pSrc.Elem(), active)
+	case srcPtr:
+		return append(at(conv.LossNil, ""), x.analyze(path, tDst, pSrc.Elem(), active)...)
+	case dstPtr:
+		return x.analyze(path, pDst.Elem(), tSrc, active)
+	case sequence(uDst) && sequence(uSrc):
+		var o []conv.Loss
+		if a, ok := uDst.(*types.Array); ok {
+			if b, ok := uSrc.(*types.Array); !ok || b.Len() > a.Len() {
+				o = at(conv.LossTruncation, fmt.Sprintf("limited to %d elements", a.Len()))
+			}
+		}
+		return append(o, x.analyze(path+"[]", elem(uDst), elem(uSrc), active)...)
+	case isMap(uDst) && isMap(uSrc):
+		mDst, mSrc := uDst.(*types.Map), uSrc.(*types.Map)
+		o := x.analyze(path+"[key]", mDst.Key(), mSrc.Key(), active)
+		return append(o, x.analyze(path+"[]", mDst.Elem(), mSrc.Elem(), active)...)
+	case isStruct(uDst) && isStruct(uSrc):
+		return x.analyzeStruct(path, tDst, tSrc, active)
+	}
+	return at(conv.LossUnknown, x.typ(tSrc)+" to "+x.typ(tDst)+" is not supported")
+}
+
+func (x *generator) analyzeStruct(path string, tDst, tSrc types.Type, active map[string]bool) []conv.Loss {
+	key := types.TypeString(tDst, nil) + "\x00" + types.TypeString(tSrc, nil)
+	if active[key] {
+		return nil
+	}
+	active[key] = true
+	defer delete(active, key)
+
+	field := func(name string) string {
+		if path == "" {
+			return name
+		}
+		return path + "." + name
+	}
+
+	var o []conv.Loss
+	matched := make(map[string]bool)
+	for _, f := range visibleFields(tDst) {
+		df := f.v
+		if !df.Exported() || f.indirect || (df.Embedded() && isStruct(df.Type().Underlying())) {
+			continue
+		}
+		name := field(df.Name())
+		obj, index, _ := lookupField(tSrc, x.pkg, fieldName(f))
+		sf, ok := obj.(*types.Var)
+		if !ok || !sf.IsField() || !sf.Exported() {
+			o = append(o, conv.Loss{Path: name, Kind: conv.LossDefaulted, Detail: "no source field"})
+			continue
+		}
+		matched[sf.Name()] = true
+
+		if ignored(f.tag) || ignored(fieldTag(tSrc, index)) {
+			o = append(o, conv.Loss{Path: name, Kind: conv.LossIgnored, Detail: "excluded by directive"})
+			continue
+		}
+		o = append(o, x.analyze(name, df.Type(), sf.Type(), active)...)
+	}
+
+	for _, f := range visibleFields(tSrc) {
+		sf := f.v
+		if !sf.Exported() || (sf.Embedded() && isStruct(sf.Type().Underlying())) || matched[sf.Name()] {
+			continue
+		}
+		o = append(o, conv.Loss{Path: field(sf.Name()), Kind: conv.LossIgnored, Detail: "no destination field"})
+	}
+	return o
+}
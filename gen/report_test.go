@@ -0,0 +1,61 @@
+package gen
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestReport(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "types.go"), []byte(testTypes), 0666); err != nil {
+		t.Fatal(err)
+	}
+	pkg, err := Load(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	reports, err := pkg.Report([]Pair{
+		{Name: "UserFromRow", Src: "Row", Dst: "User"},
+		{Name: "RowFromUser", Src: "User", Dst: "Row"},
+		{Name: "Item2FromItem", Src: "Item", Dst: "Item2"},
+		{Name: "ItemFromItem2", Src: "Item2", Dst: "Item"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := [][]string{
+		{
+			"Secret: ignored (excluded by directive)",
+			"Items[].N: narrowing (int to uint8)",
+			"Ptr: nil",
+			"Extra: defaulted (no source field)",
+		},
+		{
+			"Age: narrowing (float64 to int32)",
+			"Scores[]: narrowing (float64 to float32)",
+			"Secret: ignored (excluded by directive)",
+			"Items[]: nil",
+			"Arr: truncation (limited to 3 elements)",
+			"Extra: ignored (no destination field)",
+		},
+		{"N: narrowing (int to uint8)"},
+		nil,
+	}
+	for i, r := range reports {
+		var got []string
+		for _, l := range r.Losses {
+			got = append(got, l.String())
+		}
+		if len(got) != len(want[i]) {
+			t.Errorf("%s: wrong losses %q", r.Name, got)
+			continue
+		}
+		for j := range got {
+			if got[j] != want[i][j] {
+				t.Errorf("%s: expected %q, got %q", r.Name, want[i][j], got[j])
+			}
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package gen
+
+import (
+	"bytes"
+	"fmt"
+	"go/token"
+	"go/types"
+	"unicode"
+)
+
+// Stubs returns the formatted source of a file for the package, implementing each of the named interface types through a conv.Mapper, as a typed facade over it.
+// Interface methods must convert one value, with one of the forms:
+//
+//	ToDTO(User) (UserDTO, error)
+//	ToDTO(User) UserDTO
+//
+// where the second form panics if the conversion fails.
+//
+// For an interface named Converts, the file declares an unexported convertsImpl type, along with:
+//
+//	// NewConverts returns a Converts backed by "m", or by conv.NewDeepMapper(nil) if nil.
+//	func NewConverts(m *conv.Mapper) Converts
+func (x *Package) Stubs(ifaces []string) ([]byte, error) {
+	g := newGenerator(x.Types)
+	conv := g.importName(convPath, "conv")
+
+	var body bytes.Buffer
+	for _, name := range ifaces {
+		obj, ok := x.Types.Scope().Lookup(name).(*types.TypeName)
+		if !ok {
+			return nil, fmt.Errorf("%s: not a type of the package", name)
+		}
+		iface, ok := obj.Type().Underlying().(*types.Interface)
+		if !ok {
+			return nil, fmt.Errorf("%s: not an interface type", name)
+		}
+
+		r := []rune(name)
+		ctor := "New" + name
+		if !token.IsExported(name) {
+			ctor = "new" + string(unicode.ToUpper(r[0])) + string(r[1:])
+		}
+		r[0] = unicode.ToLower(r[0])
+		impl := string(r) + "Impl"
+		for _, n := range [2]string{impl, ctor} {
+			if g.taken(n) || g.names[n] {
+				return nil, fmt.Errorf("%s: %s is already declared", name, n)
+			}
+			g.names[n] = true
+		}
+
+		fmt.Fprintf(&body, "\n// %s implements %s through a Mapper.\ntype %s struct {\nm *%s.Mapper\n}\n", impl, name, impl, conv)
+		fmt.Fprintf(&body, "\n// %s returns a %s backed by \"m\", or by conv.NewDeepMapper(nil) if nil.\n", ctor, name)
+		fmt.Fprintf(&body, "func %s(m *%s.Mapper) %s {\nif m == nil {\nm = %s.NewDeepMapper(nil)\n}\nreturn %s{m}\n}\n", ctor, conv, name, conv, impl)
+
+		for i := 0; i < iface.NumMethods(); i++ {
+			m := iface.Method(i)
+			sig := m.Type().(*types.Signature)
+			params, results := sig.Params(), sig.Results()
+			withErr := results.Len() == 2 && isError(results.At(1).Type())
+			if params.Len() != 1 || sig.Variadic() || (results.Len() != 1 && !withErr) {
+				return nil, fmt.Errorf("%s.%s: expected a single parameter, and a single result with an optional error", name, m.Name())
+			}
+			src, dst := g.typ(params.At(0).Type()), g.typ(results.At(0).Type())
+
+			fmt.Fprintf(&body, "\nfunc (x %s) %s(src %s) ", impl, m.Name(), src)
+			if withErr {
+				fmt.Fprintf(&body, "(%s, error) {\nvar dst %s\nerr := x.m.Map(&dst, src)\nreturn dst, err\n}\n", dst, dst)
+			} else {
+				fmt.Fprintf(&body, "%s {\nvar dst %s\nif err := x.m.Map(&dst, src); err != nil {\npanic(err)\n}\nreturn dst\n}\n", dst, dst)
+			}
+		}
+	}
+	return g.file("conv-gen", body.Bytes())
+}
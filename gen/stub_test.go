@@ -0,0 +1,84 @@
+package gen
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const stubTypes = `package main
+
+type User struct {
+	ID   int64
+	Name string
+}
+
+type UserDTO struct {
+	ID   int
+	Name string
+}
+
+type Converts interface {
+	ToDTO(User) UserDTO
+	ToModel(UserDTO) (User, error)
+}
+`
+
+const stubMain = `package main
+
+import "fmt"
+
+func main() {
+	c := NewConverts(nil)
+	dto := c.ToDTO(User{ID: 1, Name: "a"})
+	u, err := c.ToModel(dto)
+	fmt.Println(dto, u, err)
+}
+`
+
+func TestStubs(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0666); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("types.go", stubTypes)
+	pkg, err := Load(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	src, err := pkg.Stubs([]string{"Converts"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, s := range []string{"type convertsImpl struct {", "func NewConverts(m *conv.Mapper) Converts {", "func (x convertsImpl) ToModel(src UserDTO) (User, error) {"} {
+		if !strings.Contains(string(src), s) {
+			t.Errorf("missing %q:\n%s", s, src)
+		}
+	}
+	if _, err := pkg.Stubs([]string{"User"}); err == nil {
+		t.Error("expected non interface error")
+	}
+
+	gobin, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go command not available")
+	}
+	root, _ := filepath.Abs("..")
+	write("conv_stubs.go", string(src))
+	write("main.go", stubMain)
+	write("go.mod", "module example.com/stubtest\n\ngo 1.20\n\nrequire github.com/blitz-frost/conv v0.0.0\n\nreplace github.com/blitz-frost/conv => "+root+"\n")
+	cmd := exec.Command(gobin, "run", ".")
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GOFLAGS=-mod=mod", "GOPROXY=off")
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("%v\n%s\n%s", err, out, src)
+	}
+	if got := strings.TrimSpace(string(out)); got != "{1 a} {1 a} <nil>" {
+		t.Errorf("wrong output %q", got)
+	}
+}
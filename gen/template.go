@@ -0,0 +1,57 @@
+package gen
+
+import (
+	"text/template"
+)
+
+// DefaultTemplate defines the "func" and "helper" templates used by Generate.
+//
+// "func" is executed with FuncData for each Pair, and "helper" with HelperData for each struct conversion helper.
+// Templates may import packages through the "import" function, which takes a package path and returns the name to refer to it by:
+//
+//	{{import "log"}}.Printf("converting %v", src)
+const DefaultTemplate = `{{define "func"}}
+// {{.Name}} converts {{.Pair.Src}} values into {{.Pair.Dst}}.
+func {{.Name}}(src {{.Src}}) ({{.Dst}}, error) {
+	var dst {{.Dst}}
+{{.Body}}	return dst, nil
+}
+{{end}}{{define "helper"}}
+func {{.Name}}(dst *{{.Dst}}, src {{.Src}}) {
+{{.Body}}}
+{{end}}`
+
+// FuncData is the input of the "func" template.
+type FuncData struct {
+	Pair
+	Src, Dst string // type source forms, qualified for the generated file
+	Body     string // statements converting "src" into "dst", which starts as a zero value
+}
+
+// HelperData is the input of the "helper" template.
+// Helpers convert struct "src" into the struct pointed to by "dst", and are called by the generated functions.
+type HelperData struct {
+	Name     string
+	Src, Dst string
+	Body     string
+}
+
+// ParseTemplate returns DefaultTemplate with the templates defined by "text" taking precedence, so that either may be replaced on its own:
+//
+//	{{define "func"}}
+//	func {{.Name}}(ctx context.Context, src {{.Src}}) ({{.Dst}}, error) {
+//		{{import "example.com/metrics"}}.Inc(ctx, "{{.Name}}")
+//		var dst {{.Dst}}
+//	{{.Body}}	return dst, nil
+//	}
+//	{{end}}
+func ParseTemplate(text string) (*template.Template, error) {
+	o := defaultTemplate()
+	return o.Parse(text)
+}
+
+func defaultTemplate() *template.Template {
+	return template.Must(template.New("gen").Funcs(template.FuncMap{
+		"import": func(path string) string { return "" },
+	}).Parse(DefaultTemplate))
+}
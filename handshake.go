@@ -0,0 +1,206 @@
+package conv
+
+import (
+	"errors"
+	"fmt"
+	. "reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var ErrIncompatible = errors.New("incompatible types")
+
+// A TypeOffer announces a type expected by one side of a connection, under an application defined name, such as a message or method name.
+type TypeOffer struct {
+	Name        string
+	Fingerprint uint64
+	Base        string
+}
+
+// A Handshake negotiates the types exchanged over a connection, before any values are.
+// Each side sends its Offer, and Negotiates the one it receives: types with the same fingerprint are used as they are, while differing ones are reconstructed from their base, compared, and bridged through Mapper if possible.
+type Handshake struct {
+	Mapper *Mapper // bridges differing types; a plain deep Mapper is used if nil
+
+	types map[string]Type
+}
+
+// An Agreement is the result of a successful negotiation.
+type Agreement struct {
+	Types   map[string]*Negotiated
+	Unknown []string // names only offered by the remote side
+}
+
+// A Negotiated type pairs a local type with its remote counterpart.
+type Negotiated struct {
+	Local, Remote Type
+	Identical     bool     // the types share a base; Remote is then the same as Local
+	Diff          []string // structural differences, as returned by TypeDiff
+	Decode        Mapping  // from Remote to Local values; nil if Identical
+	Encode        Mapping  // from Local to Remote values; nil if Identical, or if not available
+}
+
+// Expect registers type "t" under "name".
+func (x *Handshake) Expect(name string, t Type) {
+	if x.types == nil {
+		x.types = make(map[string]Type)
+	}
+	x.types[name] = t
+}
+
+// Offer returns the registered types, ordered by name.
+func (x *Handshake) Offer() []TypeOffer {
+	o := make([]TypeOffer, 0, len(x.types))
+	for name, t := range x.types {
+		base := Base(t)
+		o = append(o, TypeOffer{
+			Name:        name,
+			Fingerprint: fingerprint(base),
+			Base:        base,
+		})
+	}
+	sort.Slice(o, func(i, j int) bool {
+		return o[i].Name < o[j].Name
+	})
+	return o
+}
+
+// Negotiate matches the remote offer against the registered types.
+// Fails with ErrIncompatible if a registered type is missing from the offer, or cannot be decoded from its remote counterpart, describing the differences. Decoding is verified on zero values.
+func (x *Handshake) Negotiate(remote []TypeOffer) (*Agreement, error) {
+	m := x.Mapper
+	if m == nil {
+		m = NewDeepMapper(nil)
+	}
+	lib := (*Library[Mapping])(m)
+
+	o := &Agreement{
+		Types: make(map[string]*Negotiated),
+	}
+	offered := make(map[string]TypeOffer, len(remote))
+	for _, r := range remote {
+		if r.Fingerprint != fingerprint(r.Base) {
+			return nil, fmt.Errorf("type %s: fingerprint does not match base: %w", r.Name, ErrInvalid)
+		}
+		offered[r.Name] = r
+		if _, ok := x.types[r.Name]; !ok {
+			o.Unknown = append(o.Unknown, r.Name)
+		}
+	}
+
+	var errs []string
+	for _, name := range sortedKeys(x.types) {
+		t := x.types[name]
+		r, ok := offered[name]
+		if !ok {
+			errs = append(errs, fmt.Sprintf("type %s: not offered", name))
+			continue
+		}
+		if r.Fingerprint == Fingerprint(t) {
+			o.Types[name] = &Negotiated{
+				Local:     t,
+				Remote:    t,
+				Identical: true,
+			}
+			continue
+		}
+
+		rt, err := asType(r.Base, 0)
+		if err != nil {
+			return nil, fmt.Errorf("type %s: %w", name, err)
+		}
+		n := &Negotiated{
+			Local:  t,
+			Remote: rt,
+			Diff:   TypeDiff(t, rt),
+		}
+		if fn, ok := lib.Lookup(MappingType(t, rt)); ok && fn(New(t).Elem(), New(rt).Elem(), nil) == nil {
+			n.Decode = fn
+		} else {
+			errs = append(errs, fmt.Sprintf("type %s: %s", name, strings.Join(n.Diff, "; ")))
+			continue
+		}
+		if fn, ok := lib.Lookup(MappingType(rt, t)); ok && fn(New(rt).Elem(), New(t).Elem(), nil) == nil {
+			n.Encode = fn
+		}
+		o.Types[name] = n
+	}
+
+	if errs != nil {
+		return o, fmt.Errorf("%w: %s", ErrIncompatible, strings.Join(errs, ", "))
+	}
+	return o, nil
+}
+
+func sortedKeys[T any](m map[string]T) []string {
+	o := make([]string, 0, len(m))
+	for k := range m {
+		o = append(o, k)
+	}
+	sort.Strings(o)
+	return o
+}
+
+// TypeDiff describes the structural differences between types "a" and "b", one per line, as:
+//
+//	path: base of a != base of b
+//	path: only in a
+//	path: only in b
+//
+// Paths start at the root type, written as "", and follow struct fields as ".Name", elements as "[]", map keys as "[key]" and array lengths as "[len]". Pointers are transparent.
+// Returns nil if the types share a base.
+func TypeDiff(a, b Type) []string {
+	var o []string
+	typeDiff(a, b, "", make(map[[2]Type]bool), &o)
+	return o
+}
+
+func typeDiff(a, b Type, path string, seen map[[2]Type]bool, o *[]string) {
+	if a == b || seen[[2]Type{a, b}] {
+		return
+	}
+	seen[[2]Type{a, b}] = true
+
+	mismatch := func() {
+		*o = append(*o, path+": "+Base(a)+" != "+Base(b))
+	}
+	if a.Kind() != b.Kind() {
+		mismatch()
+		return
+	}
+
+	switch a.Kind() {
+	case Array:
+		if a.Len() != b.Len() {
+			*o = append(*o, path+"[len]: "+strconv.Itoa(a.Len())+" != "+strconv.Itoa(b.Len()))
+		}
+		typeDiff(a.Elem(), b.Elem(), path+"[]", seen, o)
+	case Slice, Chan:
+		typeDiff(a.Elem(), b.Elem(), path+"[]", seen, o)
+	case Pointer:
+		typeDiff(a.Elem(), b.Elem(), path, seen, o)
+	case Map:
+		typeDiff(a.Key(), b.Key(), path+"[key]", seen, o)
+		typeDiff(a.Elem(), b.Elem(), path+"[]", seen, o)
+	case Struct:
+		for i, n := 0, a.NumField(); i < n; i++ {
+			fa := a.Field(i)
+			if fb, ok := b.FieldByName(fa.Name); ok && len(fb.Index) == 1 {
+				typeDiff(fa.Type, fb.Type, path+"."+fa.Name, seen, o)
+			} else {
+				*o = append(*o, path+"."+fa.Name+": only in a")
+			}
+		}
+		for i, n := 0, b.NumField(); i < n; i++ {
+			fb := b.Field(i)
+			if fa, ok := a.FieldByName(fb.Name); !ok || len(fa.Index) != 1 {
+				*o = append(*o, path+"."+fb.Name+": only in b")
+			}
+		}
+	case Func, Interface:
+		if Base(a) != Base(b) {
+			mismatch()
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package conv
+
+import (
+	"errors"
+	. "reflect"
+	"testing"
+)
+
+func TestHandshake(t *testing.T) {
+	type itemV1 struct {
+		ID    int32
+		Name  string
+		Price float64
+	}
+	type itemV2 struct {
+		ID   int64
+		Name string
+		Tags []string
+	}
+	type other struct {
+		N int
+	}
+
+	var a, b Handshake
+	a.Expect("item", TypeEval[itemV1]())
+	a.Expect("other", TypeEval[other]())
+	a.Expect("extra", TypeEval[bool]())
+	b.Expect("item", TypeEval[itemV2]())
+	b.Expect("other", TypeEval[struct{ N int }]())
+
+	agreement, err := b.Negotiate(a.Offer())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !DeepEqual(agreement.Unknown, []string{"extra"}) {
+		t.Error("wrong unknown types", agreement.Unknown)
+	}
+	if n := agreement.Types["other"]; !n.Identical || n.Decode != nil {
+		t.Error("expected identical type", n)
+	}
+
+	n := agreement.Types["item"]
+	expDiff := []string{".ID: int64 != int32", ".Tags: only in a", ".Price: only in b"}
+	if n.Identical || !DeepEqual(n.Diff, expDiff) {
+		t.Error("wrong diff", n.Diff)
+	}
+
+	// decode a value of the remote layout, as received
+	remote := New(n.Remote).Elem()
+	remote.Field(0).SetInt(7)
+	remote.Field(1).SetString("x")
+	var local itemV2
+	if err := n.Decode(ValueOf(&local).Elem(), remote, nil); err != nil {
+		t.Fatal(err)
+	}
+	if local.ID != 7 || local.Name != "x" {
+		t.Error("wrong decoded value", local)
+	}
+	if n.Encode == nil {
+		t.Error("missing encode mapping")
+	}
+
+	// incompatible, and missing types
+	var c Handshake
+	c.Expect("item", TypeEval[struct{ ID []int }]())
+	c.Expect("missing", TypeEval[int]())
+	if _, err := c.Negotiate(a.Offer()); !errors.Is(err, ErrIncompatible) {
+		t.Error("expected incompatible types, got", err)
+	}
+
+	offer := a.Offer()
+	offer[0].Fingerprint++
+	if _, err := b.Negotiate(offer); !errors.Is(err, ErrInvalid) {
+		t.Error("expected invalid offer, got", err)
+	}
+}
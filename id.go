@@ -0,0 +1,196 @@
+package conv
+
+import (
+	"encoding/hex"
+	"fmt"
+	. "reflect"
+	"strings"
+)
+
+// UUIDMapping is a Builder of Mappings between [16]byte kinds and:
+//
+//   - string kinds, in canonical UUID form, such as "f81d4fae-7dec-11d0-a765-00a0c91e6bf6"
+//   - byte slices, holding the 16 raw bytes
+//
+// Parsing is case insensitive, and also accepts the hex digits without dashes, optionally enclosed in braces or prefixed by "urn:uuid:".
+// Empty strings and slices map to zero values, while zero values map to the nil UUID, "00000000-0000-0000-0000-000000000000", or 16 zero bytes.
+func UUIDMapping(t Type) (Mapping, bool) {
+	tDst, tSrc := t.Out(0), t.In(0)
+	switch {
+	case isByteArray(tSrc, 16) && tDst.Kind() == String:
+		return func(dst, src Value, s *State) error {
+			b := scratchBytes(src)
+			dst.SetString(uuidFormat(*b))
+			putBytes(b)
+			return nil
+		}, true
+	case isByteArray(tDst, 16) && tSrc.Kind() == String:
+		return func(dst, src Value, s *State) error {
+			b, err := uuidParse(src.String())
+			if err != nil {
+				return err
+			}
+			Copy(dst, ValueOf(b))
+			return nil
+		}, true
+	case isByteArray(tSrc, 16) && isBytes(tDst):
+		return func(dst, src Value, s *State) error {
+			dst.SetBytes(arrayBytes(src))
+			return nil
+		}, true
+	case isByteArray(tDst, 16) && isBytes(tSrc):
+		return func(dst, src Value, s *State) error {
+			return arraySet(dst, src.Bytes())
+		}, true
+	}
+	return nil, false
+}
+
+// UUIDConverter is a Builder of string Converters for the types covered by UUIDMapping.
+func UUIDConverter(t Type) (Converter[string], bool) {
+	return mappingConverter(UUIDMapping, t)
+}
+
+// UUIDInverter is a Builder of string Inverters for the types covered by UUIDMapping.
+func UUIDInverter(t Type) (Inverter[string], bool) {
+	return mappingInverter(UUIDMapping, t)
+}
+
+// HexMapping is a Builder of Mappings between byte arrays of any length and string kinds, as lower case hex digits, two per byte.
+// Parsing is case insensitive, and the number of digits must match the array length exactly; empty strings map to zero values.
+//
+// Being more general, HexMapping should follow UUIDMapping in a Scheme that uses both.
+func HexMapping(t Type) (Mapping, bool) {
+	tDst, tSrc := t.Out(0), t.In(0)
+	switch {
+	case isByteArray(tSrc, -1) && tDst.Kind() == String:
+		return func(dst, src Value, s *State) error {
+			b := scratchBytes(src)
+			dst.SetString(hex.EncodeToString(*b))
+			putBytes(b)
+			return nil
+		}, true
+	case isByteArray(tDst, -1) && tSrc.Kind() == String:
+		return func(dst, src Value, s *State) error {
+			b, err := hex.DecodeString(src.String())
+			if err != nil {
+				return fmt.Errorf("%w: %w", ErrInvalid, err)
+			}
+			return arraySet(dst, b)
+		}, true
+	}
+	return nil, false
+}
+
+// HexConverter is a Builder of string Converters for the types covered by HexMapping.
+func HexConverter(t Type) (Converter[string], bool) {
+	return mappingConverter(HexMapping, t)
+}
+
+// HexInverter is a Builder of string Inverters for the types covered by HexMapping.
+func HexInverter(t Type) (Inverter[string], bool) {
+	return mappingInverter(HexMapping, t)
+}
+
+// isByteArray returns true if "t" is an array of bytes, of length "n" unless negative.
+func isByteArray(t Type, n int) bool {
+	return t.Kind() == Array && t.Elem().Kind() == Uint8 && (n < 0 || t.Len() == n)
+}
+
+// arrayBytes returns a copy of the contents of byte array "v".
+func arrayBytes(v Value) []byte {
+	o := make([]byte, v.Len())
+	Copy(ValueOf(o), v)
+	return o
+}
+
+// scratchBytes is the same as arrayBytes, but returns a pooled buffer, to be returned through putBytes.
+func scratchBytes(v Value) *[]byte {
+	b := getBytes(v.Len())
+	Copy(ValueOf(*b), v)
+	return b
+}
+
+// arraySet sets byte array "v" to "b", which must be empty or have the same length.
+func arraySet(v Value, b []byte) error {
+	if len(b) == 0 {
+		v.SetZero()
+		return nil
+	}
+	if len(b) != v.Len() {
+		return fmt.Errorf("%d bytes into %s: %w", len(b), describeType(v.Type()), ErrInvalid)
+	}
+	Copy(v, ValueOf(b))
+	return nil
+}
+
+func uuidFormat(b []byte) string {
+	o := make([]byte, 36)
+	hex.Encode(o, b[:4])
+	o[8] = '-'
+	hex.Encode(o[9:], b[4:6])
+	o[13] = '-'
+	hex.Encode(o[14:], b[6:8])
+	o[18] = '-'
+	hex.Encode(o[19:], b[8:10])
+	o[23] = '-'
+	hex.Encode(o[24:], b[10:])
+	return string(o)
+}
+
+func uuidParse(s string) ([]byte, error) {
+	o := make([]byte, 16)
+	if s == "" {
+		return o, nil
+	}
+
+	digits := s
+	switch {
+	case len(digits) == 38 && digits[0] == '{' && digits[37] == '}':
+		digits = digits[1:37]
+	case len(digits) == 45 && strings.EqualFold(digits[:9], "urn:uuid:"):
+		digits = digits[9:]
+	}
+	if len(digits) == 36 {
+		if digits[8] != '-' || digits[13] != '-' || digits[18] != '-' || digits[23] != '-' {
+			return nil, fmt.Errorf("UUID %q: %w", s, ErrInvalid)
+		}
+		digits = digits[:8] + digits[9:13] + digits[14:18] + digits[19:23] + digits[24:]
+	}
+	if len(digits) != 32 {
+		return nil, fmt.Errorf("UUID %q: %w", s, ErrInvalid)
+	}
+	if _, err := hex.Decode(o, []byte(digits)); err != nil {
+		return nil, fmt.Errorf("UUID %q: %w", s, ErrInvalid)
+	}
+	return o, nil
+}
+
+// mappingConverter returns a string Converter built from a Mapping Builder.
+func mappingConverter(b Builder[Mapping], t Type) (Converter[string], bool) {
+	fn, ok := b(MappingType(typeString, t))
+	if !ok {
+		return nil, false
+	}
+	return func(v Value) (string, error) {
+		o := getScratch(typeString)
+		defer putScratch(o)
+		err := fn(o, v, nil)
+		return o.String(), err
+	}, true
+}
+
+// mappingInverter returns a string Inverter built from a Mapping Builder.
+func mappingInverter(b Builder[Mapping], t Type) (Inverter[string], bool) {
+	fn, ok := b(MappingType(t, typeString))
+	if !ok {
+		return nil, false
+	}
+	return func(s string) (Value, error) {
+		o := New(t).Elem()
+		if err := fn(o, ValueOf(s), nil); err != nil {
+			return Value{}, err
+		}
+		return o, nil
+	}, true
+}
@@ -0,0 +1,65 @@
+package conv
+
+import (
+	"errors"
+	. "reflect"
+	"testing"
+)
+
+func TestUUIDMapping(t *testing.T) {
+	type ID [16]byte
+	type record struct {
+		ID    ID
+		Trace [8]byte
+		Raw   [16]byte
+	}
+	type wire struct {
+		ID    string
+		Trace string
+		Raw   []byte
+	}
+
+	m := NewDeepMapper(nil, UUIDMapping, HexMapping)
+	src := wire{
+		ID:    "F81D4FAE-7DEC-11D0-A765-00A0C91E6BF6",
+		Trace: "0102030405a0b0c0",
+		Raw:   []byte{15: 1},
+	}
+	var r record
+	if err := m.Map(&r, src); err != nil {
+		t.Fatal(err)
+	}
+	if r.ID != (ID{0xf8, 0x1d, 0x4f, 0xae, 0x7d, 0xec, 0x11, 0xd0, 0xa7, 0x65, 0x00, 0xa0, 0xc9, 0x1e, 0x6b, 0xf6}) || r.Trace != [8]byte{1, 2, 3, 4, 5, 0xa0, 0xb0, 0xc0} || r.Raw != [16]byte{15: 1} {
+		t.Error("wrong record", r)
+	}
+
+	var back wire
+	if err := m.Map(&back, r); err != nil {
+		t.Fatal(err)
+	}
+	if back.ID != "f81d4fae-7dec-11d0-a765-00a0c91e6bf6" || back.Trace != src.Trace || !DeepEqual(back.Raw, src.Raw) {
+		t.Error("wrong round trip", back)
+	}
+
+	inv := NewInversion(Scheme[Inverter[string]]{UUIDInverter, HexInverter}.Build)
+	for _, s := range []string{"{f81d4fae-7dec-11d0-a765-00a0c91e6bf6}", "urn:uuid:f81d4fae-7dec-11d0-a765-00a0c91e6bf6", "f81d4fae7dec11d0a76500a0c91e6bf6"} {
+		if id, err := As[ID](inv, s); err != nil || id != r.ID {
+			t.Error("wrong parse of", s, err)
+		}
+	}
+	if id, err := As[ID](inv, ""); err != nil || id != (ID{}) {
+		t.Error("empty string not zero", id, err)
+	}
+	for _, s := range []string{"f81d4fae-7dec-11d0-a765_00a0c91e6bf6", "f81d4fae-7dec-11d0-a765-00a0c91e6bfx", "f81d"} {
+		if _, err := As[ID](inv, s); !errors.Is(err, ErrInvalid) {
+			t.Error("expected invalid UUID", s, err)
+		}
+	}
+	if _, err := As[[8]byte](inv, "0102"); !errors.Is(err, ErrInvalid) {
+		t.Error("expected invalid length, got", err)
+	}
+
+	if s, err := NewConversion(UUIDConverter).Call(ID{}); err != nil || s != "00000000-0000-0000-0000-000000000000" {
+		t.Error("wrong nil UUID", s, err)
+	}
+}
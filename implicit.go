@@ -0,0 +1,88 @@
+package conv
+
+import (
+	. "reflect"
+)
+
+// ImplicitMapping is a Builder of Mappings between types that share their memory representation, which it reinterprets as each other, at the cost of a plain copy.
+// Types share their representation if they are of the same kind, and so are their components: array lengths, channel directions, function signatures, map keys and elements, pointer and slice elements, and struct fields, in order, regardless of their names and tags.
+// This extends Go conversions to composite types of convertible components, such as []Celsius into []float64, func(Celsius) into func(float64), or structs with differently named fields.
+// Interfaces are not covered, as interfaces with and without methods are represented differently.
+//
+// As with Go conversions, reference types, such as slices and maps, share their contents with the source, rather than copying them.
+// Unexported struct fields are carried over too, so types with invariants beyond their representation should be kept out, such as through NamedTypes.
+//
+// In safe mode, only the types that Go can convert are covered.
+func ImplicitMapping(t Type) (Mapping, bool) {
+	tDst, tSrc := t.Out(0), t.In(0)
+	if !implicit(tDst, tSrc, make(map[[2]Type]bool)) {
+		return nil, false
+	}
+
+	if safeMode {
+		if !tSrc.ConvertibleTo(tDst) {
+			return nil, false
+		}
+		return func(dst, src Value, s *State) error {
+			dst.Set(src.Convert(tDst))
+			return nil
+		}, true
+	}
+	return func(dst, src Value, s *State) error {
+		viewAs(dst, tSrc).Set(src)
+		return nil
+	}, true
+}
+
+// implicit returns true if "a" and "b" share the same memory representation.
+// "active" holds the type pairs being checked, which are assumed to match, so that recursive types terminate.
+func implicit(a, b Type, active map[[2]Type]bool) bool {
+	if a == b {
+		return true
+	}
+	k := a.Kind()
+	if k != b.Kind() || k == Interface {
+		return false
+	}
+	pair := [2]Type{a, b}
+	if active[pair] {
+		return true
+	}
+	active[pair] = true
+	defer delete(active, pair)
+
+	switch k {
+	case Array:
+		return a.Len() == b.Len() && implicit(a.Elem(), b.Elem(), active)
+	case Chan:
+		return a.ChanDir() == b.ChanDir() && implicit(a.Elem(), b.Elem(), active)
+	case Func:
+		if a.NumIn() != b.NumIn() || a.NumOut() != b.NumOut() || a.IsVariadic() != b.IsVariadic() {
+			return false
+		}
+		for i, n := 0, a.NumIn(); i < n; i++ {
+			if !implicit(a.In(i), b.In(i), active) {
+				return false
+			}
+		}
+		for i, n := 0, a.NumOut(); i < n; i++ {
+			if !implicit(a.Out(i), b.Out(i), active) {
+				return false
+			}
+		}
+	case Map:
+		return implicit(a.Key(), b.Key(), active) && implicit(a.Elem(), b.Elem(), active)
+	case Pointer, Slice:
+		return implicit(a.Elem(), b.Elem(), active)
+	case Struct:
+		if a.NumField() != b.NumField() {
+			return false
+		}
+		for i, n := 0, a.NumField(); i < n; i++ {
+			if !implicit(a.Field(i).Type, b.Field(i).Type, active) {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,95 @@
+package conv
+
+import (
+	"errors"
+	. "reflect"
+	"testing"
+)
+
+type implicitCelsius float64
+
+type implicitNode struct {
+	V    int
+	Next *implicitNode
+}
+
+type implicitLink struct {
+	Value int
+	Link  *implicitLink
+}
+
+func TestImplicitMapping(t *testing.T) {
+	m := NewMapper(ImplicitMapping)
+
+	// Go convertible
+	type point struct{ X, Y int }
+	type pair struct {
+		X int `json:"x"`
+		Y int `json:"y"`
+	}
+	var p pair
+	if err := m.Map(&p, point{1, 2}); err != nil || p != (pair{1, 2}) {
+		t.Error("wrong pair", p, err)
+	}
+
+	for _, tc := range []struct {
+		dst, src Type
+		ok       bool
+	}{
+		{TypeEval[[]float64](), TypeEval[[]implicitCelsius](), true},
+		{TypeEval[struct{ A, B int }](), TypeEval[point](), true},
+		{TypeEval[struct{ A int }](), TypeEval[point](), false},
+		{TypeEval[implicitLink](), TypeEval[implicitNode](), true},
+		{TypeEval[func(float64) int](), TypeEval[func(implicitCelsius) int](), true},
+		{TypeEval[func(...float64)](), TypeEval[func([]float64)](), false},
+		{TypeEval[chan float64](), TypeEval[chan implicitCelsius](), true},
+		{TypeEval[<-chan float64](), TypeEval[chan implicitCelsius](), false},
+		{TypeEval[map[string]float64](), TypeEval[map[string]implicitCelsius](), true},
+		{TypeEval[[2]float64](), TypeEval[[3]implicitCelsius](), false},
+		{TypeEval[int64](), TypeEval[int](), false},
+		{TypeEval[any](), TypeEval[error](), false},
+	} {
+		if _, ok := ImplicitMapping(MappingType(tc.dst, tc.src)); ok != tc.ok && (ok || !safeMode) {
+			t.Errorf("%v into %v: expected %v", tc.src, tc.dst, tc.ok)
+		}
+	}
+	var i64 int64
+	if err := m.Map(&i64, 1); !errors.Is(err, ErrInvalid) {
+		t.Error("different kinds should not be covered", err)
+	}
+
+	if safeMode {
+		t.Skip("reinterpretation needs unsafe")
+	}
+
+	temps := []implicitCelsius{1.5, 2}
+	var fs []float64
+	if err := m.Map(&fs, temps); err != nil || len(fs) != 2 || fs[0] != 1.5 {
+		t.Fatal("wrong slice", fs, err)
+	}
+	fs[1] = 3
+	if temps[1] != 3 {
+		t.Error("slice should share its contents")
+	}
+
+	n := &implicitNode{1, &implicitNode{2, nil}}
+	var l *implicitLink
+	if err := m.Map(&l, n); err != nil || l.Value != 1 || l.Link.Value != 2 || l.Link.Link != nil {
+		t.Error("wrong list", l, err)
+	}
+
+	var fn func(float64) float64
+	if err := m.Map(&fn, func(c implicitCelsius) implicitCelsius { return c * 2 }); err != nil || fn(1.5) != 3 {
+		t.Error("wrong function", err)
+	}
+
+	ch := make(chan implicitCelsius, 1)
+	var fch chan float64
+	if err := m.Map(&fch, ch); err != nil {
+		t.Fatal(err)
+	}
+	fch <- 4
+	if c := <-ch; c != 4 {
+		t.Error("channel should be shared", c)
+	}
+}
@@ -0,0 +1,286 @@
+package conv
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	. "reflect"
+	"strconv"
+)
+
+// A Layout describes the placement of the fields of a struct type in raw memory, such as C structs shared through cgo or memory mapped binary formats.
+// Fields are flattened down to their bool and numeric leaves, with nested structs and arrays expanded in place.
+//
+// Layouts are usually computed by CLayout or PackedLayout, but may then be adjusted to a described layout, by setting field offsets and the total size directly; Check should be called after doing so.
+// Unexported and blank fields take up space, but are neither read nor written, so they can stand for padding.
+// Memory gives access to native memory, unless built in safe mode.
+type Layout struct {
+	Type   Type
+	Size   uintptr
+	Order  binary.ByteOrder
+	Fields []LayoutField
+}
+
+// A LayoutField is a leaf value of a Layout.
+type LayoutField struct {
+	Path   string // Go path from the struct root, like "Header.Len" or "Data[2]"
+	Offset uintptr
+	Kind   Kind
+
+	get func(Value) Value
+}
+
+// Size returns the field width, in bytes.
+func (x LayoutField) Size() uintptr {
+	return layoutKindSize(x.Kind)
+}
+
+// CLayout computes the layout that a C compiler would use for struct type "t": fields in declaration order, each aligned to its own size, and the total size rounded to the largest alignment.
+// Field types must be bools or fixed size numbers (not int, uint or uintptr, whose widths are platform dependent, nor complex numbers), or arrays and structs of them.
+func CLayout(t Type, order binary.ByteOrder) (*Layout, error) {
+	return newLayout(t, order, false)
+}
+
+// PackedLayout computes a layout without padding: fields follow each other in declaration order, and the total size is the sum of their sizes.
+// Field types are restricted like for CLayout.
+func PackedLayout(t Type, order binary.ByteOrder) (*Layout, error) {
+	return newLayout(t, order, true)
+}
+
+func newLayout(t Type, order binary.ByteOrder, packed bool) (*Layout, error) {
+	if t.Kind() != Struct {
+		return nil, fmt.Errorf("layout of %s: %w", describeType(t), ErrUnsupportedKind)
+	}
+	size, _, err := layoutMeasure(t, "", packed)
+	if err != nil {
+		return nil, err
+	}
+	o := &Layout{
+		Type:  t,
+		Size:  size,
+		Order: order,
+	}
+	o.place(t, "", func(v Value) Value { return v }, 0, packed, true)
+	return o, nil
+}
+
+// layoutMeasure returns the size and alignment of type "t", placed at "path".
+func layoutMeasure(t Type, path string, packed bool) (uintptr, uintptr, error) {
+	switch k := t.Kind(); k {
+	case Array:
+		size, align, err := layoutMeasure(t.Elem(), path+"[]", packed)
+		return size * uintptr(t.Len()), align, err
+	case Struct:
+		var off, maxAlign uintptr = 0, 1
+		for i, n := 0, t.NumField(); i < n; i++ {
+			f := t.Field(i)
+			size, align, err := layoutMeasure(f.Type, layoutPath(path, f.Name), packed)
+			if err != nil {
+				return 0, 0, err
+			}
+			off = layoutAlign(off, align, packed)
+			off += size
+			if align > maxAlign {
+				maxAlign = align
+			}
+		}
+		return layoutAlign(off, maxAlign, packed), maxAlign, nil
+	default:
+		size := layoutKindSize(k)
+		if size == 0 {
+			return 0, 0, fmt.Errorf("layout field %s: %s: %w", path, describeType(t), ErrUnsupportedKind)
+		}
+		return size, size, nil
+	}
+}
+
+// place adds the leaves of type "t" at offset "off". Leaves are only added if "access" is true.
+func (x *Layout) place(t Type, path string, get func(Value) Value, off uintptr, packed, access bool) {
+	switch t.Kind() {
+	case Array:
+		size, _, _ := layoutMeasure(t.Elem(), path, packed)
+		for i, n := 0, t.Len(); i < n; i++ {
+			i := i
+			x.place(t.Elem(), path+"["+strconv.Itoa(i)+"]", func(v Value) Value {
+				return get(v).Index(i)
+			}, off+uintptr(i)*size, packed, access)
+		}
+	case Struct:
+		for i, n := 0, t.NumField(); i < n; i++ {
+			i := i
+			f := t.Field(i)
+			size, align, _ := layoutMeasure(f.Type, "", packed)
+			off = layoutAlign(off, align, packed)
+			x.place(f.Type, layoutPath(path, f.Name), func(v Value) Value {
+				return get(v).Field(i)
+			}, off, packed, access && f.IsExported() && f.Name != "_")
+			off += size
+		}
+	default:
+		if access {
+			x.Fields = append(x.Fields, LayoutField{
+				Path:   path,
+				Offset: off,
+				Kind:   t.Kind(),
+				get:    get,
+			})
+		}
+	}
+}
+
+func layoutPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+func layoutAlign(off, align uintptr, packed bool) uintptr {
+	if packed {
+		return off
+	}
+	return (off + align - 1) / align * align
+}
+
+// layoutKindSize returns the width of fixed size leaf kinds, or 0 for other kinds.
+func layoutKindSize(k Kind) uintptr {
+	switch k {
+	case Bool, Int8, Uint8:
+		return 1
+	case Int16, Uint16:
+		return 2
+	case Int32, Uint32, Float32:
+		return 4
+	case Int64, Uint64, Float64:
+		return 8
+	}
+	return 0
+}
+
+// Field returns the field at "path".
+func (x *Layout) Field(path string) (*LayoutField, bool) {
+	for i := range x.Fields {
+		if x.Fields[i].Path == path {
+			return &x.Fields[i], true
+		}
+	}
+	return nil, false
+}
+
+// Check verifies that all fields fit within Size, without overlapping.
+func (x *Layout) Check() error {
+	for i, f := range x.Fields {
+		end := f.Offset + f.Size()
+		if end > x.Size {
+			return fmt.Errorf("layout field %s ends at %d, past size %d: %w", f.Path, end, x.Size, ErrInvalid)
+		}
+		for _, g := range x.Fields[:i] {
+			if f.Offset < g.Offset+g.Size() && g.Offset < end {
+				return fmt.Errorf("layout fields %s and %s overlap: %w", g.Path, f.Path, ErrInvalid)
+			}
+		}
+	}
+	return nil
+}
+
+// Read sets struct "dst" from memory "b", which must hold at least Size bytes.
+func (x *Layout) Read(b []byte, dst Value) error {
+	if uintptr(len(b)) < x.Size {
+		return fmt.Errorf("%d bytes into %d byte layout: %w", len(b), x.Size, ErrInvalid)
+	}
+	for _, f := range x.Fields {
+		v := f.get(dst)
+		p := b[f.Offset:]
+		var u uint64
+		switch f.Size() {
+		case 1:
+			u = uint64(p[0])
+		case 2:
+			u = uint64(x.Order.Uint16(p))
+		case 4:
+			u = uint64(x.Order.Uint32(p))
+		case 8:
+			u = x.Order.Uint64(p)
+		}
+
+		switch k := f.Kind; {
+		case k == Bool:
+			v.SetBool(u != 0)
+		case k == Float32:
+			v.SetFloat(float64(math.Float32frombits(uint32(u))))
+		case k == Float64:
+			v.SetFloat(math.Float64frombits(u))
+		case k >= Int8 && k <= Int64:
+			// sign extend
+			shift := 64 - 8*f.Size()
+			v.SetInt(int64(u<<shift) >> shift)
+		default:
+			v.SetUint(u)
+		}
+	}
+	return nil
+}
+
+// Write stores struct "src" into memory "b", which must hold at least Size bytes.
+// Bytes not covered by fields are left untouched.
+func (x *Layout) Write(b []byte, src Value) error {
+	if uintptr(len(b)) < x.Size {
+		return fmt.Errorf("%d byte layout into %d bytes: %w", x.Size, len(b), ErrInvalid)
+	}
+	for _, f := range x.Fields {
+		v := f.get(src)
+		var u uint64
+		switch k := f.Kind; {
+		case k == Bool:
+			if v.Bool() {
+				u = 1
+			}
+		case k == Float32:
+			u = uint64(math.Float32bits(float32(v.Float())))
+		case k == Float64:
+			u = math.Float64bits(v.Float())
+		case k >= Int8 && k <= Int64:
+			u = uint64(v.Int())
+		default:
+			u = v.Uint()
+		}
+
+		p := b[f.Offset:]
+		switch f.Size() {
+		case 1:
+			p[0] = byte(u)
+		case 2:
+			x.Order.PutUint16(p, uint16(u))
+		case 4:
+			x.Order.PutUint32(p, uint32(u))
+		case 8:
+			x.Order.PutUint64(p, u)
+		}
+	}
+	return nil
+}
+
+// Converter is a Builder of []byte Converters for the Layout type.
+func (x *Layout) Converter(t Type) (Converter[[]byte], bool) {
+	if t != x.Type {
+		return nil, false
+	}
+	return func(v Value) ([]byte, error) {
+		o := make([]byte, x.Size)
+		return o, x.Write(o, v)
+	}, true
+}
+
+// Inverter is a Builder of []byte Inverters for the Layout type.
+func (x *Layout) Inverter(t Type) (Inverter[[]byte], bool) {
+	if t != x.Type {
+		return nil, false
+	}
+	return func(b []byte) (Value, error) {
+		o := New(t).Elem()
+		if err := x.Read(b, o); err != nil {
+			return Value{}, err
+		}
+		return o, nil
+	}, true
+}
@@ -0,0 +1,69 @@
+package conv
+
+import (
+	"encoding/binary"
+	"errors"
+	"testing"
+)
+
+func TestCLayout(t *testing.T) {
+	type point struct {
+		X, Y int16
+	}
+	type header struct {
+		Flag  bool
+		Count uint32
+		_     [3]byte
+		Pts   [2]point
+		Scale float64
+		Tag   int8
+	}
+
+	th := TypeEval[header]()
+	l, err := CLayout(th, binary.LittleEndian)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Go uses C alignment rules for fixed size kinds
+	offset := func(name string) uintptr {
+		f, _ := th.FieldByName(name)
+		return f.Offset
+	}
+	if l.Size != th.Size() {
+		t.Error("wrong size", l.Size)
+	}
+	for path, off := range map[string]uintptr{
+		"Count":    offset("Count"),
+		"Pts[1].Y": offset("Pts") + 6,
+		"Scale":    offset("Scale"),
+		"Tag":      offset("Tag"),
+	} {
+		if f, ok := l.Field(path); !ok || f.Offset != off {
+			t.Errorf("%s: expected offset %d, got %v", path, off, f)
+		}
+	}
+	if len(l.Fields) != 8 {
+		t.Error("wrong field count", len(l.Fields))
+	}
+
+	// described layout, with swapped fields
+	l, _ = CLayout(TypeEval[point](), binary.BigEndian)
+	x, _ := l.Field("X")
+	y, _ := l.Field("Y")
+	x.Offset, y.Offset = 2, 0
+	if err := l.Check(); err != nil {
+		t.Fatal(err)
+	}
+	b, err := NewConversion(l.Converter).Call(point{1, 2})
+	if err != nil || string(b) != "\x00\x02\x00\x01" {
+		t.Errorf("wrong bytes %x %v", b, err)
+	}
+	y.Offset = 1
+	if err := l.Check(); !errors.Is(err, ErrInvalid) {
+		t.Error("expected overlap, got", err)
+	}
+
+	if _, err := CLayout(TypeEval[struct{ N int }](), binary.LittleEndian); !errors.Is(err, ErrUnsupportedKind) {
+		t.Error("expected unsupported int, got", err)
+	}
+}
@@ -0,0 +1,202 @@
+package conv
+
+import (
+	"fmt"
+	. "reflect"
+	"strconv"
+	"strings"
+)
+
+// A Locale is a set of Builders formatting and parsing numbers according to local conventions, for text meant for humans.
+// Integer digits are grouped by GroupSize (3 if zero) using Group, unless it is 0. Floats use Decimal as their decimal separator, with the shortest representation that parses back exactly, or Fraction digits if positive.
+//
+// Parsing accepts numbers with or without group separators; when Group is a space character, plain spaces are accepted as well.
+// Exponents are neither produced nor accepted.
+type Locale struct {
+	Decimal   rune
+	Group     rune
+	GroupSize int
+	Fraction  int
+}
+
+// locales holds the conventions of common languages and regions. Regions override their language.
+var locales = map[string]Locale{
+	"en":    {Decimal: '.', Group: ','},
+	"ja":    {Decimal: '.', Group: ','},
+	"zh":    {Decimal: '.', Group: ','},
+	"ko":    {Decimal: '.', Group: ','},
+	"de":    {Decimal: ',', Group: '.'},
+	"de-CH": {Decimal: '.', Group: '\u2019'},
+	"es":    {Decimal: ',', Group: '.'},
+	"it":    {Decimal: ',', Group: '.'},
+	"nl":    {Decimal: ',', Group: '.'},
+	"pt":    {Decimal: ',', Group: '.'},
+	"tr":    {Decimal: ',', Group: '.'},
+	"id":    {Decimal: ',', Group: '.'},
+	"fr":    {Decimal: ',', Group: '\u202f'},
+	"fr-CH": {Decimal: ',', Group: '\u202f'},
+	"ru":    {Decimal: ',', Group: '\u00a0'},
+	"pl":    {Decimal: ',', Group: '\u00a0'},
+	"cs":    {Decimal: ',', Group: '\u00a0'},
+	"sv":    {Decimal: ',', Group: '\u00a0'},
+	"fi":    {Decimal: ',', Group: '\u00a0'},
+	"nb":    {Decimal: ',', Group: '\u00a0'},
+	"uk":    {Decimal: ',', Group: '\u00a0'},
+}
+
+// NewLocale returns the conventions of language tag "tag", such as "de" or "de-CH", from a built-in table of common locales.
+// Unknown regions fall back to their language. Returns ErrInvalid for unknown languages.
+func NewLocale(tag string) (*Locale, error) {
+	tag = strings.ReplaceAll(tag, "_", "-")
+	lang, region, _ := strings.Cut(tag, "-")
+	lang = strings.ToLower(lang)
+	if region != "" {
+		if o, ok := locales[lang+"-"+strings.ToUpper(region)]; ok {
+			return &o, nil
+		}
+	}
+	if o, ok := locales[lang]; ok {
+		return &o, nil
+	}
+	return nil, fmt.Errorf("locale %q: %w", tag, ErrInvalid)
+}
+
+// Converter is a Builder of string Converters for integer and float kinds.
+func (x *Locale) Converter(t Type) (Converter[string], bool) {
+	switch k := t.Kind(); k {
+	case Int, Int8, Int16, Int32, Int64:
+		return func(v Value) (string, error) {
+			return x.format(strconv.FormatInt(v.Int(), 10)), nil
+		}, true
+	case Uint, Uint8, Uint16, Uint32, Uint64, Uintptr:
+		return func(v Value) (string, error) {
+			return x.format(strconv.FormatUint(v.Uint(), 10)), nil
+		}, true
+	case Float32, Float64:
+		size := t.Bits()
+		prec := -1
+		if x.Fraction > 0 {
+			prec = x.Fraction
+		}
+		return func(v Value) (string, error) {
+			return x.format(strconv.FormatFloat(v.Float(), 'f', prec, size)), nil
+		}, true
+	}
+	return nil, false
+}
+
+// Inverter is a Builder of string Inverters for integer and float kinds.
+// Values that don't fit the destination kind fail with a *strconv.NumError.
+func (x *Locale) Inverter(t Type) (Inverter[string], bool) {
+	switch k := t.Kind(); k {
+	case Int, Int8, Int16, Int32, Int64:
+		size := t.Bits()
+		return func(s string) (Value, error) {
+			s, err := x.normalize(s, false)
+			if err != nil {
+				return Value{}, err
+			}
+			n, err := strconv.ParseInt(s, 10, size)
+			if err != nil {
+				return Value{}, err
+			}
+			o := New(t).Elem()
+			o.SetInt(n)
+			return o, nil
+		}, true
+	case Uint, Uint8, Uint16, Uint32, Uint64, Uintptr:
+		size := t.Bits()
+		return func(s string) (Value, error) {
+			s, err := x.normalize(s, false)
+			if err != nil {
+				return Value{}, err
+			}
+			n, err := strconv.ParseUint(s, 10, size)
+			if err != nil {
+				return Value{}, err
+			}
+			o := New(t).Elem()
+			o.SetUint(n)
+			return o, nil
+		}, true
+	case Float32, Float64:
+		size := t.Bits()
+		return func(s string) (Value, error) {
+			s, err := x.normalize(s, true)
+			if err != nil {
+				return Value{}, err
+			}
+			f, err := strconv.ParseFloat(s, size)
+			if err != nil {
+				return Value{}, err
+			}
+			o := New(t).Elem()
+			o.SetFloat(f)
+			return o, nil
+		}, true
+	}
+	return nil, false
+}
+
+func (x *Locale) decimal() rune {
+	if x.Decimal == 0 {
+		return '.'
+	}
+	return x.Decimal
+}
+
+// format localizes a number formatted by strconv, without exponent.
+func (x *Locale) format(s string) string {
+	sign := ""
+	if s != "" && (s[0] == '-' || s[0] == '+') {
+		sign, s = s[:1], s[1:]
+	}
+	if s == "" || s[0] < '0' || s[0] > '9' {
+		// NaN, Inf
+		return sign + s
+	}
+	integer, fraction, ok := strings.Cut(s, ".")
+
+	var b strings.Builder
+	b.WriteString(sign)
+	size := x.GroupSize
+	if size <= 0 {
+		size = 3
+	}
+	for i, c := range integer {
+		if x.Group != 0 && i > 0 && (len(integer)-i)%size == 0 {
+			b.WriteRune(x.Group)
+		}
+		b.WriteRune(c)
+	}
+	if ok {
+		b.WriteRune(x.decimal())
+		b.WriteString(fraction)
+	}
+	return b.String()
+}
+
+// normalize converts a localized number into strconv syntax.
+func (x *Locale) normalize(s string, float bool) (string, error) {
+	var b strings.Builder
+	seenDecimal := false
+	for _, c := range s {
+		switch {
+		case c == x.decimal() && float && !seenDecimal:
+			b.WriteByte('.')
+			seenDecimal = true
+		case x.Group != 0 && !seenDecimal && (c == x.Group || (c == ' ' && isSpaceGroup(x.Group))):
+		case c == 'e' || c == 'E' || c == '_' || c == '.' || c == ',':
+			// exponents, Go digit separators, and foreign separators
+			return "", fmt.Errorf("locale number %q: %w", s, ErrInvalid)
+		default:
+			b.WriteRune(c)
+		}
+	}
+	return b.String(), nil
+}
+
+// isSpaceGroup returns true for the space characters used as group separators: space, no-break space and narrow no-break space.
+func isSpaceGroup(c rune) bool {
+	return c == ' ' || c == '\u00a0' || c == '\u202f'
+}
@@ -0,0 +1,57 @@
+package conv
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestLocale(t *testing.T) {
+	de, err := NewLocale("de_AT")
+	if err != nil {
+		t.Fatal(err)
+	}
+	fr, _ := NewLocale("fr-FR")
+	ch, _ := NewLocale("de-CH")
+
+	for _, tc := range []struct {
+		l   *Locale
+		v   any
+		exp string
+	}{
+		{de, 1234567, "1.234.567"},
+		{de, -1234.5, "-1.234,5"},
+		{de, uint8(255), "255"},
+		{fr, 1234.25, "1\u202f234,25"},
+		{ch, 1e6, "1\u2019000\u2019000"},
+		{&Locale{Decimal: ',', Fraction: 2}, 1234.5, "1234,50"},
+		{de, math.Inf(-1), "-Inf"},
+	} {
+		s, err := NewConversion(tc.l.Converter).Call(tc.v)
+		if err != nil || s != tc.exp {
+			t.Errorf("%v: expected %q, got %q", tc.v, tc.exp, s)
+		}
+	}
+
+	inv := NewInversion(de.Inverter)
+	if f, err := As[float64](inv, "-1.234,5"); err != nil || f != -1234.5 {
+		t.Error("wrong float", f, err)
+	}
+	if n, err := As[int](inv, "1.234.567"); err != nil || n != 1234567 {
+		t.Error("wrong int", n, err)
+	}
+	if _, err := As[int](inv, "12,5"); !errors.Is(err, ErrInvalid) {
+		t.Error("expected invalid integer, got", err)
+	}
+	if _, err := As[int8](inv, "1.000"); err == nil {
+		t.Error("expected range error")
+	}
+	// plain spaces stand for no-break spaces
+	if f, err := As[float32](NewInversion(fr.Inverter), "12 345,5"); err != nil || f != 12345.5 {
+		t.Error("wrong french float", f, err)
+	}
+
+	if _, err := NewLocale("xx"); !errors.Is(err, ErrInvalid) {
+		t.Error("expected unknown locale, got", err)
+	}
+}
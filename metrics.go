@@ -0,0 +1,39 @@
+package conv
+
+import (
+	. "reflect"
+	"time"
+)
+
+// Metrics receives measurements from Libraries and Conversions, for forwarding to a metrics system, such as Prometheus or expvar, without this package depending on one.
+// Implementations must be safe for concurrent use, and fast, as they are called inline.
+type Metrics interface {
+	// Build records the building of a function for type "t", taking "d", and whether the Builder covered the type.
+	Build(t Type, d time.Duration, ok bool)
+
+	// Convert records a Conversion.Call with a value of type "t", taking "d", along with its error.
+	// Not called for nil values, which have no type.
+	Convert(t Type, d time.Duration, err error)
+}
+
+// SetMetrics makes the Library report to "m", or to nothing if nil.
+// Must be called before the Library is used.
+func (x *Library[T]) SetMetrics(m Metrics) {
+	x.metrics = m
+}
+
+// SetMetrics is the same as Library.SetMetrics. Conversions also report each Call.
+func (x *Conversion[T]) SetMetrics(m Metrics) {
+	(*Library[Converter[T]])(x).SetMetrics(m)
+}
+
+// build calls the Builder, reporting to the Metrics, if any.
+func (x *Library[T]) build(t Type) (T, bool) {
+	if x.metrics == nil {
+		return x.b(t)
+	}
+	start := time.Now()
+	o, ok := x.b(t)
+	x.metrics.Build(t, time.Since(start), ok)
+	return o, ok
+}
@@ -0,0 +1,53 @@
+package conv
+
+import (
+	. "reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+type testMetrics struct {
+	mux      sync.Mutex
+	builds   map[Type]bool
+	converts map[Type]int
+	failures int
+}
+
+func (x *testMetrics) Build(t Type, d time.Duration, ok bool) {
+	x.mux.Lock()
+	defer x.mux.Unlock()
+	x.builds[t] = ok
+}
+
+func (x *testMetrics) Convert(t Type, d time.Duration, err error) {
+	x.mux.Lock()
+	defer x.mux.Unlock()
+	x.converts[t]++
+	if err != nil {
+		x.failures++
+	}
+}
+
+func TestMetrics(t *testing.T) {
+	m := &testMetrics{
+		builds:   make(map[Type]bool),
+		converts: make(map[Type]int),
+	}
+	c := NewConversion(StrconvConverter)
+	c.SetMetrics(m)
+
+	c.Call(1)
+	c.Call(2)
+	c.Call([]int{})
+
+	if len(m.builds) != 2 || !m.builds[TypeEval[int]()] || m.builds[TypeEval[[]int]()] {
+		t.Error("wrong builds", m.builds)
+	}
+	if m.converts[TypeEval[int]()] != 2 || m.converts[TypeEval[[]int]()] != 1 || len(m.converts) != 2 {
+		t.Error("wrong conversions", m.converts)
+	}
+	if m.failures != 1 {
+		t.Error("wrong failure count", m.failures)
+	}
+}
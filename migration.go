@@ -0,0 +1,129 @@
+package conv
+
+import (
+	"errors"
+	. "reflect"
+	"sync"
+)
+
+var ErrMigrationLoop = errors.New("migration steps form a loop")
+
+// A Migration upgrades older versions of an evolving type to T, the latest version, by chaining registered steps (V1→V2→V3→T).
+// Versions are identified by their Fingerprint, so inputs only need to structurally match a registered version, not be of the exact same Go type.
+//
+// Its Build method is a Builder, meant to be wrapped by a Conversion[T].
+type Migration[T any] struct {
+	steps map[uint64]migrationStep // keyed by source fingerprint
+	mux   sync.RWMutex
+}
+
+type migrationStep struct {
+	src Type
+	dst Type
+	fn  Converter[Value]
+}
+
+func NewMigration[T any]() *Migration[T] {
+	return &Migration[T]{
+		steps: make(map[uint64]migrationStep),
+	}
+}
+
+// MigrationStep registers "fn" as the upgrade path from version S to version D.
+// Overwrites any previous step registered for S.
+func MigrationStep[S, D, T any](x *Migration[T], fn func(S) (D, error)) {
+	src := TypeEval[S]()
+	x.mux.Lock()
+	x.steps[Fingerprint(src)] = migrationStep{
+		src: src,
+		dst: TypeEval[D](),
+		fn: func(v Value) (Value, error) {
+			o, err := fn(v.Interface().(S))
+			return ValueOf(&o).Elem(), err
+		},
+	}
+	x.mux.Unlock()
+}
+
+// Build composes the step chain that upgrades "t" to T.
+// Returns false if "t" doesn't match any registered version, or the chain doesn't end in T.
+func (x *Migration[T]) Build(t Type) (Converter[T], bool) {
+	dst := TypeEval[T]()
+	fpDst := Fingerprint(dst)
+
+	x.mux.RLock()
+	defer x.mux.RUnlock()
+
+	var chain []migrationStep
+	visited := make(map[uint64]bool)
+	for fp := Fingerprint(t); fp != fpDst; {
+		if visited[fp] {
+			// loops are a registration error, but Builders can only decline
+			return nil, false
+		}
+		visited[fp] = true
+
+		step, ok := x.steps[fp]
+		if !ok {
+			return nil, false
+		}
+		chain = append(chain, step)
+		fp = Fingerprint(step.dst)
+	}
+
+	return func(v Value) (T, error) {
+		var err error
+		for _, step := range chain {
+			if v, err = migrationCast(v, step.src); err != nil {
+				var o T
+				return o, err
+			}
+			if v, err = step.fn(v); err != nil {
+				var o T
+				return o, err
+			}
+		}
+		if v, err = migrationCast(v, dst); err != nil {
+			var o T
+			return o, err
+		}
+		return v.Interface().(T), nil
+	}, true
+}
+
+// Check verifies that the registered steps don't form loops on the way to T.
+// Chains end at T, so steps out of T are never taken, and can't close a loop.
+func (x *Migration[T]) Check() error {
+	fpDst := Fingerprint(TypeEval[T]())
+
+	x.mux.RLock()
+	defer x.mux.RUnlock()
+
+	for fp := range x.steps {
+		visited := make(map[uint64]bool)
+		for fp != fpDst {
+			if visited[fp] {
+				return ErrMigrationLoop
+			}
+			visited[fp] = true
+
+			step, ok := x.steps[fp]
+			if !ok {
+				break
+			}
+			fp = Fingerprint(step.dst)
+		}
+	}
+	return nil
+}
+
+// migrationCast converts "v" into the structurally identical type "t".
+func migrationCast(v Value, t Type) (Value, error) {
+	if v.Type() == t {
+		return v, nil
+	}
+	if !v.Type().ConvertibleTo(t) {
+		return Value{}, ErrInvalid
+	}
+	return v.Convert(t), nil
+}
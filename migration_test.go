@@ -0,0 +1,73 @@
+package conv
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+)
+
+func TestMigration(t *testing.T) {
+	type v1 struct {
+		Name string
+		Age  string
+	}
+	type v2 struct {
+		Name string
+		Age  int
+	}
+	type v3 struct {
+		First string
+		Age   int
+	}
+
+	m := NewMigration[v3]()
+	MigrationStep(m, func(v v1) (v2, error) {
+		age, err := strconv.Atoi(v.Age)
+		return v2{v.Name, age}, err
+	})
+	MigrationStep(m, func(v v2) (v3, error) {
+		return v3{v.Name, v.Age}, nil
+	})
+	if err := m.Check(); err != nil {
+		t.Fatal(err)
+	}
+	c := NewConversion(m.Build)
+
+	// structurally identical to v1
+	type legacy struct {
+		Name string
+		Age  string
+	}
+
+	for _, v := range []any{v1{"a", "3"}, legacy{"a", "3"}, v2{"a", 3}, v3{"a", 3}} {
+		o, err := c.Call(v)
+		if err != nil || o != (v3{"a", 3}) {
+			t.Errorf("%T failed: %v %v", v, o, err)
+		}
+	}
+
+	if _, err := c.Call(v1{"a", "x"}); err == nil {
+		t.Error("expected step error")
+	}
+	if _, err := c.Call(0); !errors.Is(err, ErrInvalid) {
+		t.Error("expected invalid conversion")
+	}
+
+	// chains stop at the latest version
+	MigrationStep(m, func(v v3) (v1, error) {
+		return v1{v.First, strconv.Itoa(v.Age)}, nil
+	})
+	if err := m.Check(); err != nil {
+		t.Error("steps out of the latest version are not loops", err)
+	}
+	if o, err := c.Call(v1{"a", "3"}); err != nil || o != (v3{"a", 3}) {
+		t.Error("wrong migration", o, err)
+	}
+
+	MigrationStep(m, func(v v2) (v1, error) {
+		return v1{v.Name, strconv.Itoa(v.Age)}, nil
+	})
+	if m.Check() != ErrMigrationLoop {
+		t.Error("expected loop")
+	}
+}
@@ -0,0 +1,367 @@
+package conv
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	. "reflect"
+
+	"github.com/blitz-frost/conv/wrap"
+)
+
+// MessagePack formats
+const (
+	msgpackFixMap   byte = 0x80
+	msgpackFixArray byte = 0x90
+	msgpackFixStr   byte = 0xa0
+	msgpackNil      byte = 0xc0
+	msgpackFalse    byte = 0xc2
+	msgpackTrue     byte = 0xc3
+	msgpackBin8     byte = 0xc4
+	msgpackBin16    byte = 0xc5
+	msgpackBin32    byte = 0xc6
+	msgpackFloat32  byte = 0xca
+	msgpackFloat64  byte = 0xcb
+	msgpackUint8    byte = 0xcc
+	msgpackUint16   byte = 0xcd
+	msgpackUint32   byte = 0xce
+	msgpackUint64   byte = 0xcf
+	msgpackInt8     byte = 0xd0
+	msgpackInt16    byte = 0xd1
+	msgpackInt32    byte = 0xd2
+	msgpackInt64    byte = 0xd3
+	msgpackStr8     byte = 0xd9
+	msgpackStr16    byte = 0xda
+	msgpackStr32    byte = 0xdb
+	msgpackArray16  byte = 0xdc
+	msgpackArray32  byte = 0xdd
+	msgpackMap16    byte = 0xde
+	msgpackMap32    byte = 0xdf
+)
+
+// MsgpackConverter is a Builder of Converters that encode values as MessagePack objects.
+// Values are traversed through the wrap package, with the same layout as CBORConverter: structs become maps keyed by field name, and nil pointers, slices, maps and interfaces become nil.
+// Integers use their shortest format. Floats are encoded as float32 whenever that is lossless.
+//
+// Complex numbers, channels, functions and unsafe pointers are not supported. Neither are cyclic values, which fail once nested too deep.
+func MsgpackConverter(t Type) (Converter[[]byte], bool) {
+	if !supports(t, cborKind) {
+		return nil, false
+	}
+	return func(v Value) ([]byte, error) {
+		return msgpackAppend(nil, wrap.Of(v), 0)
+	}, true
+}
+
+// MsgpackInverter is a Builder of Inverters that decode single MessagePack objects.
+// Numbers decode into any numeric kind they fit exactly, and fail with ErrOverflow otherwise. Nil decodes as zero values.
+// Struct fields are matched by name; unknown keys are ignored. Extension types are not supported.
+func MsgpackInverter(t Type) (Inverter[[]byte], bool) {
+	if !supports(t, cborKind) {
+		return nil, false
+	}
+	return func(b []byte) (Value, error) {
+		d := msgpackDecoder{b: b}
+		w, err := d.object(0)
+		if err != nil {
+			return Value{}, err
+		}
+		if d.i != len(b) {
+			return Value{}, fmt.Errorf("msgpack: %d trailing bytes: %w", len(b)-d.i, ErrInvalid)
+		}
+		o := New(t).Elem()
+		if err := wireAssign(o, w); err != nil {
+			return Value{}, err
+		}
+		return o, nil
+	}, true
+}
+
+// msgpackLen appends the header of a string, binary, array or map of length "n".
+// "fix" is the fixed format for short lengths, if any; "f8" the 8 bit format, if any; "f16" the 16 bit format, followed by the 32 bit one.
+func msgpackLen(b []byte, n int, fix, fixMax, f8, f16 byte) ([]byte, error) {
+	switch {
+	case fix != 0 && n <= int(fixMax):
+		return append(b, fix|byte(n)), nil
+	case f8 != 0 && n <= math.MaxUint8:
+		return append(b, f8, byte(n)), nil
+	case n <= math.MaxUint16:
+		return binary.BigEndian.AppendUint16(append(b, f16), uint16(n)), nil
+	case uint64(n) <= math.MaxUint32:
+		return binary.BigEndian.AppendUint32(append(b, f16+1), uint32(n)), nil
+	}
+	return nil, fmt.Errorf("msgpack: length %d: %w", n, ErrOverflow)
+}
+
+func msgpackUint(b []byte, n uint64) []byte {
+	switch {
+	case n <= 0x7f:
+		return append(b, byte(n))
+	case n <= math.MaxUint8:
+		return append(b, msgpackUint8, byte(n))
+	case n <= math.MaxUint16:
+		return binary.BigEndian.AppendUint16(append(b, msgpackUint16), uint16(n))
+	case n <= math.MaxUint32:
+		return binary.BigEndian.AppendUint32(append(b, msgpackUint32), uint32(n))
+	}
+	return binary.BigEndian.AppendUint64(append(b, msgpackUint64), n)
+}
+
+func msgpackInt(b []byte, n int64) []byte {
+	switch {
+	case n >= 0:
+		return msgpackUint(b, uint64(n))
+	case n >= -32:
+		return append(b, byte(n))
+	case n >= math.MinInt8:
+		return append(b, msgpackInt8, byte(n))
+	case n >= math.MinInt16:
+		return binary.BigEndian.AppendUint16(append(b, msgpackInt16), uint16(n))
+	case n >= math.MinInt32:
+		return binary.BigEndian.AppendUint32(append(b, msgpackInt32), uint32(n))
+	}
+	return binary.BigEndian.AppendUint64(append(b, msgpackInt64), uint64(n))
+}
+
+func msgpackAppend(b []byte, w any, depth int) ([]byte, error) {
+	if depth > wireMaxDepth {
+		return nil, ErrCycle
+	}
+	depth++
+
+	var err error
+	switch x := w.(type) {
+	case nil:
+		return append(b, msgpackNil), nil
+	case bool:
+		if x {
+			return append(b, msgpackTrue), nil
+		}
+		return append(b, msgpackFalse), nil
+	case string:
+		if b, err = msgpackLen(b, len(x), msgpackFixStr, 31, msgpackStr8, msgpackStr16); err != nil {
+			return nil, err
+		}
+		return append(b, x...), nil
+	case wrap.Number:
+		if n, ok := x.Int64(); ok {
+			return msgpackInt(b, n), nil
+		}
+		if n, ok := x.Uint64(); ok {
+			return msgpackUint(b, n), nil
+		}
+		if n, ok := x.Float64(); ok {
+			if f := float32(n); float64(f) == n || math.IsNaN(n) {
+				return binary.BigEndian.AppendUint32(append(b, msgpackFloat32), math.Float32bits(f)), nil
+			}
+			return binary.BigEndian.AppendUint64(append(b, msgpackFloat64), math.Float64bits(n)), nil
+		}
+	case wrap.Pointer:
+		if x.IsNil() {
+			return append(b, msgpackNil), nil
+		}
+		return msgpackAppend(b, x.Elem(), depth)
+	case wrap.Slice:
+		if x.IsNil() {
+			return append(b, msgpackNil), nil
+		}
+		n := x.Len()
+		if x.Type().Elem().Kind() == Uint8 {
+			if b, err = msgpackLen(b, n, 0, 0, msgpackBin8, msgpackBin16); err != nil {
+				return nil, err
+			}
+			for i := 0; i < n; i++ {
+				e, _ := x.Index(i).(wrap.Number).Uint64()
+				b = append(b, byte(e))
+			}
+			return b, nil
+		}
+		if b, err = msgpackLen(b, n, msgpackFixArray, 15, 0, msgpackArray16); err != nil {
+			return nil, err
+		}
+		for i := 0; i < n; i++ {
+			if b, err = msgpackAppend(b, x.Index(i), depth); err != nil {
+				return nil, err
+			}
+		}
+		return b, nil
+	case wrap.Map:
+		if x.IsNil() {
+			return append(b, msgpackNil), nil
+		}
+		if b, err = msgpackLen(b, x.Len(), msgpackFixMap, 15, 0, msgpackMap16); err != nil {
+			return nil, err
+		}
+		x.Range(func(k, v any) bool {
+			if b, err = msgpackAppend(b, k, depth); err != nil {
+				return false
+			}
+			b, err = msgpackAppend(b, v, depth)
+			return err == nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		return b, nil
+	case wrap.Struct:
+		var (
+			body []byte
+			n    int
+		)
+		for iter := x.Iter(); iter.Next(); n++ {
+			if body, err = msgpackAppend(body, iter.Name(), depth); err != nil {
+				return nil, err
+			}
+			if body, err = msgpackAppend(body, iter.Value(), depth); err != nil {
+				return nil, err
+			}
+		}
+		if b, err = msgpackLen(b, n, msgpackFixMap, 15, 0, msgpackMap16); err != nil {
+			return nil, err
+		}
+		return append(b, body...), nil
+	}
+	return nil, ErrUnsupportedKind
+}
+
+type msgpackDecoder struct {
+	b []byte
+	i int
+}
+
+func (x *msgpackDecoder) next(n uint64) ([]byte, error) {
+	if n > uint64(len(x.b)-x.i) {
+		return nil, io.ErrUnexpectedEOF
+	}
+	o := x.b[x.i : x.i+int(n)]
+	x.i += int(n)
+	return o, nil
+}
+
+// uint reads an "n" byte big endian unsigned integer.
+func (x *msgpackDecoder) uint(n uint64) (uint64, error) {
+	b, err := x.next(n)
+	if err != nil {
+		return 0, err
+	}
+	var o uint64
+	for _, c := range b {
+		o = o<<8 | uint64(c)
+	}
+	return o, nil
+}
+
+// object decodes the next object into a wire value.
+func (x *msgpackDecoder) object(depth int) (any, error) {
+	if depth > wireMaxDepth {
+		return nil, fmt.Errorf("msgpack: nesting too deep: %w", ErrInvalid)
+	}
+	depth++
+
+	b, err := x.next(1)
+	if err != nil {
+		return nil, err
+	}
+	c := b[0]
+
+	var (
+		n    uint64
+		kind byte // one of the fixed formats, standing for its whole family
+	)
+	switch {
+	case c <= 0x7f:
+		return uint64(c), nil
+	case c >= 0xe0:
+		return int64(int8(c)), nil
+	case c&0xf0 == msgpackFixMap:
+		n, kind = uint64(c&0x0f), msgpackFixMap
+	case c&0xf0 == msgpackFixArray:
+		n, kind = uint64(c&0x0f), msgpackFixArray
+	case c&0xe0 == msgpackFixStr:
+		n, kind = uint64(c&0x1f), msgpackFixStr
+	}
+
+	if kind == 0 {
+		switch c {
+		case msgpackNil:
+			return nil, nil
+		case msgpackFalse:
+			return false, nil
+		case msgpackTrue:
+			return true, nil
+		case msgpackFloat32:
+			n, err := x.uint(4)
+			return float64(math.Float32frombits(uint32(n))), err
+		case msgpackFloat64:
+			n, err := x.uint(8)
+			return math.Float64frombits(n), err
+		case msgpackUint8, msgpackUint16, msgpackUint32, msgpackUint64:
+			return x.uint(1 << (c - msgpackUint8))
+		case msgpackInt8, msgpackInt16, msgpackInt32, msgpackInt64:
+			size := uint64(1) << (c - msgpackInt8)
+			n, err := x.uint(size)
+			// sign extend
+			shift := 64 - 8*size
+			return int64(n<<shift) >> shift, err
+		case msgpackStr8, msgpackStr16, msgpackStr32:
+			n, err = x.uint(1 << (c - msgpackStr8))
+			kind = msgpackFixStr
+		case msgpackBin8, msgpackBin16, msgpackBin32:
+			n, err = x.uint(1 << (c - msgpackBin8))
+			kind = msgpackBin8
+		case msgpackArray16, msgpackArray32:
+			n, err = x.uint(2 << (c - msgpackArray16))
+			kind = msgpackFixArray
+		case msgpackMap16, msgpackMap32:
+			n, err = x.uint(2 << (c - msgpackMap16))
+			kind = msgpackFixMap
+		default:
+			return nil, fmt.Errorf("msgpack: format 0x%x: %w", c, ErrUnsupportedKind)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	switch kind {
+	case msgpackFixStr:
+		b, err := x.next(n)
+		if err != nil {
+			return nil, err
+		}
+		return string(b), nil
+	case msgpackBin8:
+		b, err := x.next(n)
+		if err != nil {
+			return nil, err
+		}
+		return append([]byte{}, b...), nil
+	case msgpackFixArray:
+		if n > uint64(len(x.b)-x.i) {
+			// every element takes at least one byte
+			return nil, io.ErrUnexpectedEOF
+		}
+		o := make([]any, n)
+		for i := range o {
+			if o[i], err = x.object(depth); err != nil {
+				return nil, err
+			}
+		}
+		return o, nil
+	}
+
+	if n > uint64(len(x.b)-x.i)/2 {
+		return nil, io.ErrUnexpectedEOF
+	}
+	o := make(wireMap, n)
+	for i := range o {
+		if o[i].k, err = x.object(depth); err != nil {
+			return nil, err
+		}
+		if o[i].v, err = x.object(depth); err != nil {
+			return nil, err
+		}
+	}
+	return o, nil
+}
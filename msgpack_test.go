@@ -0,0 +1,94 @@
+package conv
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	. "reflect"
+	"testing"
+)
+
+func TestMsgpack(t *testing.T) {
+	type entry struct {
+		Key   string
+		Value float64
+		Hits  []uint32
+		Next  *entry
+		Blob  []byte
+		Meta  map[int]string
+	}
+
+	enc := NewConversion(MsgpackConverter)
+	dec := NewInversion(MsgpackInverter)
+
+	known := []struct {
+		v   any
+		exp []byte
+	}{
+		{7, []byte{0x07}},
+		{-5, []byte{0xfb}},
+		{200, []byte{0xcc, 0xc8}},
+		{-200, []byte{0xd1, 0xff, 0x38}},
+		{uint64(math.MaxUint64), []byte{0xcf, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}},
+		{1.5, []byte{0xca, 0x3f, 0xc0, 0x00, 0x00}},
+		{0.1, []byte{0xcb, 0x3f, 0xb9, 0x99, 0x99, 0x99, 0x99, 0x99, 0x9a}},
+		{"hi", []byte{0xa2, 'h', 'i'}},
+		{[]byte{1}, []byte{0xc4, 0x01, 0x01}},
+		{[]bool{true, false}, []byte{0x92, 0xc3, 0xc2}},
+		{map[string]int(nil), []byte{0xc0}},
+	}
+	for _, c := range known {
+		b, err := enc.Call(c.v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(b, c.exp) {
+			t.Errorf("%v: expected %x, got %x", c.v, c.exp, b)
+		}
+	}
+
+	v := entry{
+		Key:   string(bytes.Repeat([]byte("k"), 40)),
+		Value: 0.25,
+		Hits:  make([]uint32, 20),
+		Next:  &entry{Key: "tail", Value: -1e300},
+		Blob:  bytes.Repeat([]byte{9}, 300),
+		Meta:  map[int]string{-1: "neg"},
+	}
+	v.Hits[19] = math.MaxUint32
+	b, err := enc.Call(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	o, err := As[entry](dec, b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !DeepEqual(o, v) {
+		t.Error("wrong round trip", o)
+	}
+
+	var tree any
+	if tree, err = As[any](dec, b); err != nil {
+		t.Fatal(err)
+	}
+	if m := tree.(map[string]any); m["Value"] != 0.25 || len(m["Hits"].([]any)) != 20 {
+		t.Error("wrong tree", tree)
+	}
+
+	if _, err := As[int16](dec, []byte{0xd2, 0x00, 0x01, 0x00, 0x00}); !errors.Is(err, ErrOverflow) {
+		t.Error("expected overflow, got", err)
+	}
+	if n, err := As[int8](dec, []byte{0xd1, 0xff, 0x80}); err != nil || n != -128 {
+		t.Error("wrong sign extension", n, err)
+	}
+	if _, err := As[string](dec, []byte{0xd9, 0x05, 'a'}); err == nil {
+		t.Error("expected truncation error")
+	}
+	if _, err := As[int](dec, []byte{0xd4, 0x01, 0x00}); !errors.Is(err, ErrUnsupportedKind) {
+		t.Error("expected unsupported extension, got", err)
+	}
+	if _, err := As[map[any]int](dec, []byte{0x81, 0x91, 0x01, 0x01}); !errors.Is(err, ErrInvalid) {
+		t.Error("expected unhashable key error, got", err)
+	}
+}
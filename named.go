@@ -0,0 +1,89 @@
+package conv
+
+import (
+	. "reflect"
+	"sync"
+)
+
+// A NamedTypes is a policy for named types, applied to Builders that match on underlying kinds, such as StrconvConverter and AssignMapping, which otherwise treat named types like their underlying kinds.
+// It keeps semantically different types that share a kind, such as user and order IDs, from converting into each other, or from plain values, by accident.
+//
+// Types are opaque to the Builders that NamedConverter and NamedInverter wrap, and to Mappings guarded by Build, if registered through Opaque, or reported by IsOpaque, or, if Strict is set, if they are named and not registered through Allow. Predeclared types, such as int and string, are never opaque.
+// Opaque types are left to other Builders, such as Enums, or to registered functions.
+type NamedTypes struct {
+	Strict   bool
+	IsOpaque func(Type) bool // optional hook, such as for matching types by package path
+
+	opaque map[Type]bool // false for allowed types
+	mux    sync.RWMutex
+}
+
+// Opaque makes "types" opaque, regardless of Strict.
+func (x *NamedTypes) Opaque(types ...Type) {
+	x.set(types, true)
+}
+
+// Allow exempts "types" from Strict.
+func (x *NamedTypes) Allow(types ...Type) {
+	x.set(types, false)
+}
+
+func (x *NamedTypes) set(types []Type, opaque bool) {
+	x.mux.Lock()
+	defer x.mux.Unlock()
+
+	if x.opaque == nil {
+		x.opaque = make(map[Type]bool)
+	}
+	for _, t := range types {
+		x.opaque[t] = opaque
+	}
+}
+
+// hides returns true if "t" is opaque.
+func (x *NamedTypes) hides(t Type) bool {
+	if t.Name() == "" || t.PkgPath() == "" {
+		return false
+	}
+	x.mux.RLock()
+	opaque, ok := x.opaque[t]
+	x.mux.RUnlock()
+	if ok {
+		return opaque
+	}
+	if x.IsOpaque != nil && x.IsOpaque(t) {
+		return true
+	}
+	return x.Strict
+}
+
+// Build is a Builder of Mappings between different types, either of which is opaque, which fail with ErrInvalid.
+// Mappers always fall back to the Builders that match on kinds, so rather than wrapping them, Build claims the Mappings they must not build. It should come after the Builders meant to handle opaque types, such as Enums.Build, and before AssignMapping, such as among the extra Builders of NewDeepMapper.
+// Mappings of a type to itself are left to other Builders, as copying a value doesn't change its meaning.
+func (x *NamedTypes) Build(t Type) (Mapping, bool) {
+	tDst, tSrc := t.Out(0), t.In(0)
+	if tDst == tSrc || (!x.hides(tDst) && !x.hides(tSrc)) {
+		return nil, false
+	}
+	return mappingInvalid, true
+}
+
+// NamedConverter returns a Builder that defers to "b", except for types that "x" makes opaque.
+func NamedConverter[T any](x *NamedTypes, b Builder[Converter[T]]) Builder[Converter[T]] {
+	return func(t Type) (Converter[T], bool) {
+		if x.hides(t) {
+			return nil, false
+		}
+		return b(t)
+	}
+}
+
+// NamedInverter returns a Builder that defers to "b", except for types that "x" makes opaque.
+func NamedInverter[T any](x *NamedTypes, b Builder[Inverter[T]]) Builder[Inverter[T]] {
+	return func(t Type) (Inverter[T], bool) {
+		if x.hides(t) {
+			return nil, false
+		}
+		return b(t)
+	}
+}
@@ -0,0 +1,67 @@
+package conv
+
+import (
+	"errors"
+	. "reflect"
+	"testing"
+)
+
+type namedUserID int64
+
+type namedOrderID int64
+
+type namedScore float64
+
+func TestNamedTypes(t *testing.T) {
+	x := &NamedTypes{}
+	x.Opaque(TypeEval[namedUserID]())
+	m := NewDeepMapper(nil, x.Build)
+
+	var order namedOrderID
+	if err := m.Map(&order, namedUserID(3)); !errors.Is(err, ErrInvalid) {
+		t.Error("opaque source should not map", order, err)
+	}
+	var user namedUserID
+	if err := m.Map(&user, int64(3)); !errors.Is(err, ErrInvalid) {
+		t.Error("opaque destination should not map", user, err)
+	}
+	if err := m.Map(&user, namedUserID(4)); err != nil || user != 4 {
+		t.Error("identical types should map", user, err)
+	}
+	if err := m.Map(&order, int64(5)); err != nil || order != 5 {
+		t.Error("other named types should map", order, err)
+	}
+
+	c := NewConversion(NamedConverter(x, StrconvConverter))
+	if _, err := c.Call(namedUserID(1)); !errors.Is(err, ErrInvalid) {
+		t.Error("opaque type should not convert", err)
+	}
+	inv := NewInversion(NamedInverter(x, StrconvInverter))
+	if _, err := As[namedUserID](inv, "1"); !errors.Is(err, ErrInvalid) {
+		t.Error("opaque type should not invert", err)
+	}
+}
+
+func TestNamedTypesStrict(t *testing.T) {
+	x := &NamedTypes{
+		Strict: true,
+		IsOpaque: func(t Type) bool {
+			return t.Name() == "namedScore"
+		},
+	}
+	x.Allow(TypeEval[namedOrderID](), TypeEval[namedScore]())
+	c := NewConversion(NamedConverter(x, StrconvConverter))
+
+	if o, err := c.Call(int64(2)); err != nil || o != "2" {
+		t.Error("predeclared types should convert", o, err)
+	}
+	if _, err := c.Call(namedUserID(1)); !errors.Is(err, ErrInvalid) {
+		t.Error("named types should not convert", err)
+	}
+	if o, err := c.Call(namedOrderID(3)); err != nil || o != "3" {
+		t.Error("allowed types should convert", o, err)
+	}
+	if _, err := c.Call(namedScore(1)); err != nil {
+		t.Error("registration should take precedence over the hook", err)
+	}
+}
@@ -0,0 +1,155 @@
+package conv
+
+import (
+	"fmt"
+	"net"
+	"net/netip"
+	"net/url"
+	. "reflect"
+)
+
+var (
+	typeIP           = TypeEval[net.IP]()
+	typeAddr         = TypeEval[netip.Addr]()
+	typeHardwareAddr = TypeEval[net.HardwareAddr]()
+	typeURL          = TypeEval[url.URL]()
+	typeString       = TypeEval[string]()
+)
+
+// NetMapping is a Builder of Mappings for network types:
+//
+//   - net.IP and netip.Addr, between each other and string kinds, byte slices, [4]byte and [16]byte
+//   - net.HardwareAddr and string kinds
+//   - url.URL and string kinds
+//
+// Nil and zero addresses map to zero values, and the other way around. IPv4 addresses held in 16 bytes are unmapped.
+func NetMapping(t Type) (Mapping, bool) {
+	tDst, tSrc := t.Out(0), t.In(0)
+	switch {
+	case (tSrc == typeIP || tSrc == typeAddr) && ipForm(tDst), (tDst == typeIP || tDst == typeAddr) && ipForm(tSrc):
+		return func(dst, src Value, s *State) error {
+			addr, err := ipGet(src)
+			if err != nil {
+				return err
+			}
+			return ipSet(dst, addr)
+		}, true
+	case tSrc == typeHardwareAddr && tDst.Kind() == String:
+		return func(dst, src Value, s *State) error {
+			dst.SetString(src.Interface().(net.HardwareAddr).String())
+			return nil
+		}, true
+	case tDst == typeHardwareAddr && tSrc.Kind() == String:
+		return func(dst, src Value, s *State) error {
+			if src.Len() == 0 {
+				dst.SetZero()
+				return nil
+			}
+			o, err := net.ParseMAC(src.String())
+			if err != nil {
+				return fmt.Errorf("%w: %w", ErrInvalid, err)
+			}
+			dst.SetBytes(o)
+			return nil
+		}, true
+	case tSrc == typeURL && tDst.Kind() == String:
+		return func(dst, src Value, s *State) error {
+			u := src.Interface().(url.URL)
+			dst.SetString(u.String())
+			return nil
+		}, true
+	case tDst == typeURL && tSrc.Kind() == String:
+		return func(dst, src Value, s *State) error {
+			o, err := url.Parse(src.String())
+			if err != nil {
+				return fmt.Errorf("%w: %w", ErrInvalid, err)
+			}
+			dst.Set(ValueOf(*o))
+			return nil
+		}, true
+	}
+	return nil, false
+}
+
+// NetConverter is a Builder of string Converters for the types covered by NetMapping.
+func NetConverter(t Type) (Converter[string], bool) {
+	return mappingConverter(NetMapping, t)
+}
+
+// NetInverter is a Builder of string Inverters for the types covered by NetMapping.
+func NetInverter(t Type) (Inverter[string], bool) {
+	return mappingInverter(NetMapping, t)
+}
+
+// ipForm returns true if "t" can represent an IP address.
+func ipForm(t Type) bool {
+	switch {
+	case t == typeIP, t == typeAddr, t.Kind() == String, isBytes(t):
+		return true
+	case t.Kind() == Array && t.Elem().Kind() == Uint8:
+		return t.Len() == 4 || t.Len() == 16
+	}
+	return false
+}
+
+// ipGet returns the address held by "v", which must be an ipForm. Zero values result in the zero address.
+func ipGet(v Value) (netip.Addr, error) {
+	t := v.Type()
+	switch {
+	case t == typeAddr:
+		return v.Interface().(netip.Addr), nil
+	case t.Kind() == String:
+		if v.Len() == 0 {
+			return netip.Addr{}, nil
+		}
+		o, err := netip.ParseAddr(v.String())
+		if err != nil {
+			return o, fmt.Errorf("%w: %w", ErrInvalid, err)
+		}
+		return o, nil
+	case t.Kind() == Slice:
+		if v.Len() == 0 {
+			return netip.Addr{}, nil
+		}
+		o, ok := netip.AddrFromSlice(v.Bytes())
+		if !ok {
+			return o, fmt.Errorf("%d byte address: %w", v.Len(), ErrInvalid)
+		}
+		return o.Unmap(), nil
+	case t.Len() == 4:
+		var b [4]byte
+		Copy(ValueOf(b[:]), v)
+		return netip.AddrFrom4(b), nil
+	}
+	var b [16]byte
+	Copy(ValueOf(b[:]), v)
+	return netip.AddrFrom16(b).Unmap(), nil
+}
+
+// ipSet sets "v", which must be an ipForm, to "addr".
+func ipSet(v Value, addr netip.Addr) error {
+	if !addr.IsValid() {
+		v.SetZero()
+		return nil
+	}
+
+	t := v.Type()
+	switch {
+	case t == typeAddr:
+		v.Set(ValueOf(addr))
+	case t.Kind() == String:
+		v.SetString(addr.String())
+	case t.Kind() == Slice:
+		v.SetBytes(addr.AsSlice())
+	case t.Len() == 4:
+		if !addr.Is4() {
+			return fmt.Errorf("%v into %s: %w", addr, describeType(t), ErrInvalid)
+		}
+		b := addr.As4()
+		Copy(v, ValueOf(b[:]))
+	default:
+		b := addr.As16()
+		Copy(v, ValueOf(b[:]))
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package conv
+
+import (
+	"errors"
+	"net"
+	"net/netip"
+	"net/url"
+	. "reflect"
+	"testing"
+)
+
+func TestNetMapping(t *testing.T) {
+	type config struct {
+		Listen  net.IP
+		Peer    netip.Addr
+		Mask    net.IP
+		MAC     net.HardwareAddr
+		Backend *url.URL
+	}
+	type raw struct {
+		Listen  string
+		Peer    [16]byte
+		Mask    [4]byte
+		MAC     string
+		Backend string
+	}
+
+	m := NewDeepMapper(nil, NetMapping)
+
+	src := raw{
+		Listen:  "10.0.0.1",
+		Peer:    netip.MustParseAddr("fe80::1").As16(),
+		Mask:    [4]byte{255, 255, 255, 0},
+		MAC:     "00:1a:2b:3c:4d:5e",
+		Backend: "https://example.com:8080/api?x=1",
+	}
+	var c config
+	if err := m.Map(&c, src); err != nil {
+		t.Fatal(err)
+	}
+	if !c.Listen.Equal(net.IPv4(10, 0, 0, 1)) || c.Peer != netip.MustParseAddr("fe80::1") || c.Mask.String() != "255.255.255.0" {
+		t.Error("wrong addresses", c)
+	}
+	if c.MAC.String() != src.MAC || c.Backend.Host != "example.com:8080" {
+		t.Error("wrong values", c.MAC, c.Backend)
+	}
+
+	var back raw
+	if err := m.Map(&back, c); err != nil {
+		t.Fatal(err)
+	}
+	if !DeepEqual(back, src) {
+		t.Error("wrong round trip", back)
+	}
+
+	var b4 [4]byte
+	if err := m.Map(&b4, netip.MustParseAddr("::1")); !errors.Is(err, ErrInvalid) {
+		t.Error("expected invalid IPv4, got", err)
+	}
+	var ip net.IP
+	if err := m.Map(&ip, netip.Addr{}); err != nil || ip != nil {
+		t.Error("zero address not nil", ip, err)
+	}
+
+	inv := NewInversion(NetInverter)
+	if _, err := As[net.IP](inv, "10.0.0"); !errors.Is(err, ErrInvalid) {
+		t.Error("expected parse error, got", err)
+	}
+	if s, err := NewConversion(NetConverter).Call(c.Peer); err != nil || s != "fe80::1" {
+		t.Error("wrong string", s, err)
+	}
+}
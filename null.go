@@ -0,0 +1,120 @@
+package conv
+
+import (
+	"fmt"
+	. "reflect"
+)
+
+// Null is a Builder of Mappings between the different representations of absent values:
+//
+//   - pointers, absent when nil
+//   - option structs, made of a bool Valid field and a single value field, such as sql.NullString or sql.NullInt64; absent when not Valid
+//   - plain values, absent when zero if ZeroNull is set
+//
+// Mappings are built between option structs and any other representation, including option structs of different value types. Pointers and plain values are left to Deep, unless the policy of Null changes the outcome.
+// Present values are mapped through Elems, which will usually be the Mapper that Null is itself part of.
+//
+// Absent values map to zero values, or fail with ErrRequired when mapped to plain values if Required is set.
+type Null struct {
+	Elems    *Mapper
+	ZeroNull bool
+	Required bool
+}
+
+// nullForm describes the representation of a possibly absent value.
+type nullForm struct {
+	kind  int  // one of the constants below
+	elem  Type // type of present values
+	valid int  // option struct Valid field index
+	value int  // option struct value field index
+}
+
+const (
+	nullPlain = iota
+	nullPointer
+	nullOption
+)
+
+// Build is a Builder of Mappings between absent value representations.
+func (x *Null) Build(t Type) (Mapping, bool) {
+	tDst, tSrc := t.Out(0), t.In(0)
+	if tDst == tSrc {
+		return nil, false
+	}
+	dst, src := x.form(tDst), x.form(tSrc)
+	switch {
+	case dst.kind == nullOption || src.kind == nullOption:
+	case dst.kind == nullPointer && src.kind == nullPlain && x.ZeroNull:
+	case dst.kind == nullPlain && src.kind == nullPointer && x.Required:
+	default:
+		return nil, false
+	}
+
+	ref := &mappingRef{
+		m:   x.Elems,
+		dst: dst.elem,
+		src: src.elem,
+	}
+	return func(dstV, srcV Value, s *State) error {
+		v, ok := src.get(srcV, x.ZeroNull)
+		if !ok {
+			if dst.kind == nullPlain && x.Required {
+				return fmt.Errorf("absent %s into %s: %w", describeType(tSrc), describeType(tDst), ErrRequired)
+			}
+			dstV.SetZero()
+			return nil
+		}
+
+		switch dst.kind {
+		case nullPointer:
+			o := New(dst.elem)
+			if err := ref.get()(o.Elem(), v, s); err != nil {
+				return err
+			}
+			dstV.Set(o)
+		case nullOption:
+			if err := ref.get()(dstV.Field(dst.value), v, s); err != nil {
+				return err
+			}
+			dstV.Field(dst.valid).SetBool(true)
+		default:
+			return ref.get()(dstV, v, s)
+		}
+		return nil
+	}, true
+}
+
+func (x *Null) form(t Type) nullForm {
+	switch t.Kind() {
+	case Pointer:
+		return nullForm{kind: nullPointer, elem: t.Elem()}
+	case Struct:
+		if t.NumField() != 2 {
+			break
+		}
+		valid, ok := t.FieldByName("Valid")
+		if !ok || len(valid.Index) != 1 || valid.Type.Kind() != Bool {
+			break
+		}
+		value := t.Field(1 - valid.Index[0])
+		if !value.IsExported() {
+			break
+		}
+		return nullForm{kind: nullOption, elem: value.Type, valid: valid.Index[0], value: value.Index[0]}
+	}
+	return nullForm{kind: nullPlain, elem: t}
+}
+
+// get returns the present value held by "v", or false if absent.
+func (x nullForm) get(v Value, zeroNull bool) (Value, bool) {
+	switch x.kind {
+	case nullPointer:
+		if v.IsNil() {
+			return v, false
+		}
+		return v.Elem(), true
+	case nullOption:
+		return v.Field(x.value), v.Field(x.valid).Bool()
+	}
+	return v, !zeroNull || !v.IsZero()
+}
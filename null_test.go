@@ -0,0 +1,67 @@
+package conv
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+)
+
+func TestNull(t *testing.T) {
+	type row struct {
+		Name  sql.NullString
+		Age   sql.NullInt64
+		Score sql.NullFloat64
+		Note  string
+	}
+	type model struct {
+		Name  *string
+		Age   sql.NullInt32
+		Score float64
+		Note  *string
+	}
+
+	null := &Null{ZeroNull: true}
+	m := NewDeepMapper(nil, null.Build)
+	null.Elems = m
+
+	var mod model
+	src := row{
+		Name:  sql.NullString{String: "x", Valid: true},
+		Age:   sql.NullInt64{Int64: 42, Valid: true},
+		Score: sql.NullFloat64{Float64: 1.5},
+	}
+	if err := m.Map(&mod, src); err != nil {
+		t.Fatal(err)
+	}
+	if mod.Name == nil || *mod.Name != "x" || mod.Age != (sql.NullInt32{Int32: 42, Valid: true}) || mod.Score != 0 || mod.Note != nil {
+		t.Error("wrong model", mod)
+	}
+
+	var back row
+	if err := m.Map(&back, mod); err != nil {
+		t.Fatal(err)
+	}
+	src.Score.Float64 = 0 // absent values don't carry over
+	if back != src {
+		t.Error("wrong round trip", back)
+	}
+
+	// zero values are present without ZeroNull
+	null.ZeroNull = false
+	m = NewDeepMapper(nil, null.Build)
+	null.Elems = m
+	if err := m.Map(&back, model{}); err != nil || !back.Score.Valid {
+		t.Error("zero score not valid", back, err)
+	}
+
+	null.Required = true
+	m = NewDeepMapper(nil, null.Build)
+	null.Elems = m
+	if err := m.Map(&mod, row{}); !errors.Is(err, ErrRequired) {
+		t.Error("expected required score, got", err)
+	}
+	var n int
+	if err := m.Map(&n, (*int)(nil)); !errors.Is(err, ErrRequired) {
+		t.Error("expected required int, got", err)
+	}
+}
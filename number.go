@@ -0,0 +1,170 @@
+package conv
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	. "reflect"
+	"strconv"
+	"strings"
+)
+
+// JSONNumber is a Builder of Mappings between json.Number, as produced by json.Decoder.UseNumber, and numeric kinds (other than complex kinds).
+// If Strings is set, all string kinds are treated as decimal numbers, rather than following the Go conversion rules.
+//
+// Numbers are only mapped if they fit the destination exactly: integers must be in range and have no fractional part, while floats must parse back to the same decimal value when formatted with the shortest representation. Numbers out of range fail with ErrOverflow, numbers in range but not exactly representable with ErrPrecisionLoss, and malformed ones with ErrInvalid.
+// Floats map to the shortest decimal that parses back exactly; NaN and infinities fail with ErrInvalid, as JSON cannot hold them.
+type JSONNumber struct {
+	Strings bool
+}
+
+var typeJSONNumber = TypeEval[json.Number]()
+
+// Build is a Builder of Mappings between json.Number, or string kinds, and numeric kinds.
+func (x *JSONNumber) Build(t Type) (Mapping, bool) {
+	tDst, tSrc := t.Out(0), t.In(0)
+	switch {
+	case x.decimal(tSrc) && numberKind(tDst.Kind()):
+		return func(dst, src Value, s *State) error {
+			return numberParse(dst, src.String())
+		}, true
+	case x.decimal(tDst) && numberKind(tSrc.Kind()):
+		return func(dst, src Value, s *State) error {
+			o, err := numberFormat(src)
+			if err != nil {
+				return err
+			}
+			dst.SetString(o)
+			return nil
+		}, true
+	}
+	return nil, false
+}
+
+func (x *JSONNumber) decimal(t Type) bool {
+	return t == typeJSONNumber || (x.Strings && t.Kind() == String)
+}
+
+func numberKind(k Kind) bool {
+	return k >= Int && k <= Float64
+}
+
+func numberFormat(v Value) (string, error) {
+	switch k := v.Kind(); {
+	case k >= Int && k <= Int64:
+		return strconv.FormatInt(v.Int(), 10), nil
+	case k >= Uint && k <= Uintptr:
+		return strconv.FormatUint(v.Uint(), 10), nil
+	}
+	f := v.Float()
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return "", fmt.Errorf("%v as number: %w", f, ErrInvalid)
+	}
+	return strconv.FormatFloat(f, 'g', -1, v.Type().Bits()), nil
+}
+
+// numberParse sets numeric "dst" to decimal number "s", if it fits exactly.
+func numberParse(dst Value, s string) error {
+	t := dst.Type()
+	k := t.Kind()
+
+	// fast paths for plain integers
+	switch {
+	case k >= Int && k <= Int64:
+		if n, err := strconv.ParseInt(s, 10, t.Bits()); err == nil {
+			dst.SetInt(n)
+			return nil
+		}
+	case k >= Uint && k <= Uintptr:
+		if n, err := strconv.ParseUint(s, 10, t.Bits()); err == nil {
+			dst.SetUint(n)
+			return nil
+		}
+	}
+
+	if !numberSyntax(s) {
+		return fmt.Errorf("number %q: %w", s, ErrInvalid)
+	}
+	overflow := fmt.Errorf("%s into %s: %w", s, describeType(t), ErrOverflow)
+	if i := strings.IndexAny(s, "eE"); i >= 0 {
+		// big.Rat would expand huge exponents; anything past this is out of range for all kinds
+		if exp, err := strconv.Atoi(s[i+1:]); err != nil || exp > 1000 || exp < -1000 {
+			return overflow
+		}
+	}
+	loss := fmt.Errorf("%s into %s: %w", s, describeType(t), ErrPrecisionLoss)
+	r, _ := new(big.Rat).SetString(s)
+
+	// fractions truncated toward zero, to tell precision loss from overflow
+	n := r.Num()
+	if !r.IsInt() {
+		n = new(big.Int).Quo(r.Num(), r.Denom())
+	}
+	switch {
+	case k >= Int && k <= Int64:
+		if !n.IsInt64() || dst.OverflowInt(n.Int64()) {
+			return overflow
+		}
+		if !r.IsInt() {
+			return loss
+		}
+		dst.SetInt(n.Int64())
+	case k >= Uint && k <= Uintptr:
+		if !n.IsUint64() || dst.OverflowUint(n.Uint64()) {
+			return overflow
+		}
+		if !r.IsInt() {
+			return loss
+		}
+		dst.SetUint(n.Uint64())
+	default:
+		f, err := strconv.ParseFloat(s, t.Bits())
+		if err != nil {
+			return overflow
+		}
+		back, _ := new(big.Rat).SetString(strconv.FormatFloat(f, 'g', -1, t.Bits()))
+		if back.Cmp(r) != 0 {
+			return loss
+		}
+		dst.SetFloat(f)
+	}
+	return nil
+}
+
+// numberSyntax returns true if "s" is a decimal number: an optional sign, digits with an optional fractional part, and an optional exponent.
+// This is JSON number syntax, with leading zeros and plus signs also allowed.
+func numberSyntax(s string) bool {
+	i := 0
+	digits := func() bool {
+		start := i
+		for i < len(s) && s[i] >= '0' && s[i] <= '9' {
+			i++
+		}
+		return i > start
+	}
+	sign := func() {
+		if i < len(s) && (s[i] == '-' || s[i] == '+') {
+			i++
+		}
+	}
+
+	sign()
+	if !digits() {
+		return false
+	}
+	if i < len(s) && s[i] == '.' {
+		i++
+		if !digits() {
+			return false
+		}
+	}
+	if i < len(s) && (s[i] == 'e' || s[i] == 'E') {
+		i++
+		sign()
+		if !digits() {
+			return false
+		}
+	}
+	return i == len(s)
+}
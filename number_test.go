@@ -0,0 +1,70 @@
+package conv
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestJSONNumber(t *testing.T) {
+	type config struct {
+		Port  uint16
+		Ratio float32
+		Big   int64
+		Sci   int
+		Name  string
+	}
+
+	dec := json.NewDecoder(strings.NewReader(`{"Port": 8080, "Ratio": 0.25, "Big": 9007199254740993, "Sci": 1.5e3, "Name": "x"}`))
+	dec.UseNumber()
+	var tree any
+	if err := dec.Decode(&tree); err != nil {
+		t.Fatal(err)
+	}
+
+	tm := &Tree{}
+	m := NewDeepMapper(nil, (&JSONNumber{}).Build, tm.Build)
+	tm.Fields = m
+
+	var c config
+	if err := m.Map(&c, tree); err != nil {
+		t.Fatal(err)
+	}
+	if c != (config{8080, 0.25, 9007199254740993, 1500, "x"}) {
+		t.Error("wrong config", c)
+	}
+
+	for _, tc := range []struct {
+		s   json.Number
+		dst any
+		err error
+	}{
+		{"70000", new(uint16), ErrOverflow},
+		{"-1", new(uint), ErrOverflow},
+		{"1.5", new(int), ErrPrecisionLoss},
+		{"-12345678901234567890.5", new(int64), ErrOverflow},
+		{"0.1", new(float32), nil},
+		{"0.1000000001", new(float32), ErrPrecisionLoss},
+		{"1e400", new(float64), ErrOverflow},
+		{"1e-999999999", new(float64), ErrOverflow},
+		{"0x10", new(int), ErrInvalid},
+		{"1/2", new(float64), ErrInvalid},
+		{"1.", new(float64), ErrInvalid},
+	} {
+		if err := m.Map(tc.dst, tc.s); !errors.Is(err, tc.err) || (tc.err == nil && err != nil) {
+			t.Errorf("%s: expected %v, got %v", tc.s, tc.err, err)
+		}
+	}
+
+	var n json.Number
+	if err := m.Map(&n, 0.1); err != nil || n != "0.1" {
+		t.Error("wrong number", n, err)
+	}
+
+	// plain strings follow the Go rules unless enabled
+	var s string
+	if err := NewDeepMapper(nil, (&JSONNumber{Strings: true}).Build).Map(&s, 65); err != nil || s != "65" {
+		t.Error("wrong string", s, err)
+	}
+}
@@ -0,0 +1,292 @@
+package conv
+
+import (
+	"fmt"
+	"math"
+	. "reflect"
+	"sort"
+)
+
+//go:generate go run numeric_gen.go
+
+// NumericConverter extends Builder "b" to the numeric kinds it doesn't cover, by extrapolating from those it does.
+// Source values are converted into the covered kind that best holds them, preferring kinds that hold all their values, then kinds of the same nature (signed, unsigned or float), then the closest size.
+// Conversions go through a generated table of kind pair functions, rather than further reflection. Values out of range of the covered kind fail with ErrOverflow, and those it can't represent exactly with ErrPrecisionLoss.
+func NumericConverter[T any](b Builder[Converter[T]]) Builder[Converter[T]] {
+	return NumericConverterWith(b, NumericPolicy{})
+}
+
+// NumericConverterWith is the same as NumericConverter, with extrapolation configured by "p".
+func NumericConverterWith[T any](b Builder[Converter[T]], p NumericPolicy) Builder[Converter[T]] {
+	return func(t Type) (Converter[T], bool) {
+		if o, ok := b(t); ok {
+			return o, true
+		}
+		k, ok := p.fill(t.Kind(), func(k Kind) bool {
+			_, ok := b(numericTypes[k])
+			return ok
+		})
+		if !ok {
+			return nil, false
+		}
+		fn, _ := b(numericTypes[k])
+
+		to := numericFuncs[t.Kind()][k]
+		return func(v Value) (T, error) {
+			w, ok := to(v)
+			if !ok {
+				if !p.Lossy {
+					var o T
+					return o, fmt.Errorf("%v into %v: %w", v, k, numericLoss(v, k))
+				}
+				w = v.Convert(numericTypes[k])
+			}
+			return fn(w)
+		}, true
+	}
+}
+
+// NumericInverter extends Builder "b" to the numeric kinds it doesn't cover, by extrapolating from those it does.
+// Values are inverted into the covered kind that best holds the destination kind, ranked as for NumericConverter, then converted through the generated kind pair table. Results out of range of the destination fail with ErrOverflow, and those it can't represent exactly with ErrPrecisionLoss.
+func NumericInverter[T any](b Builder[Inverter[T]]) Builder[Inverter[T]] {
+	return NumericInverterWith(b, NumericPolicy{})
+}
+
+// NumericInverterWith is the same as NumericInverter, with extrapolation configured by "p".
+func NumericInverterWith[T any](b Builder[Inverter[T]], p NumericPolicy) Builder[Inverter[T]] {
+	return func(t Type) (Inverter[T], bool) {
+		if o, ok := b(t); ok {
+			return o, true
+		}
+		k, ok := p.fill(t.Kind(), func(k Kind) bool {
+			_, ok := b(numericTypes[k])
+			return ok
+		})
+		if !ok {
+			return nil, false
+		}
+		fn, _ := b(numericTypes[k])
+
+		from := numericFuncs[k][t.Kind()]
+		named := t != numericTypes[t.Kind()]
+		return func(v T) (Value, error) {
+			w, err := fn(v)
+			if err != nil {
+				return Value{}, err
+			}
+			o, ok := from(w)
+			if !ok {
+				if !p.Lossy {
+					return Value{}, fmt.Errorf("%v into %s: %w", w, describeType(t), numericLoss(w, t.Kind()))
+				}
+				return w.Convert(t), nil
+			}
+			if named {
+				o = o.Convert(t)
+			}
+			return o, nil
+		}, true
+	}
+}
+
+// NumericMapping is a Builder of Mappings between numeric types of different kinds, through the generated kind pair table.
+// Unlike Go conversions, as done by AssignMapping, values out of range of the destination fail with ErrOverflow, rather than wrapping around, and those it can't represent exactly with ErrPrecisionLoss, rather than being truncated, unless the Mapper is lossy; see Mapper.SetLossy.
+func NumericMapping(t Type) (Mapping, bool) {
+	tDst, tSrc := t.Out(0), t.In(0)
+	kDst, kSrc := tDst.Kind(), tSrc.Kind()
+	if kDst == kSrc || kDst < Int || kDst > Float64 || kSrc < Int || kSrc > Float64 {
+		return nil, false
+	}
+
+	to := numericFuncs[kSrc][kDst]
+	named := tDst != numericTypes[kDst]
+	return func(dst, src Value, s *State) error {
+		o, ok := to(src)
+		if !ok {
+			if !s.Lossy() {
+				return fmt.Errorf("%v into %s: %w", src, describeType(tDst), numericLoss(src, kDst))
+			}
+			o = src.Convert(numericTypes[kDst])
+		}
+		if named {
+			o = o.Convert(tDst)
+		}
+		dst.Set(o)
+		return nil
+	}, true
+}
+
+// A NumericPolicy configures numeric extrapolation. The zero value extrapolates all numeric kinds, as NumericConverter and NumericInverter do.
+type NumericPolicy struct {
+	Except []Kind // kinds never extrapolated, for schemes that intentionally support only some kinds
+	Lossy  bool   // convert values that don't fit as Go conversions do, wrapping around and truncating, rather than failing with ErrOverflow or ErrPrecisionLoss
+
+	// Rank rates covered kind "dst" as a stand-in for extrapolated kind "src"; the lowest rated covered kind is used, with ties going to the lower kind. Negative ratings exclude the kind.
+	// Defaults to NumericRankDefault. NumericRankClosestSize and NumericRankSameNature are alternatives.
+	Rank func(dst, src Kind) int
+}
+
+// fill returns the kind to extrapolate numeric kind "k" from, among those that "covered" returns true for.
+// "covered" may be called for kinds other than the one returned, so it must not have side effects.
+func (x NumericPolicy) fill(k Kind, covered func(Kind) bool) (Kind, bool) {
+	for _, e := range x.Except {
+		if e == k {
+			return Invalid, false
+		}
+	}
+	if x.Rank == nil {
+		return fillNumeric(k, covered)
+	}
+	if !numberKind(k) {
+		return Invalid, false
+	}
+	o, best := Invalid, -1
+	for dst := Int; dst <= Float64; dst++ {
+		r := x.Rank(dst, k)
+		if r < 0 || (best >= 0 && r >= best) || !covered(dst) {
+			continue
+		}
+		o, best = dst, r
+	}
+	return o, best >= 0
+}
+
+// NumericRankDefault is the ranking of NumericConverter and NumericInverter: kinds that hold all values of the extrapolated kind first, then kinds of the same nature (signed, unsigned or float), then the closest size.
+func NumericRankDefault(dst, src Kind) int {
+	return numericRating(dst, src)
+}
+
+// NumericRankClosestSize ranks kinds by the closest size, then by the same nature, regardless of whether they hold all values of the extrapolated kind.
+func NumericRankClosestSize(dst, src Kind) int {
+	o := 10 * numericSize(dst, src)
+	if numericNature(dst) != numericNature(src) {
+		o++
+	}
+	return o
+}
+
+// NumericRankSameNature ranks as NumericRankDefault, but only kinds of the same nature as the extrapolated kind, so that integers are never extrapolated through floats, nor signed through unsigned integers.
+func NumericRankSameNature(dst, src Kind) int {
+	if numericNature(dst) != numericNature(src) {
+		return -1
+	}
+	return numericRating(dst, src)
+}
+
+// NumericFills returns the numeric kinds that extrapolation under "p" adds to Builder "b", each mapped to the covered kind it is extrapolated from.
+// "b" is queried with the predeclared type of each kind. Works for Builders of Converters and Inverters alike.
+func NumericFills[T any](b Builder[T], p NumericPolicy) map[Kind]Kind {
+	o := make(map[Kind]Kind)
+	for k := Int; k <= Float64; k++ {
+		if _, ok := b(numericTypes[k]); ok {
+			continue
+		}
+		src, ok := p.fill(k, func(k Kind) bool {
+			_, ok := b(numericTypes[k])
+			return ok
+		})
+		if ok {
+			o[k] = src
+		}
+	}
+	return o
+}
+
+// numericLoss returns the class of failure of converting numeric value "v" to kind "k": ErrPrecisionLoss if the destination range holds it, but not exactly, or else ErrOverflow.
+func numericLoss(v Value, k Kind) error {
+	if !v.CanFloat() {
+		// integers only lose precision as floats
+		if k == Float32 || k == Float64 {
+			return ErrPrecisionLoss
+		}
+		return ErrOverflow
+	}
+	f := v.Float()
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return ErrOverflow
+	}
+	if _, ok := numericFuncs[Float64][k](ValueOf(math.Trunc(f))); ok {
+		return ErrPrecisionLoss
+	}
+	return ErrOverflow
+}
+
+// fillNumeric returns the first kind of numericChart, for extrapolating numeric kind "k", that "covered" returns true for.
+func fillNumeric(k Kind, covered func(Kind) bool) (Kind, bool) {
+	if !numberKind(k) {
+		return Invalid, false
+	}
+	for _, o := range numericChart[k] {
+		if covered(o) {
+			return o, true
+		}
+	}
+	return Invalid, false
+}
+
+var numericTypes = [Float64 + 1]Type{
+	Int:     TypeEval[int](),
+	Int8:    TypeEval[int8](),
+	Int16:   TypeEval[int16](),
+	Int32:   TypeEval[int32](),
+	Int64:   TypeEval[int64](),
+	Uint:    TypeEval[uint](),
+	Uint8:   TypeEval[uint8](),
+	Uint16:  TypeEval[uint16](),
+	Uint32:  TypeEval[uint32](),
+	Uint64:  TypeEval[uint64](),
+	Uintptr: TypeEval[uintptr](),
+	Float32: TypeEval[float32](),
+	Float64: TypeEval[float64](),
+}
+
+// numericChart ranks, for each numeric kind, all numeric kinds by their fitness to stand in for it, best first.
+// The best stand-ins hold all values of the kind, so that extrapolation only fails on values that don't fit the other side.
+var numericChart = makeNumericChart()
+
+func makeNumericChart() (o [Float64 + 1][]Kind) {
+	for k := Int; k <= Float64; k++ {
+		chart := make([]Kind, 0, Float64-Int+1)
+		for to := Int; to <= Float64; to++ {
+			chart = append(chart, to)
+		}
+		sort.SliceStable(chart, func(i, j int) bool {
+			return numericRating(chart[i], k) < numericRating(chart[j], k)
+		})
+		o[k] = chart
+	}
+	return
+}
+
+// numericRating rates the conversion of numeric kind "src" into "dst"; lower is better.
+// Lossless conversions rate better than narrowing ones, conversions within the same nature (signed, unsigned or float) better than across, and closer sizes better than distant ones.
+func numericRating(dst, src Kind) int {
+	o := 0
+	if narrowing(numericTypes[dst], numericTypes[src]) {
+		o += 1000
+	}
+	if numericNature(dst) != numericNature(src) {
+		o += 100
+	}
+	return o + numericSize(dst, src)
+}
+
+// numericSize returns the difference in bits between numeric kinds "dst" and "src".
+func numericSize(dst, src Kind) int {
+	d := numericTypes[dst].Bits() - numericTypes[src].Bits()
+	if d < 0 {
+		return -d
+	}
+	return d
+}
+
+// numericNature returns 0 for signed integers, 1 for unsigned ones, and 2 for floats.
+func numericNature(k Kind) int {
+	switch {
+	case k >= Int && k <= Int64:
+		return 0
+	case k >= Uint && k <= Uintptr:
+		return 1
+	}
+	return 2
+}
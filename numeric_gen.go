@@ -0,0 +1,110 @@
+//go:build ignore
+
+// numeric_gen generates numeric_table.go, the matrix of lossless numeric kind pair conversions.
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"os"
+	"strings"
+)
+
+type kind struct {
+	name   string
+	nature byte   // 'i' signed, 'u' unsigned, 'f' float
+	lo, hi string // exclusive upper and inclusive lower bounds of integer kinds, as untyped constants
+}
+
+var kinds = []kind{
+	{"int", 'i', "math.MinInt", "math.MaxInt + 1"},
+	{"int8", 'i', "math.MinInt8", "math.MaxInt8 + 1"},
+	{"int16", 'i', "math.MinInt16", "math.MaxInt16 + 1"},
+	{"int32", 'i', "math.MinInt32", "math.MaxInt32 + 1"},
+	{"int64", 'i', "math.MinInt64", "math.MaxInt64 + 1"},
+	{"uint", 'u', "0", "math.MaxUint + 1"},
+	{"uint8", 'u', "0", "math.MaxUint8 + 1"},
+	{"uint16", 'u', "0", "math.MaxUint16 + 1"},
+	{"uint32", 'u', "0", "math.MaxUint32 + 1"},
+	{"uint64", 'u', "0", "math.MaxUint64 + 1"},
+	{"uintptr", 'u', "0", "math.MaxUint + 1"}, // uintptr has the width of uint on all supported platforms
+	{"float32", 'f', "", ""},
+	{"float64", 'f', "", ""},
+}
+
+func main() {
+	var b bytes.Buffer
+	b.WriteString("// Code generated by numeric_gen.go. DO NOT EDIT.\n\npackage conv\n\nimport (\n\t\"math\"\n\t. \"reflect\"\n)\n")
+
+	b.WriteString("\n// numericFuncs holds the lossless conversions between numeric kinds, indexed by source and destination kind.\n")
+	b.WriteString("// Each returns the converted value, with the basic type of the destination kind, and false if it doesn't represent the source exactly.\n")
+	b.WriteString("var numericFuncs = [Float64 + 1][Float64 + 1]func(Value) (Value, bool){\n")
+	for _, src := range kinds {
+		fmt.Fprintf(&b, "%s: {\n", title(src.name))
+		for _, dst := range kinds {
+			fmt.Fprintf(&b, "%s: func(v Value) (Value, bool) {\no, ok := %s(%s)\nreturn ValueOf(o), ok\n},\n", title(dst.name), funcName(src, dst), read(src))
+		}
+		b.WriteString("},\n")
+	}
+	b.WriteString("}\n")
+
+	for _, src := range kinds {
+		for _, dst := range kinds {
+			fmt.Fprintf(&b, "\nfunc %s(v %s) (%s, bool) {\n", funcName(src, dst), src.name, dst.name)
+			b.WriteString(body(src, dst))
+			b.WriteString("}\n")
+		}
+	}
+
+	src, err := format.Source(b.Bytes())
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	if err := os.WriteFile("numeric_table.go", src, 0666); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func body(src, dst kind) string {
+	switch {
+	case src == dst:
+		return "return v, true\n"
+	case src.nature != 'f' && dst.nature != 'f':
+		check := fmt.Sprintf("%s(o) == v", src.name)
+		switch {
+		case src.nature == 'i' && dst.nature == 'u':
+			check += " && v >= 0"
+		case src.nature == 'u' && dst.nature == 'i':
+			check += " && o >= 0"
+		}
+		return fmt.Sprintf("o := %s(v)\nreturn o, %s\n", dst.name, check)
+	case dst.nature == 'f' && src.nature != 'f':
+		// the range check keeps the conversion back defined
+		return fmt.Sprintf("o := %s(v)\nreturn o, o >= %s && o < %s && %s(o) == v\n", dst.name, src.lo, src.hi, src.name)
+	case src.nature == 'f' && dst.nature != 'f':
+		return fmt.Sprintf("if !(v >= %s && v < %s) {\nreturn 0, false\n}\no := %s(v)\nreturn o, %s(o) == v\n", dst.lo, dst.hi, dst.name, src.name)
+	}
+	// float to float; NaN converts to NaN
+	return fmt.Sprintf("o := %s(v)\nreturn o, %s(o) == v || v != v\n", dst.name, src.name)
+}
+
+func read(k kind) string {
+	switch k.nature {
+	case 'i':
+		return k.name + "(v.Int())"
+	case 'u':
+		return k.name + "(v.Uint())"
+	}
+	return k.name + "(v.Float())"
+}
+
+func funcName(src, dst kind) string {
+	return src.name + "To" + title(dst.name)
+}
+
+func title(s string) string {
+	return strings.ToUpper(s[:1]) + s[1:]
+}
@@ -0,0 +1,1613 @@
+// Code generated by numeric_gen.go. DO NOT EDIT.
+
+package conv
+
+import (
+	"math"
+	. "reflect"
+)
+
+// numericFuncs holds the lossless conversions between numeric kinds, indexed by source and destination kind.
+// Each returns the converted value, with the basic type of the destination kind, and false if it doesn't represent the source exactly.
+var numericFuncs = [Float64 + 1][Float64 + 1]func(Value) (Value, bool){
+	Int: {
+		Int: func(v Value) (Value, bool) {
+			o, ok := intToInt(int(v.Int()))
+			return ValueOf(o), ok
+		},
+		Int8: func(v Value) (Value, bool) {
+			o, ok := intToInt8(int(v.Int()))
+			return ValueOf(o), ok
+		},
+		Int16: func(v Value) (Value, bool) {
+			o, ok := intToInt16(int(v.Int()))
+			return ValueOf(o), ok
+		},
+		Int32: func(v Value) (Value, bool) {
+			o, ok := intToInt32(int(v.Int()))
+			return ValueOf(o), ok
+		},
+		Int64: func(v Value) (Value, bool) {
+			o, ok := intToInt64(int(v.Int()))
+			return ValueOf(o), ok
+		},
+		Uint: func(v Value) (Value, bool) {
+			o, ok := intToUint(int(v.Int()))
+			return ValueOf(o), ok
+		},
+		Uint8: func(v Value) (Value, bool) {
+			o, ok := intToUint8(int(v.Int()))
+			return ValueOf(o), ok
+		},
+		Uint16: func(v Value) (Value, bool) {
+			o, ok := intToUint16(int(v.Int()))
+			return ValueOf(o), ok
+		},
+		Uint32: func(v Value) (Value, bool) {
+			o, ok := intToUint32(int(v.Int()))
+			return ValueOf(o), ok
+		},
+		Uint64: func(v Value) (Value, bool) {
+			o, ok := intToUint64(int(v.Int()))
+			return ValueOf(o), ok
+		},
+		Uintptr: func(v Value) (Value, bool) {
+			o, ok := intToUintptr(int(v.Int()))
+			return ValueOf(o), ok
+		},
+		Float32: func(v Value) (Value, bool) {
+			o, ok := intToFloat32(int(v.Int()))
+			return ValueOf(o), ok
+		},
+		Float64: func(v Value) (Value, bool) {
+			o, ok := intToFloat64(int(v.Int()))
+			return ValueOf(o), ok
+		},
+	},
+	Int8: {
+		Int: func(v Value) (Value, bool) {
+			o, ok := int8ToInt(int8(v.Int()))
+			return ValueOf(o), ok
+		},
+		Int8: func(v Value) (Value, bool) {
+			o, ok := int8ToInt8(int8(v.Int()))
+			return ValueOf(o), ok
+		},
+		Int16: func(v Value) (Value, bool) {
+			o, ok := int8ToInt16(int8(v.Int()))
+			return ValueOf(o), ok
+		},
+		Int32: func(v Value) (Value, bool) {
+			o, ok := int8ToInt32(int8(v.Int()))
+			return ValueOf(o), ok
+		},
+		Int64: func(v Value) (Value, bool) {
+			o, ok := int8ToInt64(int8(v.Int()))
+			return ValueOf(o), ok
+		},
+		Uint: func(v Value) (Value, bool) {
+			o, ok := int8ToUint(int8(v.Int()))
+			return ValueOf(o), ok
+		},
+		Uint8: func(v Value) (Value, bool) {
+			o, ok := int8ToUint8(int8(v.Int()))
+			return ValueOf(o), ok
+		},
+		Uint16: func(v Value) (Value, bool) {
+			o, ok := int8ToUint16(int8(v.Int()))
+			return ValueOf(o), ok
+		},
+		Uint32: func(v Value) (Value, bool) {
+			o, ok := int8ToUint32(int8(v.Int()))
+			return ValueOf(o), ok
+		},
+		Uint64: func(v Value) (Value, bool) {
+			o, ok := int8ToUint64(int8(v.Int()))
+			return ValueOf(o), ok
+		},
+		Uintptr: func(v Value) (Value, bool) {
+			o, ok := int8ToUintptr(int8(v.Int()))
+			return ValueOf(o), ok
+		},
+		Float32: func(v Value) (Value, bool) {
+			o, ok := int8ToFloat32(int8(v.Int()))
+			return ValueOf(o), ok
+		},
+		Float64: func(v Value) (Value, bool) {
+			o, ok := int8ToFloat64(int8(v.Int()))
+			return ValueOf(o), ok
+		},
+	},
+	Int16: {
+		Int: func(v Value) (Value, bool) {
+			o, ok := int16ToInt(int16(v.Int()))
+			return ValueOf(o), ok
+		},
+		Int8: func(v Value) (Value, bool) {
+			o, ok := int16ToInt8(int16(v.Int()))
+			return ValueOf(o), ok
+		},
+		Int16: func(v Value) (Value, bool) {
+			o, ok := int16ToInt16(int16(v.Int()))
+			return ValueOf(o), ok
+		},
+		Int32: func(v Value) (Value, bool) {
+			o, ok := int16ToInt32(int16(v.Int()))
+			return ValueOf(o), ok
+		},
+		Int64: func(v Value) (Value, bool) {
+			o, ok := int16ToInt64(int16(v.Int()))
+			return ValueOf(o), ok
+		},
+		Uint: func(v Value) (Value, bool) {
+			o, ok := int16ToUint(int16(v.Int()))
+			return ValueOf(o), ok
+		},
+		Uint8: func(v Value) (Value, bool) {
+			o, ok := int16ToUint8(int16(v.Int()))
+			return ValueOf(o), ok
+		},
+		Uint16: func(v Value) (Value, bool) {
+			o, ok := int16ToUint16(int16(v.Int()))
+			return ValueOf(o), ok
+		},
+		Uint32: func(v Value) (Value, bool) {
+			o, ok := int16ToUint32(int16(v.Int()))
+			return ValueOf(o), ok
+		},
+		Uint64: func(v Value) (Value, bool) {
+			o, ok := int16ToUint64(int16(v.Int()))
+			return ValueOf(o), ok
+		},
+		Uintptr: func(v Value) (Value, bool) {
+			o, ok := int16ToUintptr(int16(v.Int()))
+			return ValueOf(o), ok
+		},
+		Float32: func(v Value) (Value, bool) {
+			o, ok := int16ToFloat32(int16(v.Int()))
+			return ValueOf(o), ok
+		},
+		Float64: func(v Value) (Value, bool) {
+			o, ok := int16ToFloat64(int16(v.Int()))
+			return ValueOf(o), ok
+		},
+	},
+	Int32: {
+		Int: func(v Value) (Value, bool) {
+			o, ok := int32ToInt(int32(v.Int()))
+			return ValueOf(o), ok
+		},
+		Int8: func(v Value) (Value, bool) {
+			o, ok := int32ToInt8(int32(v.Int()))
+			return ValueOf(o), ok
+		},
+		Int16: func(v Value) (Value, bool) {
+			o, ok := int32ToInt16(int32(v.Int()))
+			return ValueOf(o), ok
+		},
+		Int32: func(v Value) (Value, bool) {
+			o, ok := int32ToInt32(int32(v.Int()))
+			return ValueOf(o), ok
+		},
+		Int64: func(v Value) (Value, bool) {
+			o, ok := int32ToInt64(int32(v.Int()))
+			return ValueOf(o), ok
+		},
+		Uint: func(v Value) (Value, bool) {
+			o, ok := int32ToUint(int32(v.Int()))
+			return ValueOf(o), ok
+		},
+		Uint8: func(v Value) (Value, bool) {
+			o, ok := int32ToUint8(int32(v.Int()))
+			return ValueOf(o), ok
+		},
+		Uint16: func(v Value) (Value, bool) {
+			o, ok := int32ToUint16(int32(v.Int()))
+			return ValueOf(o), ok
+		},
+		Uint32: func(v Value) (Value, bool) {
+			o, ok := int32ToUint32(int32(v.Int()))
+			return ValueOf(o), ok
+		},
+		Uint64: func(v Value) (Value, bool) {
+			o, ok := int32ToUint64(int32(v.Int()))
+			return ValueOf(o), ok
+		},
+		Uintptr: func(v Value) (Value, bool) {
+			o, ok := int32ToUintptr(int32(v.Int()))
+			return ValueOf(o), ok
+		},
+		Float32: func(v Value) (Value, bool) {
+			o, ok := int32ToFloat32(int32(v.Int()))
+			return ValueOf(o), ok
+		},
+		Float64: func(v Value) (Value, bool) {
+			o, ok := int32ToFloat64(int32(v.Int()))
+			return ValueOf(o), ok
+		},
+	},
+	Int64: {
+		Int: func(v Value) (Value, bool) {
+			o, ok := int64ToInt(int64(v.Int()))
+			return ValueOf(o), ok
+		},
+		Int8: func(v Value) (Value, bool) {
+			o, ok := int64ToInt8(int64(v.Int()))
+			return ValueOf(o), ok
+		},
+		Int16: func(v Value) (Value, bool) {
+			o, ok := int64ToInt16(int64(v.Int()))
+			return ValueOf(o), ok
+		},
+		Int32: func(v Value) (Value, bool) {
+			o, ok := int64ToInt32(int64(v.Int()))
+			return ValueOf(o), ok
+		},
+		Int64: func(v Value) (Value, bool) {
+			o, ok := int64ToInt64(int64(v.Int()))
+			return ValueOf(o), ok
+		},
+		Uint: func(v Value) (Value, bool) {
+			o, ok := int64ToUint(int64(v.Int()))
+			return ValueOf(o), ok
+		},
+		Uint8: func(v Value) (Value, bool) {
+			o, ok := int64ToUint8(int64(v.Int()))
+			return ValueOf(o), ok
+		},
+		Uint16: func(v Value) (Value, bool) {
+			o, ok := int64ToUint16(int64(v.Int()))
+			return ValueOf(o), ok
+		},
+		Uint32: func(v Value) (Value, bool) {
+			o, ok := int64ToUint32(int64(v.Int()))
+			return ValueOf(o), ok
+		},
+		Uint64: func(v Value) (Value, bool) {
+			o, ok := int64ToUint64(int64(v.Int()))
+			return ValueOf(o), ok
+		},
+		Uintptr: func(v Value) (Value, bool) {
+			o, ok := int64ToUintptr(int64(v.Int()))
+			return ValueOf(o), ok
+		},
+		Float32: func(v Value) (Value, bool) {
+			o, ok := int64ToFloat32(int64(v.Int()))
+			return ValueOf(o), ok
+		},
+		Float64: func(v Value) (Value, bool) {
+			o, ok := int64ToFloat64(int64(v.Int()))
+			return ValueOf(o), ok
+		},
+	},
+	Uint: {
+		Int: func(v Value) (Value, bool) {
+			o, ok := uintToInt(uint(v.Uint()))
+			return ValueOf(o), ok
+		},
+		Int8: func(v Value) (Value, bool) {
+			o, ok := uintToInt8(uint(v.Uint()))
+			return ValueOf(o), ok
+		},
+		Int16: func(v Value) (Value, bool) {
+			o, ok := uintToInt16(uint(v.Uint()))
+			return ValueOf(o), ok
+		},
+		Int32: func(v Value) (Value, bool) {
+			o, ok := uintToInt32(uint(v.Uint()))
+			return ValueOf(o), ok
+		},
+		Int64: func(v Value) (Value, bool) {
+			o, ok := uintToInt64(uint(v.Uint()))
+			return ValueOf(o), ok
+		},
+		Uint: func(v Value) (Value, bool) {
+			o, ok := uintToUint(uint(v.Uint()))
+			return ValueOf(o), ok
+		},
+		Uint8: func(v Value) (Value, bool) {
+			o, ok := uintToUint8(uint(v.Uint()))
+			return ValueOf(o), ok
+		},
+		Uint16: func(v Value) (Value, bool) {
+			o, ok := uintToUint16(uint(v.Uint()))
+			return ValueOf(o), ok
+		},
+		Uint32: func(v Value) (Value, bool) {
+			o, ok := uintToUint32(uint(v.Uint()))
+			return ValueOf(o), ok
+		},
+		Uint64: func(v Value) (Value, bool) {
+			o, ok := uintToUint64(uint(v.Uint()))
+			return ValueOf(o), ok
+		},
+		Uintptr: func(v Value) (Value, bool) {
+			o, ok := uintToUintptr(uint(v.Uint()))
+			return ValueOf(o), ok
+		},
+		Float32: func(v Value) (Value, bool) {
+			o, ok := uintToFloat32(uint(v.Uint()))
+			return ValueOf(o), ok
+		},
+		Float64: func(v Value) (Value, bool) {
+			o, ok := uintToFloat64(uint(v.Uint()))
+			return ValueOf(o), ok
+		},
+	},
+	Uint8: {
+		Int: func(v Value) (Value, bool) {
+			o, ok := uint8ToInt(uint8(v.Uint()))
+			return ValueOf(o), ok
+		},
+		Int8: func(v Value) (Value, bool) {
+			o, ok := uint8ToInt8(uint8(v.Uint()))
+			return ValueOf(o), ok
+		},
+		Int16: func(v Value) (Value, bool) {
+			o, ok := uint8ToInt16(uint8(v.Uint()))
+			return ValueOf(o), ok
+		},
+		Int32: func(v Value) (Value, bool) {
+			o, ok := uint8ToInt32(uint8(v.Uint()))
+			return ValueOf(o), ok
+		},
+		Int64: func(v Value) (Value, bool) {
+			o, ok := uint8ToInt64(uint8(v.Uint()))
+			return ValueOf(o), ok
+		},
+		Uint: func(v Value) (Value, bool) {
+			o, ok := uint8ToUint(uint8(v.Uint()))
+			return ValueOf(o), ok
+		},
+		Uint8: func(v Value) (Value, bool) {
+			o, ok := uint8ToUint8(uint8(v.Uint()))
+			return ValueOf(o), ok
+		},
+		Uint16: func(v Value) (Value, bool) {
+			o, ok := uint8ToUint16(uint8(v.Uint()))
+			return ValueOf(o), ok
+		},
+		Uint32: func(v Value) (Value, bool) {
+			o, ok := uint8ToUint32(uint8(v.Uint()))
+			return ValueOf(o), ok
+		},
+		Uint64: func(v Value) (Value, bool) {
+			o, ok := uint8ToUint64(uint8(v.Uint()))
+			return ValueOf(o), ok
+		},
+		Uintptr: func(v Value) (Value, bool) {
+			o, ok := uint8ToUintptr(uint8(v.Uint()))
+			return ValueOf(o), ok
+		},
+		Float32: func(v Value) (Value, bool) {
+			o, ok := uint8ToFloat32(uint8(v.Uint()))
+			return ValueOf(o), ok
+		},
+		Float64: func(v Value) (Value, bool) {
+			o, ok := uint8ToFloat64(uint8(v.Uint()))
+			return ValueOf(o), ok
+		},
+	},
+	Uint16: {
+		Int: func(v Value) (Value, bool) {
+			o, ok := uint16ToInt(uint16(v.Uint()))
+			return ValueOf(o), ok
+		},
+		Int8: func(v Value) (Value, bool) {
+			o, ok := uint16ToInt8(uint16(v.Uint()))
+			return ValueOf(o), ok
+		},
+		Int16: func(v Value) (Value, bool) {
+			o, ok := uint16ToInt16(uint16(v.Uint()))
+			return ValueOf(o), ok
+		},
+		Int32: func(v Value) (Value, bool) {
+			o, ok := uint16ToInt32(uint16(v.Uint()))
+			return ValueOf(o), ok
+		},
+		Int64: func(v Value) (Value, bool) {
+			o, ok := uint16ToInt64(uint16(v.Uint()))
+			return ValueOf(o), ok
+		},
+		Uint: func(v Value) (Value, bool) {
+			o, ok := uint16ToUint(uint16(v.Uint()))
+			return ValueOf(o), ok
+		},
+		Uint8: func(v Value) (Value, bool) {
+			o, ok := uint16ToUint8(uint16(v.Uint()))
+			return ValueOf(o), ok
+		},
+		Uint16: func(v Value) (Value, bool) {
+			o, ok := uint16ToUint16(uint16(v.Uint()))
+			return ValueOf(o), ok
+		},
+		Uint32: func(v Value) (Value, bool) {
+			o, ok := uint16ToUint32(uint16(v.Uint()))
+			return ValueOf(o), ok
+		},
+		Uint64: func(v Value) (Value, bool) {
+			o, ok := uint16ToUint64(uint16(v.Uint()))
+			return ValueOf(o), ok
+		},
+		Uintptr: func(v Value) (Value, bool) {
+			o, ok := uint16ToUintptr(uint16(v.Uint()))
+			return ValueOf(o), ok
+		},
+		Float32: func(v Value) (Value, bool) {
+			o, ok := uint16ToFloat32(uint16(v.Uint()))
+			return ValueOf(o), ok
+		},
+		Float64: func(v Value) (Value, bool) {
+			o, ok := uint16ToFloat64(uint16(v.Uint()))
+			return ValueOf(o), ok
+		},
+	},
+	Uint32: {
+		Int: func(v Value) (Value, bool) {
+			o, ok := uint32ToInt(uint32(v.Uint()))
+			return ValueOf(o), ok
+		},
+		Int8: func(v Value) (Value, bool) {
+			o, ok := uint32ToInt8(uint32(v.Uint()))
+			return ValueOf(o), ok
+		},
+		Int16: func(v Value) (Value, bool) {
+			o, ok := uint32ToInt16(uint32(v.Uint()))
+			return ValueOf(o), ok
+		},
+		Int32: func(v Value) (Value, bool) {
+			o, ok := uint32ToInt32(uint32(v.Uint()))
+			return ValueOf(o), ok
+		},
+		Int64: func(v Value) (Value, bool) {
+			o, ok := uint32ToInt64(uint32(v.Uint()))
+			return ValueOf(o), ok
+		},
+		Uint: func(v Value) (Value, bool) {
+			o, ok := uint32ToUint(uint32(v.Uint()))
+			return ValueOf(o), ok
+		},
+		Uint8: func(v Value) (Value, bool) {
+			o, ok := uint32ToUint8(uint32(v.Uint()))
+			return ValueOf(o), ok
+		},
+		Uint16: func(v Value) (Value, bool) {
+			o, ok := uint32ToUint16(uint32(v.Uint()))
+			return ValueOf(o), ok
+		},
+		Uint32: func(v Value) (Value, bool) {
+			o, ok := uint32ToUint32(uint32(v.Uint()))
+			return ValueOf(o), ok
+		},
+		Uint64: func(v Value) (Value, bool) {
+			o, ok := uint32ToUint64(uint32(v.Uint()))
+			return ValueOf(o), ok
+		},
+		Uintptr: func(v Value) (Value, bool) {
+			o, ok := uint32ToUintptr(uint32(v.Uint()))
+			return ValueOf(o), ok
+		},
+		Float32: func(v Value) (Value, bool) {
+			o, ok := uint32ToFloat32(uint32(v.Uint()))
+			return ValueOf(o), ok
+		},
+		Float64: func(v Value) (Value, bool) {
+			o, ok := uint32ToFloat64(uint32(v.Uint()))
+			return ValueOf(o), ok
+		},
+	},
+	Uint64: {
+		Int: func(v Value) (Value, bool) {
+			o, ok := uint64ToInt(uint64(v.Uint()))
+			return ValueOf(o), ok
+		},
+		Int8: func(v Value) (Value, bool) {
+			o, ok := uint64ToInt8(uint64(v.Uint()))
+			return ValueOf(o), ok
+		},
+		Int16: func(v Value) (Value, bool) {
+			o, ok := uint64ToInt16(uint64(v.Uint()))
+			return ValueOf(o), ok
+		},
+		Int32: func(v Value) (Value, bool) {
+			o, ok := uint64ToInt32(uint64(v.Uint()))
+			return ValueOf(o), ok
+		},
+		Int64: func(v Value) (Value, bool) {
+			o, ok := uint64ToInt64(uint64(v.Uint()))
+			return ValueOf(o), ok
+		},
+		Uint: func(v Value) (Value, bool) {
+			o, ok := uint64ToUint(uint64(v.Uint()))
+			return ValueOf(o), ok
+		},
+		Uint8: func(v Value) (Value, bool) {
+			o, ok := uint64ToUint8(uint64(v.Uint()))
+			return ValueOf(o), ok
+		},
+		Uint16: func(v Value) (Value, bool) {
+			o, ok := uint64ToUint16(uint64(v.Uint()))
+			return ValueOf(o), ok
+		},
+		Uint32: func(v Value) (Value, bool) {
+			o, ok := uint64ToUint32(uint64(v.Uint()))
+			return ValueOf(o), ok
+		},
+		Uint64: func(v Value) (Value, bool) {
+			o, ok := uint64ToUint64(uint64(v.Uint()))
+			return ValueOf(o), ok
+		},
+		Uintptr: func(v Value) (Value, bool) {
+			o, ok := uint64ToUintptr(uint64(v.Uint()))
+			return ValueOf(o), ok
+		},
+		Float32: func(v Value) (Value, bool) {
+			o, ok := uint64ToFloat32(uint64(v.Uint()))
+			return ValueOf(o), ok
+		},
+		Float64: func(v Value) (Value, bool) {
+			o, ok := uint64ToFloat64(uint64(v.Uint()))
+			return ValueOf(o), ok
+		},
+	},
+	Uintptr: {
+		Int: func(v Value) (Value, bool) {
+			o, ok := uintptrToInt(uintptr(v.Uint()))
+			return ValueOf(o), ok
+		},
+		Int8: func(v Value) (Value, bool) {
+			o, ok := uintptrToInt8(uintptr(v.Uint()))
+			return ValueOf(o), ok
+		},
+		Int16: func(v Value) (Value, bool) {
+			o, ok := uintptrToInt16(uintptr(v.Uint()))
+			return ValueOf(o), ok
+		},
+		Int32: func(v Value) (Value, bool) {
+			o, ok := uintptrToInt32(uintptr(v.Uint()))
+			return ValueOf(o), ok
+		},
+		Int64: func(v Value) (Value, bool) {
+			o, ok := uintptrToInt64(uintptr(v.Uint()))
+			return ValueOf(o), ok
+		},
+		Uint: func(v Value) (Value, bool) {
+			o, ok := uintptrToUint(uintptr(v.Uint()))
+			return ValueOf(o), ok
+		},
+		Uint8: func(v Value) (Value, bool) {
+			o, ok := uintptrToUint8(uintptr(v.Uint()))
+			return ValueOf(o), ok
+		},
+		Uint16: func(v Value) (Value, bool) {
+			o, ok := uintptrToUint16(uintptr(v.Uint()))
+			return ValueOf(o), ok
+		},
+		Uint32: func(v Value) (Value, bool) {
+			o, ok := uintptrToUint32(uintptr(v.Uint()))
+			return ValueOf(o), ok
+		},
+		Uint64: func(v Value) (Value, bool) {
+			o, ok := uintptrToUint64(uintptr(v.Uint()))
+			return ValueOf(o), ok
+		},
+		Uintptr: func(v Value) (Value, bool) {
+			o, ok := uintptrToUintptr(uintptr(v.Uint()))
+			return ValueOf(o), ok
+		},
+		Float32: func(v Value) (Value, bool) {
+			o, ok := uintptrToFloat32(uintptr(v.Uint()))
+			return ValueOf(o), ok
+		},
+		Float64: func(v Value) (Value, bool) {
+			o, ok := uintptrToFloat64(uintptr(v.Uint()))
+			return ValueOf(o), ok
+		},
+	},
+	Float32: {
+		Int: func(v Value) (Value, bool) {
+			o, ok := float32ToInt(float32(v.Float()))
+			return ValueOf(o), ok
+		},
+		Int8: func(v Value) (Value, bool) {
+			o, ok := float32ToInt8(float32(v.Float()))
+			return ValueOf(o), ok
+		},
+		Int16: func(v Value) (Value, bool) {
+			o, ok := float32ToInt16(float32(v.Float()))
+			return ValueOf(o), ok
+		},
+		Int32: func(v Value) (Value, bool) {
+			o, ok := float32ToInt32(float32(v.Float()))
+			return ValueOf(o), ok
+		},
+		Int64: func(v Value) (Value, bool) {
+			o, ok := float32ToInt64(float32(v.Float()))
+			return ValueOf(o), ok
+		},
+		Uint: func(v Value) (Value, bool) {
+			o, ok := float32ToUint(float32(v.Float()))
+			return ValueOf(o), ok
+		},
+		Uint8: func(v Value) (Value, bool) {
+			o, ok := float32ToUint8(float32(v.Float()))
+			return ValueOf(o), ok
+		},
+		Uint16: func(v Value) (Value, bool) {
+			o, ok := float32ToUint16(float32(v.Float()))
+			return ValueOf(o), ok
+		},
+		Uint32: func(v Value) (Value, bool) {
+			o, ok := float32ToUint32(float32(v.Float()))
+			return ValueOf(o), ok
+		},
+		Uint64: func(v Value) (Value, bool) {
+			o, ok := float32ToUint64(float32(v.Float()))
+			return ValueOf(o), ok
+		},
+		Uintptr: func(v Value) (Value, bool) {
+			o, ok := float32ToUintptr(float32(v.Float()))
+			return ValueOf(o), ok
+		},
+		Float32: func(v Value) (Value, bool) {
+			o, ok := float32ToFloat32(float32(v.Float()))
+			return ValueOf(o), ok
+		},
+		Float64: func(v Value) (Value, bool) {
+			o, ok := float32ToFloat64(float32(v.Float()))
+			return ValueOf(o), ok
+		},
+	},
+	Float64: {
+		Int: func(v Value) (Value, bool) {
+			o, ok := float64ToInt(float64(v.Float()))
+			return ValueOf(o), ok
+		},
+		Int8: func(v Value) (Value, bool) {
+			o, ok := float64ToInt8(float64(v.Float()))
+			return ValueOf(o), ok
+		},
+		Int16: func(v Value) (Value, bool) {
+			o, ok := float64ToInt16(float64(v.Float()))
+			return ValueOf(o), ok
+		},
+		Int32: func(v Value) (Value, bool) {
+			o, ok := float64ToInt32(float64(v.Float()))
+			return ValueOf(o), ok
+		},
+		Int64: func(v Value) (Value, bool) {
+			o, ok := float64ToInt64(float64(v.Float()))
+			return ValueOf(o), ok
+		},
+		Uint: func(v Value) (Value, bool) {
+			o, ok := float64ToUint(float64(v.Float()))
+			return ValueOf(o), ok
+		},
+		Uint8: func(v Value) (Value, bool) {
+			o, ok := float64ToUint8(float64(v.Float()))
+			return ValueOf(o), ok
+		},
+		Uint16: func(v Value) (Value, bool) {
+			o, ok := float64ToUint16(float64(v.Float()))
+			return ValueOf(o), ok
+		},
+		Uint32: func(v Value) (Value, bool) {
+			o, ok := float64ToUint32(float64(v.Float()))
+			return ValueOf(o), ok
+		},
+		Uint64: func(v Value) (Value, bool) {
+			o, ok := float64ToUint64(float64(v.Float()))
+			return ValueOf(o), ok
+		},
+		Uintptr: func(v Value) (Value, bool) {
+			o, ok := float64ToUintptr(float64(v.Float()))
+			return ValueOf(o), ok
+		},
+		Float32: func(v Value) (Value, bool) {
+			o, ok := float64ToFloat32(float64(v.Float()))
+			return ValueOf(o), ok
+		},
+		Float64: func(v Value) (Value, bool) {
+			o, ok := float64ToFloat64(float64(v.Float()))
+			return ValueOf(o), ok
+		},
+	},
+}
+
+func intToInt(v int) (int, bool) {
+	return v, true
+}
+
+func intToInt8(v int) (int8, bool) {
+	o := int8(v)
+	return o, int(o) == v
+}
+
+func intToInt16(v int) (int16, bool) {
+	o := int16(v)
+	return o, int(o) == v
+}
+
+func intToInt32(v int) (int32, bool) {
+	o := int32(v)
+	return o, int(o) == v
+}
+
+func intToInt64(v int) (int64, bool) {
+	o := int64(v)
+	return o, int(o) == v
+}
+
+func intToUint(v int) (uint, bool) {
+	o := uint(v)
+	return o, int(o) == v && v >= 0
+}
+
+func intToUint8(v int) (uint8, bool) {
+	o := uint8(v)
+	return o, int(o) == v && v >= 0
+}
+
+func intToUint16(v int) (uint16, bool) {
+	o := uint16(v)
+	return o, int(o) == v && v >= 0
+}
+
+func intToUint32(v int) (uint32, bool) {
+	o := uint32(v)
+	return o, int(o) == v && v >= 0
+}
+
+func intToUint64(v int) (uint64, bool) {
+	o := uint64(v)
+	return o, int(o) == v && v >= 0
+}
+
+func intToUintptr(v int) (uintptr, bool) {
+	o := uintptr(v)
+	return o, int(o) == v && v >= 0
+}
+
+func intToFloat32(v int) (float32, bool) {
+	o := float32(v)
+	return o, o >= math.MinInt && o < math.MaxInt+1 && int(o) == v
+}
+
+func intToFloat64(v int) (float64, bool) {
+	o := float64(v)
+	return o, o >= math.MinInt && o < math.MaxInt+1 && int(o) == v
+}
+
+func int8ToInt(v int8) (int, bool) {
+	o := int(v)
+	return o, int8(o) == v
+}
+
+func int8ToInt8(v int8) (int8, bool) {
+	return v, true
+}
+
+func int8ToInt16(v int8) (int16, bool) {
+	o := int16(v)
+	return o, int8(o) == v
+}
+
+func int8ToInt32(v int8) (int32, bool) {
+	o := int32(v)
+	return o, int8(o) == v
+}
+
+func int8ToInt64(v int8) (int64, bool) {
+	o := int64(v)
+	return o, int8(o) == v
+}
+
+func int8ToUint(v int8) (uint, bool) {
+	o := uint(v)
+	return o, int8(o) == v && v >= 0
+}
+
+func int8ToUint8(v int8) (uint8, bool) {
+	o := uint8(v)
+	return o, int8(o) == v && v >= 0
+}
+
+func int8ToUint16(v int8) (uint16, bool) {
+	o := uint16(v)
+	return o, int8(o) == v && v >= 0
+}
+
+func int8ToUint32(v int8) (uint32, bool) {
+	o := uint32(v)
+	return o, int8(o) == v && v >= 0
+}
+
+func int8ToUint64(v int8) (uint64, bool) {
+	o := uint64(v)
+	return o, int8(o) == v && v >= 0
+}
+
+func int8ToUintptr(v int8) (uintptr, bool) {
+	o := uintptr(v)
+	return o, int8(o) == v && v >= 0
+}
+
+func int8ToFloat32(v int8) (float32, bool) {
+	o := float32(v)
+	return o, o >= math.MinInt8 && o < math.MaxInt8+1 && int8(o) == v
+}
+
+func int8ToFloat64(v int8) (float64, bool) {
+	o := float64(v)
+	return o, o >= math.MinInt8 && o < math.MaxInt8+1 && int8(o) == v
+}
+
+func int16ToInt(v int16) (int, bool) {
+	o := int(v)
+	return o, int16(o) == v
+}
+
+func int16ToInt8(v int16) (int8, bool) {
+	o := int8(v)
+	return o, int16(o) == v
+}
+
+func int16ToInt16(v int16) (int16, bool) {
+	return v, true
+}
+
+func int16ToInt32(v int16) (int32, bool) {
+	o := int32(v)
+	return o, int16(o) == v
+}
+
+func int16ToInt64(v int16) (int64, bool) {
+	o := int64(v)
+	return o, int16(o) == v
+}
+
+func int16ToUint(v int16) (uint, bool) {
+	o := uint(v)
+	return o, int16(o) == v && v >= 0
+}
+
+func int16ToUint8(v int16) (uint8, bool) {
+	o := uint8(v)
+	return o, int16(o) == v && v >= 0
+}
+
+func int16ToUint16(v int16) (uint16, bool) {
+	o := uint16(v)
+	return o, int16(o) == v && v >= 0
+}
+
+func int16ToUint32(v int16) (uint32, bool) {
+	o := uint32(v)
+	return o, int16(o) == v && v >= 0
+}
+
+func int16ToUint64(v int16) (uint64, bool) {
+	o := uint64(v)
+	return o, int16(o) == v && v >= 0
+}
+
+func int16ToUintptr(v int16) (uintptr, bool) {
+	o := uintptr(v)
+	return o, int16(o) == v && v >= 0
+}
+
+func int16ToFloat32(v int16) (float32, bool) {
+	o := float32(v)
+	return o, o >= math.MinInt16 && o < math.MaxInt16+1 && int16(o) == v
+}
+
+func int16ToFloat64(v int16) (float64, bool) {
+	o := float64(v)
+	return o, o >= math.MinInt16 && o < math.MaxInt16+1 && int16(o) == v
+}
+
+func int32ToInt(v int32) (int, bool) {
+	o := int(v)
+	return o, int32(o) == v
+}
+
+func int32ToInt8(v int32) (int8, bool) {
+	o := int8(v)
+	return o, int32(o) == v
+}
+
+func int32ToInt16(v int32) (int16, bool) {
+	o := int16(v)
+	return o, int32(o) == v
+}
+
+func int32ToInt32(v int32) (int32, bool) {
+	return v, true
+}
+
+func int32ToInt64(v int32) (int64, bool) {
+	o := int64(v)
+	return o, int32(o) == v
+}
+
+func int32ToUint(v int32) (uint, bool) {
+	o := uint(v)
+	return o, int32(o) == v && v >= 0
+}
+
+func int32ToUint8(v int32) (uint8, bool) {
+	o := uint8(v)
+	return o, int32(o) == v && v >= 0
+}
+
+func int32ToUint16(v int32) (uint16, bool) {
+	o := uint16(v)
+	return o, int32(o) == v && v >= 0
+}
+
+func int32ToUint32(v int32) (uint32, bool) {
+	o := uint32(v)
+	return o, int32(o) == v && v >= 0
+}
+
+func int32ToUint64(v int32) (uint64, bool) {
+	o := uint64(v)
+	return o, int32(o) == v && v >= 0
+}
+
+func int32ToUintptr(v int32) (uintptr, bool) {
+	o := uintptr(v)
+	return o, int32(o) == v && v >= 0
+}
+
+func int32ToFloat32(v int32) (float32, bool) {
+	o := float32(v)
+	return o, o >= math.MinInt32 && o < math.MaxInt32+1 && int32(o) == v
+}
+
+func int32ToFloat64(v int32) (float64, bool) {
+	o := float64(v)
+	return o, o >= math.MinInt32 && o < math.MaxInt32+1 && int32(o) == v
+}
+
+func int64ToInt(v int64) (int, bool) {
+	o := int(v)
+	return o, int64(o) == v
+}
+
+func int64ToInt8(v int64) (int8, bool) {
+	o := int8(v)
+	return o, int64(o) == v
+}
+
+func int64ToInt16(v int64) (int16, bool) {
+	o := int16(v)
+	return o, int64(o) == v
+}
+
+func int64ToInt32(v int64) (int32, bool) {
+	o := int32(v)
+	return o, int64(o) == v
+}
+
+func int64ToInt64(v int64) (int64, bool) {
+	return v, true
+}
+
+func int64ToUint(v int64) (uint, bool) {
+	o := uint(v)
+	return o, int64(o) == v && v >= 0
+}
+
+func int64ToUint8(v int64) (uint8, bool) {
+	o := uint8(v)
+	return o, int64(o) == v && v >= 0
+}
+
+func int64ToUint16(v int64) (uint16, bool) {
+	o := uint16(v)
+	return o, int64(o) == v && v >= 0
+}
+
+func int64ToUint32(v int64) (uint32, bool) {
+	o := uint32(v)
+	return o, int64(o) == v && v >= 0
+}
+
+func int64ToUint64(v int64) (uint64, bool) {
+	o := uint64(v)
+	return o, int64(o) == v && v >= 0
+}
+
+func int64ToUintptr(v int64) (uintptr, bool) {
+	o := uintptr(v)
+	return o, int64(o) == v && v >= 0
+}
+
+func int64ToFloat32(v int64) (float32, bool) {
+	o := float32(v)
+	return o, o >= math.MinInt64 && o < math.MaxInt64+1 && int64(o) == v
+}
+
+func int64ToFloat64(v int64) (float64, bool) {
+	o := float64(v)
+	return o, o >= math.MinInt64 && o < math.MaxInt64+1 && int64(o) == v
+}
+
+func uintToInt(v uint) (int, bool) {
+	o := int(v)
+	return o, uint(o) == v && o >= 0
+}
+
+func uintToInt8(v uint) (int8, bool) {
+	o := int8(v)
+	return o, uint(o) == v && o >= 0
+}
+
+func uintToInt16(v uint) (int16, bool) {
+	o := int16(v)
+	return o, uint(o) == v && o >= 0
+}
+
+func uintToInt32(v uint) (int32, bool) {
+	o := int32(v)
+	return o, uint(o) == v && o >= 0
+}
+
+func uintToInt64(v uint) (int64, bool) {
+	o := int64(v)
+	return o, uint(o) == v && o >= 0
+}
+
+func uintToUint(v uint) (uint, bool) {
+	return v, true
+}
+
+func uintToUint8(v uint) (uint8, bool) {
+	o := uint8(v)
+	return o, uint(o) == v
+}
+
+func uintToUint16(v uint) (uint16, bool) {
+	o := uint16(v)
+	return o, uint(o) == v
+}
+
+func uintToUint32(v uint) (uint32, bool) {
+	o := uint32(v)
+	return o, uint(o) == v
+}
+
+func uintToUint64(v uint) (uint64, bool) {
+	o := uint64(v)
+	return o, uint(o) == v
+}
+
+func uintToUintptr(v uint) (uintptr, bool) {
+	o := uintptr(v)
+	return o, uint(o) == v
+}
+
+func uintToFloat32(v uint) (float32, bool) {
+	o := float32(v)
+	return o, o >= 0 && o < math.MaxUint+1 && uint(o) == v
+}
+
+func uintToFloat64(v uint) (float64, bool) {
+	o := float64(v)
+	return o, o >= 0 && o < math.MaxUint+1 && uint(o) == v
+}
+
+func uint8ToInt(v uint8) (int, bool) {
+	o := int(v)
+	return o, uint8(o) == v && o >= 0
+}
+
+func uint8ToInt8(v uint8) (int8, bool) {
+	o := int8(v)
+	return o, uint8(o) == v && o >= 0
+}
+
+func uint8ToInt16(v uint8) (int16, bool) {
+	o := int16(v)
+	return o, uint8(o) == v && o >= 0
+}
+
+func uint8ToInt32(v uint8) (int32, bool) {
+	o := int32(v)
+	return o, uint8(o) == v && o >= 0
+}
+
+func uint8ToInt64(v uint8) (int64, bool) {
+	o := int64(v)
+	return o, uint8(o) == v && o >= 0
+}
+
+func uint8ToUint(v uint8) (uint, bool) {
+	o := uint(v)
+	return o, uint8(o) == v
+}
+
+func uint8ToUint8(v uint8) (uint8, bool) {
+	return v, true
+}
+
+func uint8ToUint16(v uint8) (uint16, bool) {
+	o := uint16(v)
+	return o, uint8(o) == v
+}
+
+func uint8ToUint32(v uint8) (uint32, bool) {
+	o := uint32(v)
+	return o, uint8(o) == v
+}
+
+func uint8ToUint64(v uint8) (uint64, bool) {
+	o := uint64(v)
+	return o, uint8(o) == v
+}
+
+func uint8ToUintptr(v uint8) (uintptr, bool) {
+	o := uintptr(v)
+	return o, uint8(o) == v
+}
+
+func uint8ToFloat32(v uint8) (float32, bool) {
+	o := float32(v)
+	return o, o >= 0 && o < math.MaxUint8+1 && uint8(o) == v
+}
+
+func uint8ToFloat64(v uint8) (float64, bool) {
+	o := float64(v)
+	return o, o >= 0 && o < math.MaxUint8+1 && uint8(o) == v
+}
+
+func uint16ToInt(v uint16) (int, bool) {
+	o := int(v)
+	return o, uint16(o) == v && o >= 0
+}
+
+func uint16ToInt8(v uint16) (int8, bool) {
+	o := int8(v)
+	return o, uint16(o) == v && o >= 0
+}
+
+func uint16ToInt16(v uint16) (int16, bool) {
+	o := int16(v)
+	return o, uint16(o) == v && o >= 0
+}
+
+func uint16ToInt32(v uint16) (int32, bool) {
+	o := int32(v)
+	return o, uint16(o) == v && o >= 0
+}
+
+func uint16ToInt64(v uint16) (int64, bool) {
+	o := int64(v)
+	return o, uint16(o) == v && o >= 0
+}
+
+func uint16ToUint(v uint16) (uint, bool) {
+	o := uint(v)
+	return o, uint16(o) == v
+}
+
+func uint16ToUint8(v uint16) (uint8, bool) {
+	o := uint8(v)
+	return o, uint16(o) == v
+}
+
+func uint16ToUint16(v uint16) (uint16, bool) {
+	return v, true
+}
+
+func uint16ToUint32(v uint16) (uint32, bool) {
+	o := uint32(v)
+	return o, uint16(o) == v
+}
+
+func uint16ToUint64(v uint16) (uint64, bool) {
+	o := uint64(v)
+	return o, uint16(o) == v
+}
+
+func uint16ToUintptr(v uint16) (uintptr, bool) {
+	o := uintptr(v)
+	return o, uint16(o) == v
+}
+
+func uint16ToFloat32(v uint16) (float32, bool) {
+	o := float32(v)
+	return o, o >= 0 && o < math.MaxUint16+1 && uint16(o) == v
+}
+
+func uint16ToFloat64(v uint16) (float64, bool) {
+	o := float64(v)
+	return o, o >= 0 && o < math.MaxUint16+1 && uint16(o) == v
+}
+
+func uint32ToInt(v uint32) (int, bool) {
+	o := int(v)
+	return o, uint32(o) == v && o >= 0
+}
+
+func uint32ToInt8(v uint32) (int8, bool) {
+	o := int8(v)
+	return o, uint32(o) == v && o >= 0
+}
+
+func uint32ToInt16(v uint32) (int16, bool) {
+	o := int16(v)
+	return o, uint32(o) == v && o >= 0
+}
+
+func uint32ToInt32(v uint32) (int32, bool) {
+	o := int32(v)
+	return o, uint32(o) == v && o >= 0
+}
+
+func uint32ToInt64(v uint32) (int64, bool) {
+	o := int64(v)
+	return o, uint32(o) == v && o >= 0
+}
+
+func uint32ToUint(v uint32) (uint, bool) {
+	o := uint(v)
+	return o, uint32(o) == v
+}
+
+func uint32ToUint8(v uint32) (uint8, bool) {
+	o := uint8(v)
+	return o, uint32(o) == v
+}
+
+func uint32ToUint16(v uint32) (uint16, bool) {
+	o := uint16(v)
+	return o, uint32(o) == v
+}
+
+func uint32ToUint32(v uint32) (uint32, bool) {
+	return v, true
+}
+
+func uint32ToUint64(v uint32) (uint64, bool) {
+	o := uint64(v)
+	return o, uint32(o) == v
+}
+
+func uint32ToUintptr(v uint32) (uintptr, bool) {
+	o := uintptr(v)
+	return o, uint32(o) == v
+}
+
+func uint32ToFloat32(v uint32) (float32, bool) {
+	o := float32(v)
+	return o, o >= 0 && o < math.MaxUint32+1 && uint32(o) == v
+}
+
+func uint32ToFloat64(v uint32) (float64, bool) {
+	o := float64(v)
+	return o, o >= 0 && o < math.MaxUint32+1 && uint32(o) == v
+}
+
+func uint64ToInt(v uint64) (int, bool) {
+	o := int(v)
+	return o, uint64(o) == v && o >= 0
+}
+
+func uint64ToInt8(v uint64) (int8, bool) {
+	o := int8(v)
+	return o, uint64(o) == v && o >= 0
+}
+
+func uint64ToInt16(v uint64) (int16, bool) {
+	o := int16(v)
+	return o, uint64(o) == v && o >= 0
+}
+
+func uint64ToInt32(v uint64) (int32, bool) {
+	o := int32(v)
+	return o, uint64(o) == v && o >= 0
+}
+
+func uint64ToInt64(v uint64) (int64, bool) {
+	o := int64(v)
+	return o, uint64(o) == v && o >= 0
+}
+
+func uint64ToUint(v uint64) (uint, bool) {
+	o := uint(v)
+	return o, uint64(o) == v
+}
+
+func uint64ToUint8(v uint64) (uint8, bool) {
+	o := uint8(v)
+	return o, uint64(o) == v
+}
+
+func uint64ToUint16(v uint64) (uint16, bool) {
+	o := uint16(v)
+	return o, uint64(o) == v
+}
+
+func uint64ToUint32(v uint64) (uint32, bool) {
+	o := uint32(v)
+	return o, uint64(o) == v
+}
+
+func uint64ToUint64(v uint64) (uint64, bool) {
+	return v, true
+}
+
+func uint64ToUintptr(v uint64) (uintptr, bool) {
+	o := uintptr(v)
+	return o, uint64(o) == v
+}
+
+func uint64ToFloat32(v uint64) (float32, bool) {
+	o := float32(v)
+	return o, o >= 0 && o < math.MaxUint64+1 && uint64(o) == v
+}
+
+func uint64ToFloat64(v uint64) (float64, bool) {
+	o := float64(v)
+	return o, o >= 0 && o < math.MaxUint64+1 && uint64(o) == v
+}
+
+func uintptrToInt(v uintptr) (int, bool) {
+	o := int(v)
+	return o, uintptr(o) == v && o >= 0
+}
+
+func uintptrToInt8(v uintptr) (int8, bool) {
+	o := int8(v)
+	return o, uintptr(o) == v && o >= 0
+}
+
+func uintptrToInt16(v uintptr) (int16, bool) {
+	o := int16(v)
+	return o, uintptr(o) == v && o >= 0
+}
+
+func uintptrToInt32(v uintptr) (int32, bool) {
+	o := int32(v)
+	return o, uintptr(o) == v && o >= 0
+}
+
+func uintptrToInt64(v uintptr) (int64, bool) {
+	o := int64(v)
+	return o, uintptr(o) == v && o >= 0
+}
+
+func uintptrToUint(v uintptr) (uint, bool) {
+	o := uint(v)
+	return o, uintptr(o) == v
+}
+
+func uintptrToUint8(v uintptr) (uint8, bool) {
+	o := uint8(v)
+	return o, uintptr(o) == v
+}
+
+func uintptrToUint16(v uintptr) (uint16, bool) {
+	o := uint16(v)
+	return o, uintptr(o) == v
+}
+
+func uintptrToUint32(v uintptr) (uint32, bool) {
+	o := uint32(v)
+	return o, uintptr(o) == v
+}
+
+func uintptrToUint64(v uintptr) (uint64, bool) {
+	o := uint64(v)
+	return o, uintptr(o) == v
+}
+
+func uintptrToUintptr(v uintptr) (uintptr, bool) {
+	return v, true
+}
+
+func uintptrToFloat32(v uintptr) (float32, bool) {
+	o := float32(v)
+	return o, o >= 0 && o < math.MaxUint+1 && uintptr(o) == v
+}
+
+func uintptrToFloat64(v uintptr) (float64, bool) {
+	o := float64(v)
+	return o, o >= 0 && o < math.MaxUint+1 && uintptr(o) == v
+}
+
+func float32ToInt(v float32) (int, bool) {
+	if !(v >= math.MinInt && v < math.MaxInt+1) {
+		return 0, false
+	}
+	o := int(v)
+	return o, float32(o) == v
+}
+
+func float32ToInt8(v float32) (int8, bool) {
+	if !(v >= math.MinInt8 && v < math.MaxInt8+1) {
+		return 0, false
+	}
+	o := int8(v)
+	return o, float32(o) == v
+}
+
+func float32ToInt16(v float32) (int16, bool) {
+	if !(v >= math.MinInt16 && v < math.MaxInt16+1) {
+		return 0, false
+	}
+	o := int16(v)
+	return o, float32(o) == v
+}
+
+func float32ToInt32(v float32) (int32, bool) {
+	if !(v >= math.MinInt32 && v < math.MaxInt32+1) {
+		return 0, false
+	}
+	o := int32(v)
+	return o, float32(o) == v
+}
+
+func float32ToInt64(v float32) (int64, bool) {
+	if !(v >= math.MinInt64 && v < math.MaxInt64+1) {
+		return 0, false
+	}
+	o := int64(v)
+	return o, float32(o) == v
+}
+
+func float32ToUint(v float32) (uint, bool) {
+	if !(v >= 0 && v < math.MaxUint+1) {
+		return 0, false
+	}
+	o := uint(v)
+	return o, float32(o) == v
+}
+
+func float32ToUint8(v float32) (uint8, bool) {
+	if !(v >= 0 && v < math.MaxUint8+1) {
+		return 0, false
+	}
+	o := uint8(v)
+	return o, float32(o) == v
+}
+
+func float32ToUint16(v float32) (uint16, bool) {
+	if !(v >= 0 && v < math.MaxUint16+1) {
+		return 0, false
+	}
+	o := uint16(v)
+	return o, float32(o) == v
+}
+
+func float32ToUint32(v float32) (uint32, bool) {
+	if !(v >= 0 && v < math.MaxUint32+1) {
+		return 0, false
+	}
+	o := uint32(v)
+	return o, float32(o) == v
+}
+
+func float32ToUint64(v float32) (uint64, bool) {
+	if !(v >= 0 && v < math.MaxUint64+1) {
+		return 0, false
+	}
+	o := uint64(v)
+	return o, float32(o) == v
+}
+
+func float32ToUintptr(v float32) (uintptr, bool) {
+	if !(v >= 0 && v < math.MaxUint+1) {
+		return 0, false
+	}
+	o := uintptr(v)
+	return o, float32(o) == v
+}
+
+func float32ToFloat32(v float32) (float32, bool) {
+	return v, true
+}
+
+func float32ToFloat64(v float32) (float64, bool) {
+	o := float64(v)
+	return o, float32(o) == v || v != v
+}
+
+func float64ToInt(v float64) (int, bool) {
+	if !(v >= math.MinInt && v < math.MaxInt+1) {
+		return 0, false
+	}
+	o := int(v)
+	return o, float64(o) == v
+}
+
+func float64ToInt8(v float64) (int8, bool) {
+	if !(v >= math.MinInt8 && v < math.MaxInt8+1) {
+		return 0, false
+	}
+	o := int8(v)
+	return o, float64(o) == v
+}
+
+func float64ToInt16(v float64) (int16, bool) {
+	if !(v >= math.MinInt16 && v < math.MaxInt16+1) {
+		return 0, false
+	}
+	o := int16(v)
+	return o, float64(o) == v
+}
+
+func float64ToInt32(v float64) (int32, bool) {
+	if !(v >= math.MinInt32 && v < math.MaxInt32+1) {
+		return 0, false
+	}
+	o := int32(v)
+	return o, float64(o) == v
+}
+
+func float64ToInt64(v float64) (int64, bool) {
+	if !(v >= math.MinInt64 && v < math.MaxInt64+1) {
+		return 0, false
+	}
+	o := int64(v)
+	return o, float64(o) == v
+}
+
+func float64ToUint(v float64) (uint, bool) {
+	if !(v >= 0 && v < math.MaxUint+1) {
+		return 0, false
+	}
+	o := uint(v)
+	return o, float64(o) == v
+}
+
+func float64ToUint8(v float64) (uint8, bool) {
+	if !(v >= 0 && v < math.MaxUint8+1) {
+		return 0, false
+	}
+	o := uint8(v)
+	return o, float64(o) == v
+}
+
+func float64ToUint16(v float64) (uint16, bool) {
+	if !(v >= 0 && v < math.MaxUint16+1) {
+		return 0, false
+	}
+	o := uint16(v)
+	return o, float64(o) == v
+}
+
+func float64ToUint32(v float64) (uint32, bool) {
+	if !(v >= 0 && v < math.MaxUint32+1) {
+		return 0, false
+	}
+	o := uint32(v)
+	return o, float64(o) == v
+}
+
+func float64ToUint64(v float64) (uint64, bool) {
+	if !(v >= 0 && v < math.MaxUint64+1) {
+		return 0, false
+	}
+	o := uint64(v)
+	return o, float64(o) == v
+}
+
+func float64ToUintptr(v float64) (uintptr, bool) {
+	if !(v >= 0 && v < math.MaxUint+1) {
+		return 0, false
+	}
+	o := uintptr(v)
+	return o, float64(o) == v
+}
+
+func float64ToFloat32(v float64) (float32, bool) {
+	o := float32(v)
+	return o, float64(o) == v || v != v
+}
+
+func float64ToFloat64(v float64) (float64, bool) {
+	return v, true
+}
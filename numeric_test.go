@@ -0,0 +1,274 @@
+package conv
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	. "reflect"
+	"testing"
+)
+
+func TestNumericFuncs(t *testing.T) {
+	ints := []int64{math.MinInt64, math.MinInt32 - 1, math.MinInt32, -129, -128, -1, 0, 1, 127, 128, 255, 256, 1<<24 + 1, math.MaxInt32, 1<<53 + 1, math.MaxInt64}
+	uints := []uint64{math.MaxUint32, math.MaxUint32 + 1, 1<<63 + 1, math.MaxUint64}
+	floats := []float64{0, math.Copysign(0, -1), 0.5, -1.5, 1 << 24, 1<<24 + 1, 1 << 63, 1 << 64, 3.4028234663852886e38, 1e300, math.Inf(-1), math.NaN(), 0.1}
+
+	exact := func(v Value) *big.Float {
+		o := new(big.Float).SetPrec(0)
+		switch k := v.Kind(); {
+		case k >= Int && k <= Int64:
+			return o.SetInt64(v.Int())
+		case k >= Uint && k <= Uintptr:
+			return o.SetUint64(v.Uint())
+		}
+		return o.SetFloat64(v.Float())
+	}
+
+	for kSrc := Int; kSrc <= Float64; kSrc++ {
+		tSrc := numericTypes[kSrc]
+		var values []Value
+		add := func(v Value) {
+			if v.CanConvert(tSrc) {
+				values = append(values, v.Convert(tSrc))
+			}
+		}
+		switch {
+		case kSrc >= Int && kSrc <= Int64:
+			for _, n := range ints {
+				if !New(tSrc).Elem().OverflowInt(n) {
+					add(ValueOf(n))
+				}
+			}
+		case kSrc >= Uint && kSrc <= Uintptr:
+			for _, n := range append(uints, 0, 1, 255, 256, 1<<53+1) {
+				if !New(tSrc).Elem().OverflowUint(n) {
+					add(ValueOf(n))
+				}
+			}
+		default:
+			for _, f := range floats {
+				add(ValueOf(f))
+			}
+		}
+
+		for kDst := Int; kDst <= Float64; kDst++ {
+			for _, v := range values {
+				o, ok := numericFuncs[kSrc][kDst](v)
+				if o.Type() != numericTypes[kDst] {
+					t.Fatalf("%v to %v: wrong type %v", kSrc, kDst, o.Type())
+				}
+				var want bool
+				if f := v; (kSrc == Float32 || kSrc == Float64) && math.IsNaN(f.Float()) {
+					want = kDst == Float32 || kDst == Float64
+				} else {
+					want = exact(v).Cmp(exact(o)) == 0
+				}
+				if ok != want {
+					t.Errorf("%v %v to %v: got %v, %v", kSrc, v, kDst, o, ok)
+				}
+			}
+		}
+	}
+}
+
+func TestNumericExtrapolation(t *testing.T) {
+	// only covers int64 and float64
+	b := func(t Type) (Converter[string], bool) {
+		switch t {
+		case TypeEval[int64](), TypeEval[float64]():
+			return StrconvConverter(t)
+		}
+		return nil, false
+	}
+	c := NewConversion(NumericConverter(b))
+	type small int8
+	if o, err := c.Call(small(-3)); err != nil || o != "-3" {
+		t.Error("wrong int8", o, err)
+	}
+	if o, err := c.Call(float32(0.5)); err != nil || o != "0.5" {
+		t.Error("wrong float32", o, err)
+	}
+	if o, err := c.Call(uint64(1 << 40)); err != nil || o != "1099511627776" {
+		t.Error("wrong uint64", o, err)
+	}
+	if _, err := c.Call(uint64(math.MaxUint64)); !errors.Is(err, ErrOverflow) {
+		t.Error("expected overflow", err)
+	}
+	if _, err := c.Call("x"); !errors.Is(err, ErrInvalid) {
+		t.Error("expected invalid string", err)
+	}
+
+	ib := func(t Type) (Inverter[string], bool) {
+		if t != TypeEval[int64]() {
+			return nil, false
+		}
+		return StrconvInverter(t)
+	}
+	inv := NewInversion(NumericInverter(ib))
+	if o, err := As[small](inv, "-3"); err != nil || o != -3 {
+		t.Error("wrong inverted int8", o, err)
+	}
+	if o, err := As[float32](inv, "16777216"); err != nil || o != 1<<24 {
+		t.Error("wrong inverted float32", o, err)
+	}
+	if _, err := As[uint8](inv, "256"); !errors.Is(err, ErrOverflow) {
+		t.Error("expected inverted overflow", err)
+	}
+	if _, err := As[float32](inv, "16777217"); !errors.Is(err, ErrPrecisionLoss) {
+		t.Error("expected inverted precision loss", err)
+	}
+
+	if numericChart[Int8][0] != Int8 || numericChart[Int8][1] != Int16 || numericChart[Uint16][1] != Uint32 || numericChart[Float32][1] != Float64 {
+		t.Error("wrong chart", numericChart[Int8], numericChart[Uint16], numericChart[Float32])
+	}
+}
+
+func TestNumericPolicy(t *testing.T) {
+	b := func(t Type) (Converter[string], bool) {
+		if t.Kind() != Int64 && t.Kind() != Float64 {
+			return nil, false
+		}
+		return StrconvConverter(t)
+	}
+	p := NumericPolicy{Except: []Kind{Uint8, Float32}}
+
+	fills := NumericFills(b, p)
+	if len(fills) != 9 || fills[Int8] != Int64 || fills[Uint16] != Int64 || fills[Uint64] != Int64 {
+		t.Error("wrong fills", fills)
+	}
+	if _, ok := fills[Uint8]; ok {
+		t.Error("excepted kind should not be filled")
+	}
+	if all := NumericFills(b, NumericPolicy{}); len(all) != 11 || all[Float32] != Float64 {
+		t.Error("wrong default fills", all)
+	}
+
+	c := NewConversion(b, WithNumericPolicy(p))
+	if o, err := c.Call(int8(-2)); err != nil || o != "-2" {
+		t.Error("wrong int8", o, err)
+	}
+	if _, err := c.Call(uint8(2)); !errors.Is(err, ErrInvalid) {
+		t.Error("excepted kind should not convert", err)
+	}
+	inv := NewInversion(NumericInverterWith(StrconvInverter, p))
+	if o, err := As[uint8](inv, "7"); err != nil || o != 7 {
+		t.Error("covered kinds should not be affected", o, err)
+	}
+}
+
+func TestNumericRank(t *testing.T) {
+	b := func(t Type) (Converter[string], bool) {
+		switch t.Kind() {
+		case Int64, Float32, Uint8:
+			return StrconvConverter(t)
+		}
+		return nil, false
+	}
+
+	def := NumericFills(b, NumericPolicy{})
+	if def[Int16] != Int64 || def[Uint16] != Float32 || def[Float64] != Float32 {
+		t.Error("wrong default fills", def)
+	}
+	if custom := NumericFills(b, NumericPolicy{Rank: NumericRankDefault}); !DeepEqual(custom, def) {
+		t.Error("explicit default rank should match", custom, def)
+	}
+
+	closest := NumericFills(b, NumericPolicy{Rank: NumericRankClosestSize})
+	if closest[Int16] != Uint8 || closest[Int32] != Float32 || closest[Uint16] != Uint8 {
+		t.Error("wrong closest size fills", closest)
+	}
+
+	same := NumericFills(b, NumericPolicy{Rank: NumericRankSameNature})
+	if same[Int16] != Int64 || same[Uint16] != Uint8 || same[Float64] != Float32 {
+		t.Error("wrong same nature fills", same)
+	}
+	c := NewConversion(b, WithNumericPolicy(NumericPolicy{Rank: NumericRankSameNature}))
+	if _, err := c.Call(uint16(300)); !errors.Is(err, ErrOverflow) {
+		t.Error("uint16 should go through uint8", err)
+	}
+
+	// better ranked kinds that aren't covered must not affect the chosen one
+	ints := func(t Type) (Converter[string], bool) {
+		if t.Kind() != Int {
+			return nil, false
+		}
+		return StrconvConverter(t)
+	}
+	closestConv := NewConversion(ints, WithNumericPolicy(NumericPolicy{Rank: NumericRankClosestSize}))
+	if o, err := closestConv.Call(int32(5)); err != nil || o != "5" {
+		t.Error("wrong closest size conversion", o, err)
+	}
+	intsInv := func(t Type) (Inverter[string], bool) {
+		if t.Kind() != Int {
+			return nil, false
+		}
+		return StrconvInverter(t)
+	}
+	closestInv := NewInversion(intsInv, WithNumericPolicy(NumericPolicy{Rank: NumericRankClosestSize}))
+	if v, err := closestInv.Invert(TypeEval[int32](), "5"); err != nil || v.Interface() != int32(5) {
+		t.Error("wrong closest size inversion", v, err)
+	}
+}
+
+// BenchmarkNumericConverter compares native conversions with extrapolated ones, which add a kind pair table call, and the boxing of the intermediate value.
+func BenchmarkNumericConverter(b *testing.B) {
+	var base Builder[Converter[float64]] = func(t Type) (Converter[float64], bool) {
+		if t.Kind() != Float64 && t.Kind() != Int32 {
+			return nil, false
+		}
+		return func(v Value) (float64, error) {
+			if v.Kind() == Int32 {
+				return float64(v.Int()), nil
+			}
+			return v.Float(), nil
+		}, true
+	}
+	b.Run("native", func(b *testing.B) {
+		fn, _ := NumericConverter(base)(TypeEval[int32]())
+		v := ValueOf(int32(1000))
+		for i := 0; i < b.N; i++ {
+			fn(v)
+		}
+	})
+	b.Run("extrapolated", func(b *testing.B) {
+		fn, _ := NumericConverter(base)(TypeEval[int64]())
+		v := ValueOf(int64(1000))
+		for i := 0; i < b.N; i++ {
+			fn(v)
+		}
+	})
+}
+
+func TestNumericMapping(t *testing.T) {
+	type level uint8
+	m := NewMapper(NumericMapping)
+	var l level
+	if err := m.Map(&l, 200); err != nil || l != 200 {
+		t.Error("wrong level", l, err)
+	}
+	if err := m.Map(&l, -1); !errors.Is(err, ErrOverflow) {
+		t.Error("expected overflow", l, err)
+	}
+	var f float32
+	if err := m.Map(&f, l); err != nil || f != 200 {
+		t.Error("wrong float", f, err)
+	}
+	if _, ok := NumericMapping(MappingType(TypeEval[int](), TypeEval[int]())); ok {
+		t.Error("same kinds should be left to AssignMapping")
+	}
+	if _, ok := NumericMapping(MappingType(TypeEval[string](), TypeEval[int]())); ok {
+		t.Error("strings should not be covered")
+	}
+}
+
+func TestNumericMappingLossy(t *testing.T) {
+	m := NewMapper(NumericMapping)
+	m.SetLossy(true)
+	var i int8
+	if err := m.Map(&i, 300); err != nil || i != 44 {
+		t.Error("wrong wrapped value", i, err)
+	}
+	if err := m.Map(&i, 2.75); err != nil || i != 2 {
+		t.Error("wrong truncated value", i, err)
+	}
+}
@@ -0,0 +1,50 @@
+package conv
+
+// An Optional holds a value of type T, or nothing. Its zero value holds nothing.
+// Codebases may standardize on Optional, and convert at their edges: its layout makes it an option struct, so Null builds Mappings between Optionals and pointers, sql.Null types, other option structs, and plain values, where zero values count as absent if ZeroNull is set.
+// The name Option is taken by the options of Conversions and Inversions.
+type Optional[T any] struct {
+	Value T
+	Valid bool
+}
+
+// Some returns an Optional holding "v".
+func Some[T any](v T) Optional[T] {
+	return Optional[T]{Value: v, Valid: true}
+}
+
+// None returns an Optional holding nothing.
+func None[T any]() Optional[T] {
+	return Optional[T]{}
+}
+
+// OptionalOf returns an Optional holding the value pointed to by "p", or nothing if "p" is nil.
+func OptionalOf[T any](p *T) Optional[T] {
+	if p == nil {
+		return Optional[T]{}
+	}
+	return Some(*p)
+}
+
+// IsSome returns true if "x" holds a value.
+func (x Optional[T]) IsSome() bool {
+	return x.Valid
+}
+
+// Get returns the value held by "x", or the zero value and false.
+func (x Optional[T]) Get() (T, bool) {
+	if !x.Valid {
+		var zero T
+		return zero, false
+	}
+	return x.Value, true
+}
+
+// Ptr returns a pointer to a copy of the value held by "x", or nil.
+func (x Optional[T]) Ptr() *T {
+	if !x.Valid {
+		return nil
+	}
+	v := x.Value
+	return &v
+}
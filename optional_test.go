@@ -0,0 +1,50 @@
+package conv
+
+import (
+	"database/sql"
+	"testing"
+)
+
+func TestOptional(t *testing.T) {
+	if v, ok := Some(3).Get(); !ok || v != 3 {
+		t.Error("wrong some", v, ok)
+	}
+	if None[int]().IsSome() || OptionalOf[int](nil).IsSome() {
+		t.Error("none should be empty")
+	}
+	n := 4
+	if p := OptionalOf(&n).Ptr(); p == nil || p == &n || *p != 4 {
+		t.Error("wrong pointer", p)
+	}
+
+	type model struct {
+		Name  Optional[string]
+		Age   Optional[int]
+		Score Optional[float64]
+	}
+	type row struct {
+		Name  sql.NullString
+		Age   *int64
+		Score float64
+	}
+	null := &Null{ZeroNull: true}
+	m := NewDeepMapper(nil, null.Build)
+	null.Elems = m
+
+	var r row
+	if err := m.Map(&r, model{Name: Some("x"), Score: Some(1.5)}); err != nil {
+		t.Fatal(err)
+	}
+	if r.Name != (sql.NullString{String: "x", Valid: true}) || r.Age != nil || r.Score != 1.5 {
+		t.Error("wrong row", r)
+	}
+
+	var mod model
+	age := int64(30)
+	if err := m.Map(&mod, row{Age: &age}); err != nil {
+		t.Fatal(err)
+	}
+	if mod != (model{Age: Some(30)}) {
+		t.Error("wrong model", mod)
+	}
+}
@@ -0,0 +1,149 @@
+package conv
+
+import (
+	"fmt"
+	. "reflect"
+)
+
+// An Option configures a Conversion or Inversion, as passed to NewConversion and NewInversion.
+// Constructors without options keep the default behavior.
+type Option func(*options)
+
+type options struct {
+	fallback any // Converter[T] or Inverter[T], checked by the constructor
+	numeric  bool
+	policy   NumericPolicy
+	nils     NilPolicy
+	accept   func(Type) bool
+	metrics  Metrics
+	limit    int
+}
+
+// A NilPolicy decides how a Conversion handles nil values: nil interfaces, which have no type to build for, and nil pointers, which most Converters don't expect.
+// Nil slices, maps and other reference kinds are valid empty values, and always go to the Converter.
+// Nil pointers of the types accepted through WithNilAccepted go to the Converter too, regardless of the policy.
+type NilPolicy uint8
+
+const (
+	NilError NilPolicy = iota // fail with ErrNilSource; the default
+	NilZero                   // return the zero value of the destination
+)
+
+// ConverterFallback makes "fn" the Converter of types that the Builder doesn't cover, instead of one failing with ErrInvalid.
+func ConverterFallback[T any](fn Converter[T]) Option {
+	return func(o *options) {
+		o.fallback = fn
+	}
+}
+
+// InverterFallback makes "fn" the Inverter of types that the Builder doesn't cover, instead of one failing with ErrInvalid.
+func InverterFallback[T any](fn Inverter[T]) Option {
+	return func(o *options) {
+		o.fallback = fn
+	}
+}
+
+// WithNumeric extends the Builder to all numeric kinds, through NumericConverter or NumericInverter.
+func WithNumeric() Option {
+	return func(o *options) {
+		o.numeric = true
+	}
+}
+
+// WithNumericPolicy is the same as WithNumeric, with extrapolation configured by "p", through NumericConverterWith or NumericInverterWith.
+func WithNumericPolicy(p NumericPolicy) Option {
+	return func(o *options) {
+		o.numeric = true
+		o.policy = p
+	}
+}
+
+// WithLossyNumeric is the same as WithNumeric, but lets numeric values lose information, rather than fail, as set by NumericPolicy.Lossy.
+// Mappers are configured through SetLossy instead.
+func WithLossyNumeric() Option {
+	return func(o *options) {
+		o.numeric = true
+		o.policy.Lossy = true
+	}
+}
+
+// WithNil sets the nil policy of a Conversion. Has no effect on Inversions.
+func WithNil(p NilPolicy) Option {
+	return func(o *options) {
+		o.nils = p
+	}
+}
+
+// WithNilAccepted passes the nil pointers of the types that "fn" returns true for, or of all types if "fn" is nil, to their Converters, regardless of the nil policy.
+// For Converters that handle nil pointers themselves, such as those of CBORConverter, which encodes them as null. Has no effect on Inversions.
+func WithNilAccepted(fn func(Type) bool) Option {
+	return func(o *options) {
+		if fn == nil {
+			fn = func(Type) bool { return true }
+		}
+		o.accept = fn
+	}
+}
+
+// WithMetrics is the same as calling SetMetrics on the constructed value.
+func WithMetrics(m Metrics) Option {
+	return func(o *options) {
+		o.metrics = m
+	}
+}
+
+// WithCacheLimit is the same as calling SetCacheLimit on the constructed value.
+// Guards long running processes against unbounded type populations, such as from reflect.StructOf.
+func WithCacheLimit(n int) Option {
+	return func(o *options) {
+		o.limit = n
+	}
+}
+
+func applyOptions(opts []Option) options {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// configure applies the library wide options to "x".
+func (x *Library[T]) configure(o options) {
+	if o.fallback != nil {
+		fn, ok := o.fallback.(T)
+		if !ok {
+			panic(fmt.Sprintf("conv: fallback of type %T, expected %T", o.fallback, x.zero))
+		}
+		x.zero = fn
+	}
+	x.nils = o.nils
+	x.accept = o.accept
+	x.metrics = o.metrics
+	x.limit = o.limit
+}
+
+// isNil returns true if "v" is a nil pointer or interface, which the nil policy applies to.
+func isNil(v Value) bool {
+	switch v.Kind() {
+	case Interface, Pointer:
+		return v.IsNil()
+	}
+	return false
+}
+
+// acceptsNil returns true if the nil pointers of "t" go to their Converter, as set through WithNilAccepted.
+func (x *Library[T]) acceptsNil(t Type) bool {
+	return x.accept != nil && x.accept(t)
+}
+
+// nilValue applies the nil policy of a Conversion to a nil value of type "t", or a nil interface if nil.
+func (x *Conversion[T]) nilValue(t Type) (o T, err error) {
+	if (*Library[Converter[T]])(x).nils == NilZero {
+		return o, nil
+	}
+	if t == nil {
+		return o, ErrNilSource
+	}
+	return o, fmt.Errorf("%s: %w", describeType(t), ErrNilSource)
+}
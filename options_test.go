@@ -0,0 +1,197 @@
+package conv
+
+import (
+	"errors"
+	. "reflect"
+	"strconv"
+	"testing"
+)
+
+func TestOptions(t *testing.T) {
+	strings := func(t Type) (Converter[string], bool) {
+		if t.Kind() != String && t.Kind() != Int64 {
+			return nil, false
+		}
+		return StrconvConverter(t)
+	}
+
+	// defaults
+	c := NewConversion(strings)
+	if _, err := c.Call(int8(1)); !errors.Is(err, ErrInvalid) {
+		t.Error("int8 should not be covered", err)
+	}
+
+	c = NewConversion(strings,
+		WithNumeric(),
+		WithNil(NilZero),
+		ConverterFallback(Converter[string](func(v Value) (string, error) {
+			return "?", nil
+		})),
+	)
+	if o, err := c.Call(int8(1)); err != nil || o != "1" {
+		t.Error("int8 should be extrapolated", o, err)
+	}
+	if o, err := c.Call([]int{}); err != nil || o != "?" {
+		t.Error("wrong fallback", o, err)
+	}
+	if o, err := c.Call(nil); err != nil || o != "" {
+		t.Error("wrong nil result", o, err)
+	}
+
+	c = NewConversion(strings, WithNil(NilError))
+	if _, err := c.Call(nil); !errors.Is(err, ErrNilSource) {
+		t.Error("nil should fail", err)
+	}
+
+	inv := NewInversion(StrconvInverter, WithNumeric(), InverterFallback(Inverter[string](func(s string) (Value, error) {
+		return ValueOf(s), nil
+	})))
+	if o, err := As[uint8](inv, "7"); err != nil || o != 7 {
+		t.Error("wrong inversion", o, err)
+	}
+	if o, err := As[string](inv, "s"); err != nil || o != "s" {
+		t.Error("wrong inversion", o, err)
+	}
+
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("mismatched fallback should panic")
+			}
+		}()
+		NewInversion(StrconvInverter, ConverterFallback(Converter[string](nil)))
+	}()
+}
+
+func TestCacheLimit(t *testing.T) {
+	built := 0
+	c := NewConversion(func(t Type) (Converter[string], bool) {
+		built++
+		return StrconvConverter(t)
+	}, WithCacheLimit(1))
+
+	c.Call(1)
+	c.Call(1)
+	c.Call("a") // evicts int
+	c.Call("b")
+	if built != 2 {
+		t.Error("wrong build count", built)
+	}
+	c.Call(1)
+	if built != 3 {
+		t.Error("evicted type should be rebuilt", built)
+	}
+	if n := (*Library[Converter[string]])(c).Len(); n != 1 {
+		t.Error("wrong cached type count", n)
+	}
+}
+
+func TestNilPolicy(t *testing.T) {
+	called := 0
+	b := func(t Type) (Converter[string], bool) {
+		return func(v Value) (string, error) {
+			called++
+			return v.Elem().String(), nil
+		}, t.Kind() == Pointer
+	}
+
+	c := NewConversion(b)
+	if _, err := c.Call(nil); !errors.Is(err, ErrNilSource) {
+		t.Error("nil interface should fail", err)
+	}
+	if _, err := c.Call((*string)(nil)); !errors.Is(err, ErrNilSource) || err.Error() != "*string: nil source" || called != 0 {
+		t.Error("typed nil should fail before the converter", err)
+	}
+	s := "s"
+	if o, err := c.Call(&s); err != nil || o != "s" {
+		t.Error("wrong result", o, err)
+	}
+
+	c = NewConversion(b, WithNil(NilZero))
+	if o, err := c.Call((*string)(nil)); err != nil || o != "" || called != 1 {
+		t.Error("typed nil should be zero", o, err)
+	}
+
+	// nil slices and maps are valid empty values
+	lens := NewConversion(func(t Type) (Converter[string], bool) {
+		return func(v Value) (string, error) {
+			return strconv.Itoa(v.Len()), nil
+		}, t.Kind() == Slice || t.Kind() == Map
+	})
+	if o, err := lens.Call([]int(nil)); err != nil || o != "0" {
+		t.Error("nil slice should be converted", o, err)
+	}
+	if o, err := lens.Call(map[string]int(nil)); err != nil || o != "0" {
+		t.Error("nil map should be converted", o, err)
+	}
+
+	// handles and registered functions follow the policy too
+	c = NewConversion(b)
+	if _, err := c.For(TypeEval[*string]()).Call((*string)(nil)); !errors.Is(err, ErrNilSource) {
+		t.Error("handle should apply the policy", err)
+	}
+	Register(c, func(p *int) (string, error) {
+		return strconv.Itoa(*p), nil
+	})
+	if _, err := c.Call((*int)(nil)); !errors.Is(err, ErrNilSource) {
+		t.Error("registered function should not get nils", err)
+	}
+
+	// converters may handle nils themselves, which survives wrapping and extension
+	nulls := func(t Type) (Converter[string], bool) {
+		return func(v Value) (string, error) {
+			if v.IsNil() {
+				return "null", nil
+			}
+			return v.Elem().String(), nil
+		}, t.Kind() == Pointer
+	}
+	c = NewConversion(nulls, WithNilAccepted(func(t Type) bool {
+		return t == TypeEval[*string]()
+	}))
+	if o, err := c.Call((*string)(nil)); err != nil || o != "null" {
+		t.Error("typed nil should be accepted", o, err)
+	}
+	if _, err := c.Call((*int)(nil)); !errors.Is(err, ErrNilSource) {
+		t.Error("nils of other types should still fail", err)
+	}
+	if _, err := c.Call(nil); !errors.Is(err, ErrNilSource) {
+		t.Error("nil interface should still fail", err)
+	}
+	ext := c.Extend(ConverterIf(func(Value) bool { return true }, nulls, nulls))
+	if o, err := ext.Call((*string)(nil)); err != nil || o != "null" {
+		t.Error("extension should accept nils", o, err)
+	}
+	if o, err := ext.For(TypeEval[*string]()).Call((*string)(nil)); err != nil || o != "null" {
+		t.Error("handle should accept nils", o, err)
+	}
+}
+
+func TestLossyNumeric(t *testing.T) {
+	b := func(t Type) (Converter[string], bool) {
+		if t.Kind() != Int8 {
+			return nil, false
+		}
+		return StrconvConverter(t)
+	}
+	if _, err := NewConversion(b, WithNumeric()).Call(300); !errors.Is(err, ErrOverflow) {
+		t.Error("expected overflow", err)
+	}
+	c := NewConversion(b, WithLossyNumeric())
+	if o, err := c.Call(300); err != nil || o != "44" {
+		t.Error("wrong wrapped value", o, err)
+	}
+	if o, err := c.Call(-1.5); err != nil || o != "-1" {
+		t.Error("wrong truncated value", o, err)
+	}
+
+	inv := NewInversion(func(t Type) (Inverter[string], bool) {
+		if t.Kind() != Int64 {
+			return nil, false
+		}
+		return StrconvInverter(t)
+	}, WithLossyNumeric())
+	if o, err := As[uint8](inv, "-1"); err != nil || o != 255 {
+		t.Error("wrong inverted value", o, err)
+	}
+}
@@ -0,0 +1,85 @@
+package conv
+
+import (
+	. "reflect"
+	"sync"
+	"sync/atomic"
+)
+
+// Scratch values and buffers, created during conversions and dropped before they return, are reused through sync.Pools.
+// Pooled values never reach callers of this package, but custom Mappings are handed some of them as destinations, such as the key and element of map conversions.
+var pooling atomic.Bool
+
+func init() {
+	pooling.Store(true)
+}
+
+// SetPooling enables or disables the reuse of scratch values across conversions. Enabled by default.
+// Should be disabled if custom Mappings retain their destination Values, or pointers into them, beyond their call, as pooled destinations are reused by later conversions.
+// Values are pooled per type, for at most the first 1024 types seen; values of later types are left to the garbage collector, so that unbounded type populations, such as from reflect.StructOf, don't accumulate pools.
+func SetPooling(on bool) {
+	pooling.Store(on)
+}
+
+// scratchPools holds a *sync.Pool of settable Values for each type, up to scratchTypes types.
+var (
+	scratchPools sync.Map
+	scratchCount atomic.Int32
+)
+
+const scratchTypes = 1024
+
+// getScratch returns a zero settable Value of type "t", to be returned through putScratch once no longer used.
+func getScratch(t Type) Value {
+	if pooling.Load() {
+		if p, ok := scratchPools.Load(t); ok {
+			if v, ok := p.(*sync.Pool).Get().(Value); ok {
+				return v
+			}
+		}
+	}
+	return New(t).Elem()
+}
+
+// putScratch zeroes "v" and makes it available to getScratch.
+func putScratch(v Value) {
+	if !pooling.Load() {
+		return
+	}
+	v.SetZero()
+	p, ok := scratchPools.Load(v.Type())
+	if !ok {
+		if scratchCount.Add(1) > scratchTypes {
+			scratchCount.Add(-1)
+			return
+		}
+		var loaded bool
+		if p, loaded = scratchPools.LoadOrStore(v.Type(), new(sync.Pool)); loaded {
+			scratchCount.Add(-1)
+		}
+	}
+	p.(*sync.Pool).Put(v)
+}
+
+// bytesPool holds *[]byte scratch buffers.
+var bytesPool sync.Pool
+
+// getBytes returns a scratch buffer of length "n", to be returned through putBytes once no longer used.
+func getBytes(n int) *[]byte {
+	if pooling.Load() {
+		if b, ok := bytesPool.Get().(*[]byte); ok && cap(*b) >= n {
+			*b = (*b)[:n]
+			return b
+		}
+	}
+	b := make([]byte, n)
+	return &b
+}
+
+// putBytes makes "b" available to getBytes. Oversized buffers are dropped, so that a single large conversion doesn't pin its memory.
+func putBytes(b *[]byte) {
+	if !pooling.Load() || cap(*b) > 64<<10 {
+		return
+	}
+	bytesPool.Put(b)
+}
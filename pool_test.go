@@ -0,0 +1,74 @@
+package conv
+
+import (
+	. "reflect"
+	"testing"
+)
+
+func TestScratch(t *testing.T) {
+	v := getScratch(typeString)
+	if !v.CanSet() || v.String() != "" {
+		t.Fatal("wrong scratch value", v)
+	}
+	v.SetString("x")
+	putScratch(v)
+	if v := getScratch(typeString); v.String() != "" {
+		t.Error("scratch value not zeroed", v)
+	}
+
+	b := getBytes(4)
+	if len(*b) != 4 {
+		t.Error("wrong buffer length", len(*b))
+	}
+	putBytes(b)
+	if b := getBytes(100); len(*b) != 100 {
+		t.Error("wrong buffer length", len(*b))
+	}
+
+	SetPooling(false)
+	defer SetPooling(true)
+	v = getScratch(typeString)
+	v.SetString("y")
+	putScratch(v)
+	if v.String() != "y" {
+		t.Error("disabled pooling should leave values alone")
+	}
+
+	// conversions keep working without pools
+	m := NewDeepMapper(nil)
+	var o map[string]int64
+	if err := m.Map(&o, map[string]int{"a": 1}); err != nil || o["a"] != 1 {
+		t.Error("wrong result", o, err)
+	}
+}
+
+func BenchmarkMapConversion(b *testing.B) {
+	m := NewDeepMapper(nil)
+	src := map[string]int{"a": 1, "b": 2}
+	var o map[string]int64
+	for i := 0; i < b.N; i++ {
+		if err := m.Map(&o, src); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestScratchBound(t *testing.T) {
+	for i := 0; i < scratchTypes+8; i++ {
+		putScratch(New(ArrayOf(i, typeString)).Elem())
+	}
+	if n := scratchCount.Load(); n > scratchTypes {
+		t.Error("too many pooled types", n)
+	}
+	var n int
+	scratchPools.Range(func(_, _ any) bool {
+		n++
+		return true
+	})
+	if n > scratchTypes {
+		t.Error("too many pools", n)
+	}
+	if v := getScratch(ArrayOf(scratchTypes+4, typeString)); !v.CanSet() {
+		t.Error("unpooled types should still get scratch values")
+	}
+}
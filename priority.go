@@ -0,0 +1,52 @@
+package conv
+
+import (
+	. "reflect"
+	"sort"
+)
+
+// A PriorityScheme is a Scheme whose members are ordered by priority, rather than by when they were added.
+// Members with higher priorities are called first, and members of equal priority in the order they were added, so that specific Builders, such as one for time.Time, win over generic ones, such as one for all structs, regardless of which package registered first.
+type PriorityScheme[T any] struct {
+	m []priorityMember[T] // sorted by descending priority
+}
+
+type priorityMember[T any] struct {
+	b    Builder[T]
+	prio int
+}
+
+// Use adds "b" with priority 0.
+func (x *PriorityScheme[T]) Use(b Builder[T]) {
+	x.UseWithPriority(b, 0)
+}
+
+// UseWithPriority adds "b", to be called before the members of lower priority, and after those of equal or higher priority.
+// Negative priorities suit catch all fallbacks.
+func (x *PriorityScheme[T]) UseWithPriority(b Builder[T], prio int) {
+	i := sort.Search(len(x.m), func(i int) bool {
+		return x.m[i].prio < prio
+	})
+	x.m = append(x.m, priorityMember[T]{})
+	copy(x.m[i+1:], x.m[i:])
+	x.m[i] = priorityMember[T]{b, prio}
+}
+
+func (x *PriorityScheme[T]) Build(t Type) (T, bool) {
+	for _, m := range x.m {
+		if o, ok := m.b(t); ok {
+			return o, true
+		}
+	}
+	var o T
+	return o, false
+}
+
+// Scheme returns the members in the order they are called, such as for Scheme.Explain.
+func (x *PriorityScheme[T]) Scheme() Scheme[T] {
+	o := make(Scheme[T], len(x.m))
+	for i, m := range x.m {
+		o[i] = m.b
+	}
+	return o
+}
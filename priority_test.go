@@ -0,0 +1,51 @@
+package conv
+
+import (
+	. "reflect"
+	"testing"
+	"time"
+)
+
+func TestPriorityScheme(t *testing.T) {
+	named := func(name string, fn func(Type) bool) Builder[string] {
+		return func(t Type) (string, bool) {
+			return name, fn(t)
+		}
+	}
+	all := func(Type) bool { return true }
+	structs := func(t Type) bool { return t.Kind() == Struct }
+	times := func(t Type) bool { return t == TypeEval[time.Time]() }
+
+	var x PriorityScheme[string]
+	x.UseWithPriority(named("fallback", all), -1)
+	x.Use(named("structs", structs))
+	x.UseWithPriority(named("time", times), 10)
+	x.Use(named("structs2", structs))
+
+	for _, tc := range []struct {
+		t   Type
+		exp string
+	}{
+		{TypeEval[time.Time](), "time"},
+		{TypeEval[struct{}](), "structs"},
+		{TypeEval[int](), "fallback"},
+	} {
+		if o, ok := x.Build(tc.t); !ok || o != tc.exp {
+			t.Errorf("%v: expected %s, got %s", tc.t, tc.exp, o)
+		}
+	}
+
+	var order []string
+	for _, b := range x.Scheme() {
+		o, _ := b(TypeEval[time.Time]())
+		order = append(order, o)
+	}
+	if exp := []string{"time", "structs", "structs2", "fallback"}; !DeepEqual(order, exp) {
+		t.Error("wrong order", order)
+	}
+
+	var empty PriorityScheme[string]
+	if _, ok := empty.Build(TypeEval[int]()); ok {
+		t.Error("empty scheme should not cover")
+	}
+}
@@ -0,0 +1,307 @@
+package conv
+
+import (
+	"errors"
+	"fmt"
+	. "reflect"
+	"sort"
+	"strconv"
+	"sync"
+)
+
+var ErrOneof = errors.New("multiple oneof members set")
+
+// Proto is a set of Builders for Mappings between generated protobuf message structs and plain domain structs.
+// Generated code is recognized by convention, without depending on the protobuf module:
+//
+//   - messages are structs with "protobuf" tagged fields; their unexported bookkeeping fields are ignored, and nil message pointers map to zero values
+//   - wrapper messages (wrapperspb) are messages whose only tagged field is Value, and map to and from that value
+//   - enums are named int32 types, which already map numerically to other integer types; once registered through Enum, they also map by name to and from strings
+//   - oneofs are interface fields tagged "protobuf_oneof", flattened into the domain fields named after their members
+//
+// Oneof member types must be registered through Oneof, unless the message provides the legacy XXX_OneofWrappers method.
+type Proto struct {
+	Fields  *Mapper
+	Structs *StructMap
+
+	enums  map[Type]protoEnum
+	oneofs map[Type]bool // member pointer types
+	mux    sync.RWMutex
+}
+
+type protoEnum struct {
+	values map[string]int32
+	names  map[int32]string
+}
+
+// NewProtoMapper returns a deep Mapper with the Proto builders taking precedence, wiring "p" to it.
+// "sm" may be nil.
+func NewProtoMapper(p *Proto, sm *StructMap) *Mapper {
+	if sm == nil {
+		sm = &StructMap{}
+	}
+	o := NewDeepMapper(sm, p.Build)
+	p.Fields, p.Structs = o, sm
+	return o
+}
+
+// Enum registers the names of enum type "t", usually the generated <Enum>_value map.
+func (x *Proto) Enum(t Type, values map[string]int32) {
+	e := protoEnum{
+		values: values,
+		names:  make(map[int32]string, len(values)),
+	}
+	for name, n := range values {
+		e.names[n] = name
+	}
+
+	x.mux.Lock()
+	if x.enums == nil {
+		x.enums = make(map[Type]protoEnum)
+	}
+	x.enums[t] = e
+	x.mux.Unlock()
+}
+
+// Oneof registers oneof member types, given as nil pointers, like the generated (*Msg_Member)(nil).
+func (x *Proto) Oneof(members ...any) {
+	x.mux.Lock()
+	defer x.mux.Unlock()
+
+	for _, m := range members {
+		x.addMember(TypeOf(m))
+	}
+}
+
+func (x *Proto) addMember(t Type) {
+	if t == nil || t.Kind() != Pointer || t.Elem().Kind() != Struct || t.Elem().NumField() == 0 {
+		return
+	}
+	if x.oneofs == nil {
+		x.oneofs = make(map[Type]bool)
+	}
+	x.oneofs[t] = true
+}
+
+// members returns the registered member types of oneof interface "i", declared in message type "msg".
+func (x *Proto) members(msg, i Type) []Type {
+	x.mux.Lock()
+	defer x.mux.Unlock()
+
+	// legacy generated code lists members itself
+	if m, ok := PointerTo(msg).MethodByName("XXX_OneofWrappers"); ok && m.Type.NumIn() == 1 && m.Type.NumOut() == 1 {
+		out := m.Func.Call([]Value{New(msg)})[0]
+		if w, ok := out.Interface().([]any); ok {
+			for _, member := range w {
+				x.addMember(TypeOf(member))
+			}
+		}
+	}
+
+	var o []Type
+	for t := range x.oneofs {
+		if t.Implements(i) {
+			o = append(o, t)
+		}
+	}
+	// map iteration is random; keep member precedence stable
+	sort.Slice(o, func(a, b int) bool {
+		return o[a].String() < o[b].String()
+	})
+	return o
+}
+
+// Build combines the Wrapper, EnumName and Message Builders.
+func (x *Proto) Build(t Type) (Mapping, bool) {
+	if o, ok := x.Wrapper(t); ok {
+		return o, true
+	}
+	if o, ok := x.EnumName(t); ok {
+		return o, true
+	}
+	return x.Message(t)
+}
+
+// Wrapper builds Mappings between wrapper messages and non-struct types.
+func (x *Proto) Wrapper(t Type) (Mapping, bool) {
+	tDst, tSrc := t.Out(0), t.In(0)
+	switch {
+	case isProtoWrapper(tSrc) && tDst.Kind() != Struct:
+		f, _ := tSrc.FieldByName("Value")
+		ref := &mappingRef{m: x.Fields, dst: tDst, src: f.Type}
+		return func(dst, src Value, s *State) error {
+			return ref.get()(dst, src.FieldByIndex(f.Index), s)
+		}, true
+	case isProtoWrapper(tDst) && tSrc.Kind() != Struct:
+		f, _ := tDst.FieldByName("Value")
+		ref := &mappingRef{m: x.Fields, dst: f.Type, src: tSrc}
+		return func(dst, src Value, s *State) error {
+			return ref.get()(dst.FieldByIndex(f.Index), src, s)
+		}, true
+	}
+	return nil, false
+}
+
+// EnumName builds Mappings between registered enums and string kinds.
+// Unknown numbers convert to their decimal representation, like generated String methods do, while unknown names fail.
+func (x *Proto) EnumName(t Type) (Mapping, bool) {
+	tDst, tSrc := t.Out(0), t.In(0)
+
+	x.mux.RLock()
+	eDst, okDst := x.enums[tDst]
+	eSrc, okSrc := x.enums[tSrc]
+	x.mux.RUnlock()
+
+	switch {
+	case okSrc && tDst.Kind() == String:
+		return func(dst, src Value, s *State) error {
+			n := int32(src.Int())
+			name, ok := eSrc.names[n]
+			if !ok {
+				name = strconv.Itoa(int(n))
+			}
+			dst.SetString(name)
+			return nil
+		}, true
+	case okDst && tSrc.Kind() == String:
+		return func(dst, src Value, s *State) error {
+			n, ok := eDst.values[src.String()]
+			if !ok {
+				return fmt.Errorf("unknown %s name %q: %w", describeType(tDst), src.String(), ErrInvalid)
+			}
+			dst.SetInt(int64(n))
+			return nil
+		}, true
+	}
+	return nil, false
+}
+
+// Message builds Mappings between structs where at least one side is a message with oneof fields.
+// Other fields are handled by Structs. Messages without oneofs are left to Structs entirely.
+func (x *Proto) Message(t Type) (Mapping, bool) {
+	tDst, tSrc := t.Out(0), t.In(0)
+	if tDst.Kind() != Struct || tSrc.Kind() != Struct {
+		return nil, false
+	}
+	srcOneofs, dstOneofs := protoOneofs(tSrc), protoOneofs(tDst)
+	if len(srcOneofs) == 0 && len(dstOneofs) == 0 {
+		return nil, false
+	}
+
+	base, ok := x.Structs.Build(t)
+	if !ok {
+		return nil, false
+	}
+
+	// flattening: member values go into the destination field of the same name, looked up at call time since members are dynamic
+	flatten := func(dst, src Value, s *State) error {
+		for _, i := range srcOneofs {
+			iv := src.Field(i)
+			if iv.IsNil() || iv.Elem().IsNil() {
+				continue
+			}
+			w := iv.Elem().Elem()
+			name := w.Type().Field(0).Name
+			df := dst.FieldByName(name)
+			if !df.IsValid() || !df.CanSet() {
+				continue
+			}
+			if err := x.Fields.Get(df.Type(), w.Field(0).Type())(df, w.Field(0), s); err != nil {
+				return fmt.Errorf("oneof member %s: %w", name, err)
+			}
+		}
+		return nil
+	}
+
+	// unflattening: the non-zero source field matching a member becomes the oneof value; more than one is an error
+	type member struct {
+		src  []int
+		typ  Type // member pointer type
+		name string
+		ref  *mappingRef
+	}
+	type oneof struct {
+		field   int
+		members []member
+	}
+	var oneofs []oneof
+	for _, i := range dstOneofs {
+		o := oneof{field: i}
+		for _, mt := range x.members(tDst, tDst.Field(i).Type) {
+			mf := mt.Elem().Field(0)
+			sf, ok := tSrc.FieldByName(mf.Name)
+			if !ok || !sf.IsExported() {
+				continue
+			}
+			o.members = append(o.members, member{
+				src:  sf.Index,
+				typ:  mt,
+				name: mf.Name,
+				ref:  &mappingRef{m: x.Fields, dst: mf.Type, src: sf.Type},
+			})
+		}
+		oneofs = append(oneofs, o)
+	}
+	unflatten := func(dst, src Value, s *State) error {
+		for _, o := range oneofs {
+			var set bool
+			for _, m := range o.members {
+				sv, err := src.FieldByIndexErr(m.src)
+				if err != nil || sv.IsZero() {
+					continue
+				}
+				if set {
+					return fmt.Errorf("oneof member %s: %w", m.name, ErrOneof)
+				}
+				w := New(m.typ.Elem())
+				if err := m.ref.get()(w.Elem().Field(0), sv, s); err != nil {
+					return fmt.Errorf("oneof member %s: %w", m.name, err)
+				}
+				dst.Field(o.field).Set(w)
+				set = true
+			}
+		}
+		return nil
+	}
+
+	return func(dst, src Value, s *State) error {
+		if err := base(dst, src, s); err != nil {
+			return err
+		}
+		if err := flatten(dst, src, s); err != nil {
+			return err
+		}
+		return unflatten(dst, src, s)
+	}, true
+}
+
+// isProtoWrapper returns true if "t" is a message whose only tagged field is Value.
+func isProtoWrapper(t Type) bool {
+	if t.Kind() != Struct {
+		return false
+	}
+	n := 0
+	for i, m := 0, t.NumField(); i < m; i++ {
+		if _, ok := t.Field(i).Tag.Lookup("protobuf"); ok {
+			n++
+		}
+	}
+	f, ok := t.FieldByName("Value")
+	if !ok {
+		return false
+	}
+	_, tagged := f.Tag.Lookup("protobuf")
+	return n == 1 && tagged
+}
+
+// protoOneofs returns the indexes of the oneof fields of struct type "t".
+func protoOneofs(t Type) []int {
+	var o []int
+	for i, n := 0, t.NumField(); i < n; i++ {
+		f := t.Field(i)
+		if _, ok := f.Tag.Lookup("protobuf_oneof"); ok && f.Type.Kind() == Interface && f.IsExported() {
+			o = append(o, i)
+		}
+	}
+	return o
+}
@@ -0,0 +1,96 @@
+package conv
+
+import (
+	"testing"
+)
+
+type pbInt64Value struct {
+	state         struct{}
+	sizeCache     int32
+	unknownFields []byte
+
+	Value int64 `protobuf:"varint,1,opt,name=value,proto3"`
+}
+
+type pbColor int32
+
+var pbColor_value = map[string]int32{
+	"RED":  0,
+	"BLUE": 1,
+}
+
+type pbUser struct {
+	state         struct{}
+	sizeCache     int32
+	unknownFields []byte
+
+	Name    string           `protobuf:"bytes,1,opt,name=name,proto3"`
+	Age     *pbInt64Value    `protobuf:"bytes,2,opt,name=age,proto3"`
+	Color   pbColor          `protobuf:"varint,3,opt,name=color,proto3,enum=pbColor"`
+	Contact isPbUser_Contact `protobuf_oneof:"contact"`
+}
+
+type isPbUser_Contact interface {
+	isPbUser_Contact()
+}
+
+type pbUser_Email struct {
+	Email string `protobuf:"bytes,4,opt,name=email,proto3,oneof"`
+}
+
+type pbUser_Phone struct {
+	Phone int64 `protobuf:"varint,5,opt,name=phone,proto3,oneof"`
+}
+
+func (*pbUser_Email) isPbUser_Contact() {}
+func (*pbUser_Phone) isPbUser_Contact() {}
+
+func TestProto(t *testing.T) {
+	type user struct {
+		Name  string
+		Age   *int
+		Color string
+		Email string
+		Phone int64
+	}
+
+	p := &Proto{}
+	p.Enum(TypeEval[pbColor](), pbColor_value)
+	p.Oneof((*pbUser_Email)(nil), (*pbUser_Phone)(nil))
+	m := NewProtoMapper(p, nil)
+
+	var u user
+	err := m.Map(&u, &pbUser{
+		Name:    "a",
+		Age:     &pbInt64Value{Value: 30},
+		Color:   1,
+		Contact: &pbUser_Email{"a@b"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if u.Name != "a" || u.Age == nil || *u.Age != 30 || u.Color != "BLUE" || u.Email != "a@b" || u.Phone != 0 {
+		t.Error("proto to domain failed", u)
+	}
+
+	var msg *pbUser
+	u = user{Name: "b", Color: "RED", Phone: 5}
+	if err := m.Map(&msg, u); err != nil {
+		t.Fatal(err)
+	}
+	if msg.Name != "b" || msg.Age != nil || msg.Color != 0 {
+		t.Error("domain to proto failed", msg)
+	}
+	if ph, ok := msg.Contact.(*pbUser_Phone); !ok || ph.Phone != 5 {
+		t.Error("oneof failed", msg.Contact)
+	}
+
+	u.Email = "x"
+	if err := m.Map(&msg, u); err == nil {
+		t.Error("expected oneof error")
+	}
+	u.Email, u.Color = "", "GREEN"
+	if err := m.Map(&msg, u); err == nil {
+		t.Error("expected enum error")
+	}
+}
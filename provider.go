@@ -0,0 +1,87 @@
+package conv
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+)
+
+var ErrProvider = errors.New("unknown builder provider")
+
+// providers holds the registered builder providers by name, as func() Builder[T] values of various T.
+var providers = struct {
+	m   map[string]any
+	gen atomic.Uint64 // incremented by each registration
+	mux sync.RWMutex
+}{m: make(map[string]any)}
+
+// RegisterBuilderProvider makes the Builders returned by "fn" available under "name", so that third party modules can publish builder packs, such as for protobuf, SQL or decimal types.
+// Packs register from an init function, and applications enable them by blank import, in the manner of database/sql drivers:
+//
+//	import _ "example.com/convsql"
+//
+//	var scheme conv.Scheme[conv.Mapping]
+//	scheme.UseProviders("sql")
+//
+// "fn" is called each time the provider is used, so that stateful Builders are not shared.
+// Panics if "fn" is nil, or if "name" is already registered, for any Builder type.
+func RegisterBuilderProvider[T any](name string, fn func() Builder[T]) {
+	if fn == nil {
+		panic("conv: nil builder provider " + name)
+	}
+
+	providers.mux.Lock()
+	defer providers.mux.Unlock()
+
+	if _, ok := providers.m[name]; ok {
+		panic("conv: builder provider " + name + " registered twice")
+	}
+	providers.m[name] = fn
+	providers.gen.Add(1)
+}
+
+// BuilderProviders returns the sorted names of the registered providers of Builder[T].
+func BuilderProviders[T any]() []string {
+	providers.mux.RLock()
+	defer providers.mux.RUnlock()
+
+	var o []string
+	for _, name := range sortedKeys(providers.m) {
+		if _, ok := providers.m[name].(func() Builder[T]); ok {
+			o = append(o, name)
+		}
+	}
+	return o
+}
+
+// BuilderProvider returns a new Builder from the named provider, or false if it is not registered for Builder[T].
+func BuilderProvider[T any](name string) (Builder[T], bool) {
+	providers.mux.RLock()
+	fn, ok := providers.m[name].(func() Builder[T])
+	providers.mux.RUnlock()
+
+	if !ok {
+		return nil, false
+	}
+	return fn(), true
+}
+
+// UseProviders adds a Builder from each named provider, in order.
+// With no names, all registered providers of Builder[T] are used, in name order.
+// Fails with ErrProvider if a provider is not registered for Builder[T], in which case the Scheme is left unchanged.
+func (x *Scheme[T]) UseProviders(names ...string) error {
+	if names == nil {
+		names = BuilderProviders[T]()
+	}
+	bs := make([]Builder[T], len(names))
+	for i, name := range names {
+		b, ok := BuilderProvider[T](name)
+		if !ok {
+			return fmt.Errorf("%s: %w", name, ErrProvider)
+		}
+		bs[i] = b
+	}
+	*x = append(*x, bs...)
+	return nil
+}
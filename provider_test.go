@@ -0,0 +1,48 @@
+package conv
+
+import (
+	"errors"
+	. "reflect"
+	"testing"
+)
+
+func TestBuilderProvider(t *testing.T) {
+	RegisterBuilderProvider("test.upper", func() Builder[Converter[string]] {
+		return func(t Type) (Converter[string], bool) {
+			if t.Kind() != String {
+				return nil, false
+			}
+			return func(v Value) (string, error) {
+				return "<" + v.String() + ">", nil
+			}, true
+		}
+	})
+	RegisterBuilderProvider("test.mapping", func() Builder[Mapping] {
+		return AssignMapping
+	})
+
+	names := BuilderProviders[Converter[string]]()
+	if len(names) != 1 || names[0] != "test.upper" {
+		t.Error("wrong providers", names)
+	}
+
+	var scheme Scheme[Converter[string]]
+	if err := scheme.UseProviders("test.mapping"); !errors.Is(err, ErrProvider) || scheme != nil {
+		t.Error("expected provider error", err)
+	}
+	if err := scheme.UseProviders(); err != nil {
+		t.Fatal(err)
+	}
+	if o, err := NewConversion(scheme.Build).Call("x"); err != nil || o != "<x>" {
+		t.Error("wrong conversion", o, err)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("expected duplicate panic")
+		}
+	}()
+	RegisterBuilderProvider("test.upper", func() Builder[Mapping] {
+		return AssignMapping
+	})
+}
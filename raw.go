@@ -0,0 +1,42 @@
+package conv
+
+import (
+	"encoding/binary"
+	. "reflect"
+)
+
+// Raw is a set of Builders converting structs of fixed size kinds to and from their raw memory representation, as a reflection driven alternative to encoding/binary.
+// Fields are encoded in declaration order, with nested structs and arrays expanded in place, using the byte order of Order (little endian if nil).
+// Go alignment padding is reproduced, unless Packed is set; unexported and blank fields take up space, but are encoded as zero bytes.
+type Raw struct {
+	Order  binary.ByteOrder
+	Packed bool
+}
+
+func (x *Raw) layout(t Type) (*Layout, bool) {
+	order := x.Order
+	if order == nil {
+		order = binary.LittleEndian
+	}
+	o, err := newLayout(t, order, x.Packed)
+	return o, err == nil
+}
+
+// Converter is a Builder of []byte Converters for struct types made of bool and fixed size numeric kinds, or arrays and structs of them.
+func (x *Raw) Converter(t Type) (Converter[[]byte], bool) {
+	l, ok := x.layout(t)
+	if !ok {
+		return nil, false
+	}
+	return l.Converter(t)
+}
+
+// Inverter is a Builder of []byte Inverters for the types covered by Converter.
+// Inputs must hold at least the layout size; extra bytes are ignored.
+func (x *Raw) Inverter(t Type) (Inverter[[]byte], bool) {
+	l, ok := x.layout(t)
+	if !ok {
+		return nil, false
+	}
+	return l.Inverter(t)
+}
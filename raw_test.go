@@ -0,0 +1,65 @@
+package conv
+
+import (
+	"bytes"
+	"encoding/binary"
+	. "reflect"
+	"testing"
+)
+
+func TestRaw(t *testing.T) {
+	type vec struct {
+		X, Y float32
+	}
+	type packet struct {
+		Kind  uint8
+		Len   uint16
+		Pos   [2]vec
+		Flags [3]bool
+		Seq   int64
+	}
+
+	v := packet{
+		Kind:  1,
+		Len:   0x0203,
+		Pos:   [2]vec{{1, 2}, {-1, 0}},
+		Flags: [3]bool{true, false, true},
+		Seq:   -2,
+	}
+
+	// same as encoding/binary, which doesn't pad
+	var exp bytes.Buffer
+	binary.Write(&exp, binary.BigEndian, v)
+
+	x := &Raw{Order: binary.BigEndian, Packed: true}
+	b, err := NewConversion(x.Converter).Call(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(b, exp.Bytes()) {
+		t.Errorf("expected %x, got %x", exp.Bytes(), b)
+	}
+	o, err := As[packet](NewInversion(x.Inverter), b)
+	if err != nil || o != v {
+		t.Error("wrong packed round trip", o, err)
+	}
+
+	// padded, as in memory
+	y := &Raw{}
+	if b, err = NewConversion(y.Converter).Call(v); err != nil {
+		t.Fatal(err)
+	}
+	if len(b) != int(TypeOf(v).Size()) || b[2] != 0x03 || b[3] != 0x02 {
+		t.Errorf("wrong padded encoding %x", b)
+	}
+	if o, err = As[packet](NewInversion(y.Inverter), b); err != nil || o != v {
+		t.Error("wrong padded round trip", o, err)
+	}
+	if _, err = As[packet](NewInversion(y.Inverter), b[:10]); err == nil {
+		t.Error("expected short input error")
+	}
+
+	if _, ok := x.Converter(TypeEval[struct{ S string }]()); ok {
+		t.Error("strings accepted")
+	}
+}
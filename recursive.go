@@ -0,0 +1,123 @@
+package conv
+
+import (
+	. "reflect"
+	"sync"
+	"sync/atomic"
+)
+
+// BuilderRec is a Builder for composite types, which obtains the functions of their component types, such as elements or fields, through a Resolver, rather than reimplementing their conversion.
+type BuilderRec[T any] func(Type, *Resolver[T]) (T, bool)
+
+// A Resolver gives the members of a Recursive Builder access to the functions of other types, as built by the same members.
+type Resolver[T any] struct {
+	x *recursive[T]
+}
+
+// Covers returns true if the members cover "t", building its function if not yet built.
+// Types that are still being built, as met through recursive types such as linked lists, are assumed covered. If the assumption proves false, the types built on it are dropped, and built again when next requested.
+func (x *Resolver[T]) Covers(t Type) bool {
+	_, ok := x.x.build(t)
+	return ok
+}
+
+// Ref returns a function that obtains the function of "t", or the zero value if the members don't cover it.
+// The function must only be called once building is done, such as from the built function itself, since the types that it refers to may still be building; it caches its result after the first call.
+func (x *Resolver[T]) Ref(t Type) func() T {
+	var fn atomic.Pointer[T]
+	return func() T {
+		if o := fn.Load(); o != nil {
+			return *o
+		}
+		o := x.x.get(t)
+		fn.Store(&o)
+		return o
+	}
+}
+
+// Recursive returns a Builder trying "bs" in order, as a Scheme would, each of which may resolve other types through the same members.
+// The results are cached, so that each type is built once, no matter how many composite types it is part of.
+// Safe for concurrent use.
+func Recursive[T any](bs ...BuilderRec[T]) Builder[T] {
+	x := &recursive[T]{
+		bs:     bs,
+		m:      make(map[Type]recursiveEntry[T]),
+		active: make(map[Type]bool),
+	}
+	x.r = &Resolver[T]{x}
+	return x.Build
+}
+
+// Rec adapts "b" as a BuilderRec that doesn't use its Resolver, for use with Recursive, such as for scalar types.
+func Rec[T any](b Builder[T]) BuilderRec[T] {
+	return func(t Type, _ *Resolver[T]) (T, bool) {
+		return b(t)
+	}
+}
+
+type recursive[T any] struct {
+	bs []BuilderRec[T]
+	r  *Resolver[T]
+
+	mux    sync.Mutex
+	m      map[Type]recursiveEntry[T]
+	built  []Type        // cached types, in build order
+	active map[Type]bool // types being built, with whether they were assumed covered
+}
+
+type recursiveEntry[T any] struct {
+	v  T
+	ok bool
+}
+
+func (x *recursive[T]) Build(t Type) (T, bool) {
+	x.mux.Lock()
+	defer x.mux.Unlock()
+	return x.build(t)
+}
+
+func (x *recursive[T]) get(t Type) T {
+	o, _ := x.Build(t)
+	return o
+}
+
+// build returns the cached function of "t", building it first if needed.
+// Must be called with the lock held.
+func (x *recursive[T]) build(t Type) (T, bool) {
+	if e, ok := x.m[t]; ok {
+		return e.v, e.ok
+	}
+	if _, ok := x.active[t]; ok {
+		x.active[t] = true
+		var o T
+		return o, true
+	}
+
+	x.active[t] = false
+	mark := len(x.built)
+	var (
+		o  T
+		ok bool
+	)
+	for _, b := range x.bs {
+		if o, ok = b(t, x.r); ok {
+			break
+		}
+	}
+	assumed := x.active[t]
+	delete(x.active, t)
+
+	if !ok {
+		var zero T
+		o = zero
+		if assumed {
+			for _, u := range x.built[mark:] {
+				delete(x.m, u)
+			}
+			x.built = x.built[:mark]
+		}
+	}
+	x.m[t] = recursiveEntry[T]{o, ok}
+	x.built = append(x.built, t)
+	return o, ok
+}
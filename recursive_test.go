@@ -0,0 +1,111 @@
+package conv
+
+import (
+	. "reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+type recursiveList struct {
+	V    int
+	Next *recursiveList
+}
+
+type recursiveBad struct {
+	Next *recursiveBad
+	C    chan int
+}
+
+func TestRecursive(t *testing.T) {
+	builds := make(map[Type]int)
+	ints := func(t Type) (Converter[string], bool) {
+		if t.Kind() != Int {
+			return nil, false
+		}
+		builds[t]++
+		return func(v Value) (string, error) {
+			return strconv.Itoa(int(v.Int())), nil
+		}, true
+	}
+	slices := func(t Type, r *Resolver[Converter[string]]) (Converter[string], bool) {
+		if t.Kind() != Slice || !r.Covers(t.Elem()) {
+			return nil, false
+		}
+		builds[t]++
+		elem := r.Ref(t.Elem())
+		return func(v Value) (string, error) {
+			parts := make([]string, v.Len())
+			for i := range parts {
+				s, err := elem()(v.Index(i))
+				if err != nil {
+					return "", err
+				}
+				parts[i] = s
+			}
+			return "[" + strings.Join(parts, " ") + "]", nil
+		}, true
+	}
+	pointers := func(t Type, r *Resolver[Converter[string]]) (Converter[string], bool) {
+		if t.Kind() != Pointer || !r.Covers(t.Elem()) {
+			return nil, false
+		}
+		builds[t]++
+		elem := r.Ref(t.Elem())
+		return func(v Value) (string, error) {
+			if v.IsNil() {
+				return "nil", nil
+			}
+			return elem()(v.Elem())
+		}, true
+	}
+	structs := func(t Type, r *Resolver[Converter[string]]) (Converter[string], bool) {
+		if t.Kind() != Struct {
+			return nil, false
+		}
+		fields := make([]func() Converter[string], t.NumField())
+		for i := range fields {
+			if !r.Covers(t.Field(i).Type) {
+				return nil, false
+			}
+			fields[i] = r.Ref(t.Field(i).Type)
+		}
+		builds[t]++
+		return func(v Value) (string, error) {
+			parts := make([]string, len(fields))
+			for i, fn := range fields {
+				s, err := fn()(v.Field(i))
+				if err != nil {
+					return "", err
+				}
+				parts[i] = s
+			}
+			return "{" + strings.Join(parts, " ") + "}", nil
+		}, true
+	}
+
+	c := NewConversion(Recursive(Rec(ints), slices, pointers, structs))
+	l := &recursiveList{1, &recursiveList{2, nil}}
+	if o, err := c.Call([]*recursiveList{l, nil}); err != nil || o != "[{1 {2 nil}} nil]" {
+		t.Error("wrong list", o, err)
+	}
+	if o, err := c.Call([][]int{{1}, {2, 3}}); err != nil || o != "[[1] [2 3]]" {
+		t.Error("wrong nested slice", o, err)
+	}
+	for typ, n := range builds {
+		if n != 1 {
+			t.Error("wrong build count", typ, n)
+		}
+	}
+
+	// *recursiveBad is first built assuming that recursiveBad is covered, which its channel field disproves
+	if _, err := c.Call(recursiveBad{}); err == nil {
+		t.Error("struct with channel should not be covered")
+	}
+	if _, err := c.Call(&recursiveBad{}); err == nil {
+		t.Error("pointer to uncovered struct should not be covered")
+	}
+	if _, err := c.Call([]chan int{}); err == nil {
+		t.Error("slice of channels should not be covered")
+	}
+}
@@ -0,0 +1,126 @@
+package conv
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// A Version identifies a release of a named scheme, following semantic versioning: releases of the same Major version are compatible, with later Minor and Patch ones only adding behavior or fixing it.
+type Version struct {
+	Major, Minor, Patch int
+}
+
+// ParseVersion parses a version of the form "1.2.3", with an optional "v" prefix. Minor and Patch may be omitted.
+func ParseVersion(s string) (Version, error) {
+	parts := strings.Split(strings.TrimPrefix(s, "v"), ".")
+	if len(parts) > 3 {
+		return Version{}, fmt.Errorf("version %q: %w", s, ErrInvalid)
+	}
+	var n [3]int
+	for i, part := range parts {
+		var err error
+		if n[i], err = strconv.Atoi(part); err != nil || n[i] < 0 {
+			return Version{}, fmt.Errorf("version %q: %w", s, ErrInvalid)
+		}
+	}
+	return Version{n[0], n[1], n[2]}, nil
+}
+
+func (x Version) String() string {
+	return fmt.Sprintf("%d.%d.%d", x.Major, x.Minor, x.Patch)
+}
+
+// Less returns true if "x" precedes "v".
+func (x Version) Less(v Version) bool {
+	if x.Major != v.Major {
+		return x.Major < v.Major
+	}
+	if x.Minor != v.Minor {
+		return x.Minor < v.Minor
+	}
+	return x.Patch < v.Patch
+}
+
+// A Registry holds values, usually Conversions or Inversions, by name and Version, so that services can keep old conversion behaviors alive, such as for replaying historical data, while new code uses updated schemes.
+// Safe for concurrent use.
+type Registry[V any] struct {
+	m   map[string][]registryEntry[V] // sorted by version
+	mux sync.RWMutex
+}
+
+type registryEntry[V any] struct {
+	version Version
+	v       V
+}
+
+func NewRegistry[V any]() *Registry[V] {
+	return &Registry[V]{
+		m: make(map[string][]registryEntry[V]),
+	}
+}
+
+// Register adds "v" under "name" and "version".
+// Panics if the pair is already registered.
+func (x *Registry[V]) Register(name string, version Version, v V) {
+	x.mux.Lock()
+	defer x.mux.Unlock()
+
+	entries := x.m[name]
+	i := sort.Search(len(entries), func(i int) bool {
+		return !entries[i].version.Less(version)
+	})
+	if i < len(entries) && entries[i].version == version {
+		panic("conv: duplicate registration of " + name + " " + version.String())
+	}
+	entries = append(entries, registryEntry[V]{})
+	copy(entries[i+1:], entries[i:])
+	entries[i] = registryEntry[V]{version, v}
+	x.m[name] = entries
+}
+
+// Get returns the value registered under "name" and exactly "version".
+func (x *Registry[V]) Get(name string, version Version) (V, bool) {
+	x.mux.RLock()
+	defer x.mux.RUnlock()
+
+	for _, e := range x.m[name] {
+		if e.version == version {
+			return e.v, true
+		}
+	}
+	var o V
+	return o, false
+}
+
+// Latest returns the latest value registered under "name" that is compatible with "version": of the same Major version, and not preceding it.
+// Also returns the Version it was registered under.
+func (x *Registry[V]) Latest(name string, version Version) (V, Version, bool) {
+	x.mux.RLock()
+	defer x.mux.RUnlock()
+
+	entries := x.m[name]
+	for i := len(entries) - 1; i >= 0; i-- {
+		e := entries[i]
+		if e.version.Major == version.Major && !e.version.Less(version) {
+			return e.v, e.version, true
+		}
+	}
+	var o V
+	return o, Version{}, false
+}
+
+// Versions returns the versions registered under "name", in ascending order.
+func (x *Registry[V]) Versions(name string) []Version {
+	x.mux.RLock()
+	defer x.mux.RUnlock()
+
+	entries := x.m[name]
+	o := make([]Version, len(entries))
+	for i, e := range entries {
+		o[i] = e.version
+	}
+	return o
+}
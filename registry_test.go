@@ -0,0 +1,62 @@
+package conv
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseVersion(t *testing.T) {
+	for _, tc := range []struct {
+		s   string
+		exp Version
+	}{
+		{"1.2.3", Version{1, 2, 3}},
+		{"v2", Version{2, 0, 0}},
+		{"0.4", Version{0, 4, 0}},
+	} {
+		if v, err := ParseVersion(tc.s); err != nil || v != tc.exp {
+			t.Error(tc.s, v, err)
+		}
+	}
+	for _, s := range []string{"", "1.x", "1.2.3.4", "-1"} {
+		if _, err := ParseVersion(s); !errors.Is(err, ErrInvalid) {
+			t.Error(s, "should fail", err)
+		}
+	}
+}
+
+func TestRegistry(t *testing.T) {
+	r := NewRegistry[*Conversion[string]]()
+	v1 := NewConversion(StrconvConverter)
+	v11 := NewConversion(TextConverter)
+	v2 := NewConversion(StringerConverter)
+	r.Register("events", Version{1, 1, 0}, v11)
+	r.Register("events", Version{2, 0, 0}, v2)
+	r.Register("events", Version{1, 0, 0}, v1)
+
+	if o := r.Versions("events"); len(o) != 3 || o[0] != (Version{1, 0, 0}) || o[2] != (Version{2, 0, 0}) {
+		t.Error("wrong versions", o)
+	}
+	if o, ok := r.Get("events", Version{1, 0, 0}); !ok || o != v1 {
+		t.Error("wrong exact match")
+	}
+	if _, ok := r.Get("events", Version{1, 2, 0}); ok {
+		t.Error("unexpected exact match")
+	}
+	if o, v, ok := r.Latest("events", Version{1, 0, 0}); !ok || o != v11 || v != (Version{1, 1, 0}) {
+		t.Error("wrong latest compatible", v)
+	}
+	if _, _, ok := r.Latest("events", Version{1, 2, 0}); ok {
+		t.Error("no compatible version expected")
+	}
+	if _, _, ok := r.Latest("other", Version{}); ok {
+		t.Error("unknown name")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("duplicate should panic")
+		}
+	}()
+	r.Register("events", Version{2, 0, 0}, v2)
+}
@@ -0,0 +1,61 @@
+package conv
+
+import (
+	"errors"
+	. "reflect"
+	"strings"
+)
+
+// A Result is the outcome of a conversion that may succeed partially: a usable value, along with non-fatal failures, such as precision loss or fields set to defaults.
+type Result[T any] struct {
+	Value    T
+	Warnings []error
+}
+
+// Warnings is the error through which standard Converters report the warnings of a Result, alongside its value.
+// Callers that don't distinguish warnings treat them as failures. Conversion.CallResult separates them from hard errors.
+// Hard errors must not wrap Warnings.
+type Warnings []error
+
+func (x Warnings) Error() string {
+	s := make([]string, len(x))
+	for i, err := range x {
+		s[i] = err.Error()
+	}
+	return "warnings: " + strings.Join(s, "; ")
+}
+
+// Unwrap lets errors.Is and errors.As match the individual warnings, such as ErrPrecisionLoss.
+func (x Warnings) Unwrap() []error {
+	return x
+}
+
+// ResultConverter adapts Builder "b" of Converters returning Results into a Builder of standard Converters, which return the value of a Result, along with its warnings as a Warnings error if it has any.
+func ResultConverter[T any](b Builder[func(Value) (Result[T], error)]) Builder[Converter[T]] {
+	return func(t Type) (Converter[T], bool) {
+		fn, ok := b(t)
+		if !ok {
+			return nil, false
+		}
+		return func(v Value) (T, error) {
+			r, err := fn(v)
+			if err != nil {
+				return r.Value, err
+			}
+			if len(r.Warnings) > 0 {
+				return r.Value, Warnings(r.Warnings)
+			}
+			return r.Value, nil
+		}, true
+	}
+}
+
+// CallResult is the same as Call, but surfaces the warnings of the Converter separately from hard errors: a Warnings error is returned as the warnings of a Result holding the converted value, with a nil error.
+func (x *Conversion[T]) CallResult(v any) (Result[T], error) {
+	o, err := x.Call(v)
+	var w Warnings
+	if err != nil && errors.As(err, &w) {
+		return Result[T]{Value: o, Warnings: w}, nil
+	}
+	return Result[T]{Value: o}, err
+}
@@ -0,0 +1,50 @@
+package conv
+
+import (
+	"errors"
+	"fmt"
+	. "reflect"
+	"testing"
+)
+
+func TestResult(t *testing.T) {
+	// rounds floats, warning when they aren't whole
+	b := func(t Type) (func(Value) (Result[int64], error), bool) {
+		if t.Kind() != Float64 {
+			return nil, false
+		}
+		return func(v Value) (Result[int64], error) {
+			f := v.Float()
+			if f > 1e18 || f < -1e18 {
+				return Result[int64]{}, ErrOverflow
+			}
+			r := Result[int64]{Value: int64(f)}
+			if float64(r.Value) != f {
+				r.Warnings = append(r.Warnings, fmt.Errorf("%v: %w", f, ErrPrecisionLoss))
+			}
+			return r, nil
+		}, true
+	}
+	c := NewConversion(ResultConverter(b))
+
+	if r, err := c.CallResult(2.0); err != nil || r.Value != 2 || r.Warnings != nil {
+		t.Error("wrong exact result", r, err)
+	}
+	r, err := c.CallResult(2.5)
+	if err != nil || r.Value != 2 || len(r.Warnings) != 1 || !errors.Is(r.Warnings[0], ErrPrecisionLoss) {
+		t.Error("wrong lossy result", r, err)
+	}
+	if _, err := c.CallResult(1e20); !errors.Is(err, ErrOverflow) {
+		t.Error("hard errors should stay errors", err)
+	}
+	if _, err := c.CallResult("x"); !errors.Is(err, ErrInvalid) {
+		t.Error("uncovered types should fail", err)
+	}
+
+	// plain calls report warnings as errors, along with the value
+	o, err := c.Call(2.5)
+	var w Warnings
+	if o != 2 || !errors.As(err, &w) || !errors.Is(err, ErrPrecisionLoss) {
+		t.Error("wrong plain call", o, err)
+	}
+}
@@ -0,0 +1,37 @@
+//go:build conv_safe || purego
+
+package conv
+
+import (
+	"hash/fnv"
+	. "reflect"
+)
+
+// Safe mode replacements for unsafe.go. Callers check safeMode or hostLittleEndian first, so the functions are never reached.
+const safeMode = true
+
+// hostLittleEndian cannot be determined without unsafe, disabling direct copies.
+var hostLittleEndian = false
+
+func fieldAt(v Value, t Type, off uintptr) Value {
+	panic("conv: fieldAt in safe mode")
+}
+
+func viewAs(v Value, t Type) Value {
+	panic("conv: viewAs in safe mode")
+}
+
+// typeHash falls back to hashing the type string, which may allocate for unnamed types.
+func typeHash(t Type) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(t.String()))
+	return h.Sum64() * 0x9e3779b97f4a7c15
+}
+
+func copyAt(dst, src Value, dOff, sOff, n uintptr) {
+	panic("conv: copyAt in safe mode")
+}
+
+func rawBytes(v Value, n int) []byte {
+	panic("conv: rawBytes in safe mode")
+}
@@ -0,0 +1,135 @@
+package conv
+
+import (
+	. "reflect"
+	"runtime"
+)
+
+// A ShardedLibrary is a Library split into independent shards, selected by a hash of the looked up type.
+// Meant for very large and still growing type populations, such as in plugin hosts or script bridges, where a Library would copy its whole cache for every new type, and serialize all builds.
+// Here, caching a new type only copies and locks its own shard, so bursts of new types don't stall lookups or builds of other shards.
+//
+// The wrapped Builder may be called concurrently, for types of different shards.
+// Safe for concurrent use.
+type ShardedLibrary[T any] struct {
+	shards []*Library[T]
+	shift  uint // selects the high bits of the type hash
+}
+
+// NewShardedLibrary returns a ShardedLibrary with "n" shards, rounded up to a power of two, or GOMAXPROCS shards if "n" is not positive.
+// "b" and "zero" have the same role as for NewLibrary.
+func NewShardedLibrary[T any](b Builder[T], zero T, n int) *ShardedLibrary[T] {
+	if n <= 0 {
+		n = runtime.GOMAXPROCS(0)
+	}
+	bits := uint(0)
+	for 1<<bits < n {
+		bits++
+	}
+
+	x := &ShardedLibrary[T]{
+		shards: make([]*Library[T], 1<<bits),
+		shift:  64 - bits,
+	}
+	for i := range x.shards {
+		x.shards[i] = NewLibrary(b, zero)
+	}
+	return x
+}
+
+// Get is the same as Library.Get.
+func (x *ShardedLibrary[T]) Get(t Type) T {
+	o, _ := x.Lookup(t)
+	return o
+}
+
+// Lookup is the same as Library.Lookup.
+func (x *ShardedLibrary[T]) Lookup(t Type) (T, bool) {
+	return x.shard(t).Lookup(t)
+}
+
+// Range is the same as Library.Range, visiting each shard in turn.
+func (x *ShardedLibrary[T]) Range(fn func(Type, T) bool) {
+	stop := false
+	for _, shard := range x.shards {
+		shard.Range(func(t Type, v T) bool {
+			stop = !fn(t, v)
+			return !stop
+		})
+		if stop {
+			return
+		}
+	}
+}
+
+// Types is the same as Library.Types.
+func (x *ShardedLibrary[T]) Types() []Type {
+	var o []Type
+	for _, shard := range x.shards {
+		o = append(o, shard.Types()...)
+	}
+	sortTypes(o)
+	return o
+}
+
+// Invalidate is the same as Library.Invalidate.
+func (x *ShardedLibrary[T]) Invalidate(types ...Type) {
+	for _, t := range types {
+		x.shard(t).Invalidate(t)
+	}
+}
+
+// InvalidateFunc is the same as Library.InvalidateFunc.
+func (x *ShardedLibrary[T]) InvalidateFunc(fn func(Type) bool) {
+	for _, shard := range x.shards {
+		shard.InvalidateFunc(fn)
+	}
+}
+
+// Clear is the same as Library.Clear.
+func (x *ShardedLibrary[T]) Clear() {
+	for _, shard := range x.shards {
+		shard.Clear()
+	}
+}
+
+// SetCacheLimit is the same as Library.SetCacheLimit, with "n" split evenly between the shards, rounding up.
+// Since types spread unevenly, the total number of cached types may stay somewhat below "n".
+func (x *ShardedLibrary[T]) SetCacheLimit(n int) {
+	if n > 0 {
+		n = (n + len(x.shards) - 1) / len(x.shards)
+	}
+	for _, shard := range x.shards {
+		shard.SetCacheLimit(n)
+	}
+}
+
+// Len is the same as Library.Len.
+func (x *ShardedLibrary[T]) Len() int {
+	n := 0
+	for _, shard := range x.shards {
+		n += shard.Len()
+	}
+	return n
+}
+
+// Purge is the same as Library.Purge.
+func (x *ShardedLibrary[T]) Purge() int {
+	n := 0
+	for _, shard := range x.shards {
+		n += shard.Purge()
+	}
+	return n
+}
+
+// Shards returns the number of shards.
+func (x *ShardedLibrary[T]) Shards() int {
+	return len(x.shards)
+}
+
+func (x *ShardedLibrary[T]) shard(t Type) *Library[T] {
+	if x.shift == 64 {
+		return x.shards[0]
+	}
+	return x.shards[typeHash(t)>>x.shift]
+}
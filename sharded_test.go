@@ -0,0 +1,106 @@
+package conv
+
+import (
+	. "reflect"
+	"sync"
+	"testing"
+)
+
+func TestShardedLibrary(t *testing.T) {
+	var (
+		mux   sync.Mutex
+		built = make(map[Type]int)
+	)
+	lib := NewShardedLibrary(func(t Type) (Type, bool) {
+		mux.Lock()
+		built[t]++
+		mux.Unlock()
+		return t, t.Kind() != String
+	}, nil, 5)
+	if n := lib.Shards(); n != 8 {
+		t.Fatal("wrong shard count", n)
+	}
+
+	types := []Type{TypeOf(0), TypeOf(int8(0)), TypeOf(1.5), TypeOf([]int{}), TypeOf(map[int]int{}), TypeOf(struct{ A int }{}), TypeOf(&built)}
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for _, typ := range types {
+				if o, ok := lib.Lookup(typ); o != typ || !ok {
+					t.Error("wrong entry", o, typ)
+				}
+			}
+			if o, ok := lib.Lookup(TypeOf("")); o != nil || ok {
+				t.Error("string should not be covered")
+			}
+		}()
+	}
+	wg.Wait()
+	for typ, n := range built {
+		if n != 1 {
+			t.Error("wrong build count", typ, n)
+		}
+	}
+
+	// types should spread over shards
+	used := make(map[*Library[Type]]bool)
+	for _, typ := range types {
+		used[lib.shard(typ)] = true
+	}
+	if len(used) < 2 {
+		t.Error("all types in one shard")
+	}
+
+	if got := lib.Types(); len(got) != len(types)+1 {
+		t.Error("wrong cached types", got)
+	}
+	n := 0
+	lib.Range(func(Type, Type) bool {
+		n++
+		return n < 3
+	})
+	if n != 3 {
+		t.Error("Range should stop", n)
+	}
+
+	lib.Invalidate(TypeOf(0), TypeOf(""))
+	lib.Get(TypeOf(0))
+	if n := built[TypeOf(0)]; n != 2 {
+		t.Error("int should be rebuilt", n)
+	}
+	lib.InvalidateFunc(func(t Type) bool {
+		return t.Kind() == Slice
+	})
+	if got := lib.Types(); len(got) != len(types)-1 {
+		t.Error("wrong cached types after invalidation", got)
+	}
+	if n := lib.Purge(); n != 0 || lib.Len() != len(types)-1 {
+		t.Error("fresh types should survive", n, lib.Len())
+	}
+	if n := lib.Purge(); n != len(types)-1 || lib.Len() != 0 {
+		t.Error("unused types should be purged", n, lib.Len())
+	}
+
+	for _, typ := range types {
+		lib.Get(typ)
+	}
+	lib.Clear()
+	if got := lib.Types(); len(got) != 0 {
+		t.Error("cache should be empty", got)
+	}
+
+	limited := NewShardedLibrary(func(t Type) (int, bool) { return 1, true }, 0, 2)
+	limited.SetCacheLimit(2)
+	for _, typ := range types {
+		limited.Get(typ)
+	}
+	if n := limited.Len(); n > 2 {
+		t.Error("cache over limit", n)
+	}
+
+	if one := NewShardedLibrary(func(t Type) (int, bool) { return 1, true }, 0, 1); one.Shards() != 1 || one.Get(TypeOf(0)) != 1 {
+		t.Error("single shard failed")
+	}
+}
@@ -0,0 +1,108 @@
+package conv
+
+import (
+	. "reflect"
+	"strconv"
+)
+
+// StrconvConverter is a Builder of string Converters for boolean, numeric and string kinds, formatting through the strconv package.
+// Floats use the shortest representation that parses back exactly.
+func StrconvConverter(t Type) (Converter[string], bool) {
+	switch k := t.Kind(); k {
+	case Bool:
+		return func(v Value) (string, error) {
+			return strconv.FormatBool(v.Bool()), nil
+		}, true
+	case Int, Int8, Int16, Int32, Int64:
+		return func(v Value) (string, error) {
+			return strconv.FormatInt(v.Int(), 10), nil
+		}, true
+	case Uint, Uint8, Uint16, Uint32, Uint64, Uintptr:
+		return func(v Value) (string, error) {
+			return strconv.FormatUint(v.Uint(), 10), nil
+		}, true
+	case Float32, Float64:
+		size := t.Bits()
+		return func(v Value) (string, error) {
+			return strconv.FormatFloat(v.Float(), 'g', -1, size), nil
+		}, true
+	case Complex64, Complex128:
+		size := t.Bits()
+		return func(v Value) (string, error) {
+			return strconv.FormatComplex(v.Complex(), 'g', -1, size), nil
+		}, true
+	case String:
+		return func(v Value) (string, error) {
+			return v.String(), nil
+		}, true
+	}
+	return nil, false
+}
+
+// StrconvInverter is a Builder of string Inverters for boolean, numeric and string kinds, parsing through the strconv package.
+// Values that don't fit the destination kind fail with a *strconv.NumError.
+func StrconvInverter(t Type) (Inverter[string], bool) {
+	switch k := t.Kind(); k {
+	case Bool:
+		return func(v string) (Value, error) {
+			b, err := strconv.ParseBool(v)
+			if err != nil {
+				return Value{}, err
+			}
+			o := New(t).Elem()
+			o.SetBool(b)
+			return o, nil
+		}, true
+	case Int, Int8, Int16, Int32, Int64:
+		size := t.Bits()
+		return func(v string) (Value, error) {
+			n, err := strconv.ParseInt(v, 10, size)
+			if err != nil {
+				return Value{}, err
+			}
+			o := New(t).Elem()
+			o.SetInt(n)
+			return o, nil
+		}, true
+	case Uint, Uint8, Uint16, Uint32, Uint64, Uintptr:
+		size := t.Bits()
+		return func(v string) (Value, error) {
+			n, err := strconv.ParseUint(v, 10, size)
+			if err != nil {
+				return Value{}, err
+			}
+			o := New(t).Elem()
+			o.SetUint(n)
+			return o, nil
+		}, true
+	case Float32, Float64:
+		size := t.Bits()
+		return func(v string) (Value, error) {
+			f, err := strconv.ParseFloat(v, size)
+			if err != nil {
+				return Value{}, err
+			}
+			o := New(t).Elem()
+			o.SetFloat(f)
+			return o, nil
+		}, true
+	case Complex64, Complex128:
+		size := t.Bits()
+		return func(v string) (Value, error) {
+			c, err := strconv.ParseComplex(v, size)
+			if err != nil {
+				return Value{}, err
+			}
+			o := New(t).Elem()
+			o.SetComplex(c)
+			return o, nil
+		}, true
+	case String:
+		return func(v string) (Value, error) {
+			o := New(t).Elem()
+			o.SetString(v)
+			return o, nil
+		}, true
+	}
+	return nil, false
+}
@@ -0,0 +1,32 @@
+package conv
+
+import (
+	"errors"
+	. "reflect"
+	"testing"
+)
+
+func TestStrconv(t *testing.T) {
+	type myUint uint16
+
+	c := NewConversion(StrconvConverter)
+	inv := NewInversion(StrconvInverter)
+
+	for _, v := range []any{true, -12, myUint(7), float32(0.1), 2.5, complex(1, -2), "x"} {
+		s, err := c.Call(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		o, err := (*Library[Inverter[string]])(inv).Get(TypeOf(v))(s)
+		if err != nil || o.Interface() != v {
+			t.Errorf("%T round trip failed: %s %v %v", v, s, o, err)
+		}
+	}
+
+	if _, err := As[int8](inv, "300"); err == nil {
+		t.Error("expected range error")
+	}
+	if _, err := c.Call([]int{}); !errors.Is(err, ErrInvalid) {
+		t.Error("slices should not build")
+	}
+}
@@ -0,0 +1,102 @@
+package conv
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	. "reflect"
+)
+
+// An Encoder writes a stream of values to an io.Writer, converting them to their wire form through a Conversion.
+// Each value is framed by its length, as a uvarint prefix.
+type Encoder[T ~[]byte | ~string] struct {
+	w io.Writer
+	c *Conversion[T]
+}
+
+func NewEncoder[T ~[]byte | ~string](w io.Writer, c *Conversion[T]) *Encoder[T] {
+	return &Encoder[T]{
+		w: w,
+		c: c,
+	}
+}
+
+// Encode writes a single frame containing "v".
+func (x *Encoder[T]) Encode(v any) error {
+	o, err := x.c.Call(v)
+	if err != nil {
+		return err
+	}
+	b := binary.AppendUvarint(make([]byte, 0, binary.MaxVarintLen64+len(o)), uint64(len(o)))
+	_, err = x.w.Write(append(b, o...))
+	return err
+}
+
+// A Decoder reads a stream of values written by an Encoder, converting them back through an Inversion.
+// Reads don't go past the end of the current frame, so the underlying io.Reader may be shared with other protocols.
+type Decoder[T ~[]byte | ~string] struct {
+	r      io.Reader
+	br     io.ByteReader
+	inv    *Inversion[T]
+	maxLen int
+}
+
+func NewDecoder[T ~[]byte | ~string](r io.Reader, inv *Inversion[T]) *Decoder[T] {
+	return &Decoder[T]{
+		r:   r,
+		br:  asByteReader(r),
+		inv: inv,
+	}
+}
+
+// SetMaxLen caps frame lengths, failing with ErrTooLarge beyond "n" bytes, before allocating the frame.
+// A guard for untrusted streams, whose length prefixes could otherwise request arbitrarily large allocations. Zero or less means no limit, which is the default.
+func (x *Decoder[T]) SetMaxLen(n int) {
+	x.maxLen = n
+}
+
+// Decode reads the next frame into the value pointed to by "dst".
+// Returns io.EOF if the stream ends cleanly, before the start of a frame.
+func (x *Decoder[T]) Decode(dst any) error {
+	d := ValueOf(dst)
+	if d.Kind() != Pointer || d.IsNil() {
+		return fmt.Errorf("decode destination %T: %w", dst, ErrInvalid)
+	}
+
+	b, err := x.Next()
+	if err != nil {
+		return err
+	}
+	v, err := x.inv.Invert(d.Type().Elem(), b)
+	if err != nil {
+		return err
+	}
+	d.Elem().Set(v)
+	return nil
+}
+
+// Next reads the next frame, without decoding it.
+func (x *Decoder[T]) Next() (T, error) {
+	var o T
+	// io.EOF only if no bytes were read
+	n, err := binary.ReadUvarint(x.br)
+	if err != nil {
+		return o, err
+	}
+	if n > math.MaxInt {
+		return o, fmt.Errorf("frame length %d: %w", n, ErrOverflow)
+	}
+	if err := checkLen(n, x.maxLen); err != nil {
+		return o, fmt.Errorf("frame %w", err)
+	}
+
+	b := make([]byte, n)
+	if _, err := io.ReadFull(x.r, b); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return o, err
+	}
+	return T(b), nil
+}
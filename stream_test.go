@@ -0,0 +1,58 @@
+package conv
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+)
+
+func TestStream(t *testing.T) {
+	type msg struct {
+		ID   int
+		Body string
+	}
+
+	var buf bytes.Buffer
+	enc := NewEncoder(&buf, NewConversion(CBORConverter))
+	for _, v := range []any{msg{1, "a"}, msg{2, "b"}, 3} {
+		if err := enc.Encode(v); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// unsupported values don't write partial frames
+	if err := enc.Encode(make(chan int)); err == nil {
+		t.Error("expected unsupported value error")
+	}
+
+	dec := NewDecoder(&buf, NewInversion(CBORInverter))
+	var m msg
+	for i := 1; i <= 2; i++ {
+		if err := dec.Decode(&m); err != nil {
+			t.Fatal(err)
+		}
+		if m.ID != i {
+			t.Error("wrong message", m)
+		}
+	}
+	var n uint8
+	if err := dec.Decode(&n); err != nil || n != 3 {
+		t.Error("wrong number", n, err)
+	}
+	if err := dec.Decode(&n); err != io.EOF {
+		t.Error("expected EOF, got", err)
+	}
+
+	// truncated frame
+	dec = NewDecoder(bytes.NewReader([]byte{5, 1, 2}), NewInversion(CBORInverter))
+	if err := dec.Decode(&n); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Error("expected unexpected EOF, got", err)
+	}
+
+	// oversized frames fail before allocating
+	dec = NewDecoder(bytes.NewReader([]byte{0xff, 0xff, 0xff, 0xff, 0x0f}), NewInversion(CBORInverter))
+	dec.SetMaxLen(1 << 20)
+	if err := dec.Decode(&n); !errors.Is(err, ErrTooLarge) {
+		t.Error("expected too large, got", err)
+	}
+}
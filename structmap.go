@@ -0,0 +1,719 @@
+package conv
+
+import (
+	"fmt"
+	. "reflect"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/blitz-frost/conv/wrap"
+)
+
+// A Mapping writes the conversion of "src" into "dst", which must be settable.
+// Used when both the source and destination types are only known at runtime.
+// Composite Mappings must pass "s" along to the Mappings of their elements.
+type Mapping func(dst, src Value, s *State) error
+
+// A State carries information across the nested Mappings of a single top level conversion.
+// A nil *State is valid, and disables all tracking.
+type State struct {
+	visited map[visitKey]Value
+	path    map[uintptr]bool // source pointers being mapped into values, which cannot hold cycles
+	depth   int
+	limit   int              // maximum depth, if positive
+	share   bool             // track slices and maps as well as pointers
+	strict  bool             // set through Mapper.SetStrict
+	lossy   bool             // set through Mapper.SetLossy
+	alloc   func(Type) Value // allocates pointers to new values; nil for the heap
+	data    any
+}
+
+type visitKey struct {
+	ptr uintptr
+	dst Type
+}
+
+// Visited returns the destination previously recorded for source pointer "src" and destination type "tDst".
+// Sources can also be slices or maps, when the Mapper shares them; see Mapper.SetSharing.
+func (x *State) Visited(src Value, tDst Type) (Value, bool) {
+	if x == nil || x.visited == nil {
+		return Value{}, false
+	}
+	o, ok := x.visited[visitKey{src.Pointer(), tDst}]
+	return o, ok
+}
+
+// Visit records "dst" as the conversion of source pointer "src".
+// Should be called before mapping the pointed values, so that cycles resolve to "dst".
+func (x *State) Visit(src, dst Value) {
+	if x == nil {
+		return
+	}
+	if x.visited == nil {
+		x.visited = make(map[visitKey]Value)
+	}
+	x.visited[visitKey{src.Pointer(), dst.Type()}] = dst
+}
+
+// descend enters a nested value, failing with ErrDepth past the depth limit of the State.
+// Must be paired with ascend on success.
+func (x *State) descend() error {
+	if x == nil {
+		return nil
+	}
+	if x.limit > 0 && x.depth >= x.limit {
+		return fmt.Errorf("depth %d: %w", x.limit, ErrDepth)
+	}
+	x.depth++
+	return nil
+}
+
+func (x *State) ascend() {
+	if x != nil {
+		x.depth--
+	}
+}
+
+// sharing returns true if slices and maps should be tracked through Visit, like pointers.
+func (x *State) sharing() bool {
+	return x != nil && x.share
+}
+
+// enter marks source pointer "src" as being mapped into a value, failing with ErrCycle if it already is, as the value would have to contain itself.
+// Must be paired with leave on success.
+func (x *State) enter(src Value) error {
+	if x == nil {
+		return nil
+	}
+	ptr := src.Pointer()
+	if x.path[ptr] {
+		return fmt.Errorf("%s: %w", describeType(src.Type()), ErrCycle)
+	}
+	if x.path == nil {
+		x.path = make(map[uintptr]bool)
+	}
+	x.path[ptr] = true
+	return nil
+}
+
+func (x *State) leave(src Value) {
+	if x != nil {
+		delete(x.path, src.Pointer())
+	}
+}
+
+// Data returns the user data of the conversion, as given to Mapper.MapWith, or nil.
+func (x *State) Data() any {
+	if x == nil {
+		return nil
+	}
+	return x.data
+}
+
+// Strict returns true if struct fields must match exactly, as set through Mapper.SetStrict.
+// Mappings between structs and other records, such as StructMap and Tree.Object, then fail on fields that either side lacks.
+func (x *State) Strict() bool {
+	return x != nil && x.strict
+}
+
+// Lossy returns true if numeric values may lose information, as set through Mapper.SetLossy.
+// Numeric Mappings, such as NumericMapping, then wrap around and truncate as Go conversions do, rather than failing with ErrOverflow or ErrPrecisionLoss.
+func (x *State) Lossy() bool {
+	return x != nil && x.lossy
+}
+
+// StateData returns the user data of "s", if it is of type T.
+func StateData[T any](s *State) (T, bool) {
+	o, ok := s.Data().(T)
+	return o, ok
+}
+
+// New returns a pointer to a new zero value of type "t", allocated from the arena of the State, if any, or else from the heap.
+// Mappings should allocate their destinations through it, so that conversions started with Mapper.MapArena don't add garbage collector pressure.
+func (x *State) New(t Type) Value {
+	if x == nil || x.alloc == nil {
+		return New(t)
+	}
+	return x.alloc(t)
+}
+
+// MappingType returns the func(src) dst type, which identifies the Mapping between two types inside Builders and Libraries.
+func MappingType(dst, src Type) Type {
+	return FuncOf([]Type{src}, []Type{dst}, false)
+}
+
+// A Mapper is a Library specialized in Mappings, keyed by MappingType.
+type Mapper Library[Mapping]
+
+func NewMapper(b Builder[Mapping]) *Mapper {
+	return (*Mapper)(NewLibrary[Mapping](b, mappingInvalid))
+}
+
+// Get returns the Mapping from "src" to "dst".
+func (x *Mapper) Get(dst, src Type) Mapping {
+	return (*Library[Mapping])(x).Get(MappingType(dst, src))
+}
+
+// SetMaxDepth limits the nesting of the values that Map converts to "n" levels, as counted by the Mappings of Deep, failing with ErrDepth beyond it.
+// A backstop against pathological inputs, such as very long linked lists. Zero or less means no limit, which is the default.
+// Should be set before use.
+func (x *Mapper) SetMaxDepth(n int) {
+	x.depth = n
+}
+
+// SetSharing extends identity preservation to slices and maps: sources referenced multiple times within one Map call convert to one shared destination, as pointers always do with Deep.
+// Slices are shared when they start at the same element and have the same length.
+// Off by default, so that destinations can be modified independently. Should be set before use.
+func (x *Mapper) SetSharing(on bool) {
+	x.share = on
+}
+
+// SetStrict makes struct Mappings fail on fields that either side lacks: destination fields missing from the source fail with ErrMissingField, and source fields without a destination with ErrUnknownField.
+// Fields ignored through directives are exempt. Off by default, matching fields permissively. Should be set before use.
+//
+// Mappings read the setting through State.Strict.
+func (x *Mapper) SetStrict(on bool) {
+	x.strict = on
+}
+
+// SetLossy makes numeric Mappings convert values that don't fit their destination as Go conversions do, rather than failing.
+// Off by default. Should be set before use.
+//
+// Mappings read the setting through State.Lossy.
+func (x *Mapper) SetLossy(on bool) {
+	x.lossy = on
+}
+
+// Map converts "src" into the value pointed to by "dst".
+// Each call uses a new State.
+func (x *Mapper) Map(dst, src any) error {
+	return x.mapState(dst, src, &State{})
+}
+
+// MapWith is the same as Map, but makes "data" available to all the Mappings of the conversion, through State.Data.
+// Lets Mappings depend on per call settings, such as a locale, time zone, tenant or strictness flags, without building separate Mappers. As built Mappings are shared by all calls, they must read such settings from the State on every call, rather than when built.
+func (x *Mapper) MapWith(data, dst, src any) error {
+	return x.mapState(dst, src, &State{data: data})
+}
+
+// MustConvert returns the conversion of "src" into a new D through "x", and panics with the error on failure, for initialization code and tests.
+func MustConvert[D any](x *Mapper, src any) D {
+	var o D
+	if err := x.Map(&o, src); err != nil {
+		panic(err)
+	}
+	return o
+}
+
+func (x *Mapper) mapState(dst, src any, st *State) error {
+	d := ValueOf(dst)
+	if d.Kind() != Pointer || d.IsNil() {
+		return ErrInvalid
+	}
+	s := ValueOf(src)
+	st.limit, st.share = x.depth, x.share
+	st.strict, st.lossy = x.strict, x.lossy
+	return x.Get(d.Type().Elem(), s.Type())(d.Elem(), s, st)
+}
+
+// AssignMapping is a Builder of Mappings between types that are assignable or convertible according to Go rules.
+// Integer to string conversions are excluded, as they are rarely intended.
+func AssignMapping(t Type) (Mapping, bool) {
+	set, ok := assignFunc(t.Out(0), t.In(0))
+	if !ok {
+		return nil, false
+	}
+	return func(dst, src Value, s *State) error {
+		set(dst, src)
+		return nil
+	}, true
+}
+
+// StructMap is a Builder of Mappings between struct types, and between structs and maps with string keys, matching exported fields by name.
+// Field values are mapped through Fields, which will usually be the Mapper that the StructMap itself is part of.
+// Fields promoted through embedded pointers are not set.
+// Field plans are computed once per type pair, with fields located by their offset where possible.
+// Verbatim copies of fields without pointers are done as plain memory copies, when the source is addressable.
+//
+// Fields can be controlled using the "conv" struct tag, on either side:
+//
+//	`conv:"name"`        match the field as "name", rather than by its field name; also its key in maps
+//	`conv:"-"`           ignore the field
+//	`conv:",copy"`       copy the field verbatim when both sides have the same type, without consulting Fields
+//	`conv:",required"`   on the destination, fail with ErrMissingField if the source has no such field or key
+//	`conv:",omitempty"`  when converting into a map, leave out the field if it holds its zero value
+//
+// Tags are parsed as by wrap.Tag, so that fields are named the same as by the formats built on wrap.StructIter, such as CBORConverter.
+//
+// Failed fields don't stop the mapping of the others. Failures are reported together, as FieldErrors.
+// Fields that either side lacks are skipped, unless the Mapper is strict; see Mapper.SetStrict.
+//
+// The same directives are available programmatically, through the Ignore, Copy and Require methods.
+type StructMap struct {
+	Fields *Mapper
+
+	CopyIdentical bool // copy all identically typed fields verbatim
+
+	directives map[Type]map[string]fieldDirective
+	mux        sync.RWMutex
+}
+
+type fieldDirective uint8
+
+const (
+	fieldIgnore fieldDirective = 1 << iota
+	fieldCopy
+	fieldRequired
+)
+
+// Ignore excludes the named fields of struct type "t" from conversion, whether "t" is the source or destination.
+func (x *StructMap) Ignore(t Type, names ...string) {
+	x.direct(t, fieldIgnore, names)
+}
+
+// Copy marks the named fields of struct type "t" for verbatim copying.
+func (x *StructMap) Copy(t Type, names ...string) {
+	x.direct(t, fieldCopy, names)
+}
+
+// Require marks the named fields of struct type "t" as required, when "t" is the destination.
+func (x *StructMap) Require(t Type, names ...string) {
+	x.direct(t, fieldRequired, names)
+}
+
+func (x *StructMap) direct(t Type, d fieldDirective, names []string) {
+	x.mux.Lock()
+	defer x.mux.Unlock()
+
+	if x.directives == nil {
+		x.directives = make(map[Type]map[string]fieldDirective)
+	}
+	m := x.directives[t]
+	if m == nil {
+		m = make(map[string]fieldDirective)
+		x.directives[t] = m
+	}
+	for _, name := range names {
+		m[name] |= d
+	}
+}
+
+// directive returns the combined tag and programmatic directives of field "f" of struct type "t".
+func (x *StructMap) directive(t Type, f StructField) fieldDirective {
+	var o fieldDirective
+	name, opts := parseTag(f.Tag.Get("conv"))
+	if name == "-" {
+		o |= fieldIgnore
+	}
+	for _, opt := range opts {
+		switch opt {
+		case "copy":
+			o |= fieldCopy
+		case "required":
+			o |= fieldRequired
+		}
+	}
+
+	x.mux.RLock()
+	o |= x.directives[t][f.Name]
+	x.mux.RUnlock()
+
+	return o
+}
+
+func (x *StructMap) Build(t Type) (Mapping, bool) {
+	tDst, tSrc := t.Out(0), t.In(0)
+	switch kDst, kSrc := tDst.Kind(), tSrc.Kind(); {
+	case kDst == Struct && kSrc == Struct:
+		return x.structs(tDst, tSrc), true
+	case kDst == Map && kSrc == Struct && tDst.Key().Kind() == String:
+		return x.toMap(tDst, tSrc), true
+	case kDst == Struct && kSrc == Map && tSrc.Key().Kind() == String:
+		return x.fromMap(tDst, tSrc), true
+	}
+	return nil, false
+}
+
+func (x *StructMap) structs(tDst, tSrc Type) Mapping {
+	byName := fieldsByName(tSrc)
+	type entry struct {
+		name    string
+		dst     fieldOffset
+		src     fieldOffset
+		copy    bool
+		memcopy bool // copy is pointer free and located by offset on both sides
+		fn      *mappingRef
+	}
+
+	var plan []entry
+	var missing FieldErrors
+	var absent, unknown []string // fields that either side lacks, failing strict Mappings
+	matched := make(map[string]bool)
+	for _, df := range VisibleFields(tDst) {
+		if !mappedField(tDst, df) {
+			continue
+		}
+		sf, ok := byName[fieldName(df)]
+		if !ok {
+			d := x.directive(tDst, df)
+			if d&fieldRequired != 0 {
+				missing = missing.add(df.Name, ErrMissingField)
+			} else if d&fieldIgnore == 0 {
+				absent = append(absent, df.Name)
+			}
+			continue
+		}
+		matched[sf.Name] = true
+
+		d := x.directive(tDst, df) | x.directive(tSrc, sf)
+		if d&fieldIgnore != 0 {
+			continue
+		}
+
+		e := entry{
+			name: df.Name,
+			dst:  newFieldOffset(tDst, df.Index),
+			src:  newFieldOffset(tSrc, sf.Index),
+		}
+		if df.Type == sf.Type && (x.CopyIdentical || d&fieldCopy != 0) {
+			e.copy = true
+			e.memcopy = e.dst.direct && e.src.direct && pointerFree(df.Type)
+		} else {
+			e.fn = &mappingRef{
+				m:   x.Fields,
+				dst: df.Type,
+				src: sf.Type,
+			}
+		}
+		plan = append(plan, e)
+	}
+	for _, sf := range VisibleFields(tSrc) {
+		if mappedField(tSrc, sf) && !matched[sf.Name] && x.directive(tSrc, sf)&fieldIgnore == 0 {
+			unknown = append(unknown, sf.Name)
+		}
+	}
+	if missing != nil {
+		return func(dst, src Value, s *State) error {
+			return missing
+		}
+	}
+
+	return func(dst, src Value, s *State) error {
+		var errs FieldErrors
+		// read only sources must not be read around reflect, as that would expose their unexported origin
+		raw := !safeMode && dst.CanSet() && src.CanAddr() && src.CanInterface()
+		for _, e := range plan {
+			if e.memcopy && raw {
+				copyAt(dst, src, e.dst.off, e.src.off, e.dst.typ.Size())
+				continue
+			}
+			sf, ok := e.src.get(src)
+			if !ok {
+				// nil embedded pointer; nothing to convert
+				continue
+			}
+			df, _ := e.dst.get(dst)
+			if e.copy {
+				df.Set(sf)
+				continue
+			}
+			if err := e.fn.get()(df, sf, s); err != nil {
+				errs = errs.add(e.name, err)
+			}
+		}
+		if s.Strict() {
+			for _, name := range absent {
+				errs = errs.add(name, ErrMissingField)
+			}
+			for _, name := range unknown {
+				errs = errs.add(name, ErrUnknownField)
+			}
+		}
+		return errs.err()
+	}
+}
+
+// toMap builds Mappings from structs to maps, keyed by field tag name.
+func (x *StructMap) toMap(tDst, tSrc Type) Mapping {
+	type entry struct {
+		name string
+		key  Value
+		src  fieldOffset
+		omit bool // omit zero values
+		fn   *mappingRef
+	}
+
+	var plan []entry
+	for _, sf := range VisibleFields(tSrc) {
+		if !sf.IsExported() || (sf.Anonymous && sf.Type.Kind() == Struct) || x.directive(tSrc, sf)&fieldIgnore != 0 {
+			continue
+		}
+		name, opts := wrap.Tag(sf)
+		e := entry{
+			name: name,
+			key:  ValueOf(name).Convert(tDst.Key()),
+			src:  newFieldOffset(tSrc, sf.Index),
+			fn: &mappingRef{
+				m:   x.Fields,
+				dst: tDst.Elem(),
+				src: sf.Type,
+			},
+		}
+		for _, opt := range opts {
+			e.omit = e.omit || opt == "omitempty"
+		}
+		plan = append(plan, e)
+	}
+
+	return func(dst, src Value, s *State) error {
+		var errs FieldErrors
+		o := MakeMapWithSize(tDst, len(plan))
+		v := getScratch(tDst.Elem())
+		defer putScratch(v)
+		for _, e := range plan {
+			sf, ok := e.src.get(src)
+			if !ok || (e.omit && sf.IsZero()) {
+				continue
+			}
+			v.SetZero()
+			if err := e.fn.get()(v, sf, s); err != nil {
+				errs = errs.add(e.name, err)
+				continue
+			}
+			o.SetMapIndex(e.key, v)
+		}
+		dst.Set(o)
+		return errs.err()
+	}
+}
+
+// fromMap builds Mappings from maps to structs, looking up fields by their tag name.
+func (x *StructMap) fromMap(tDst, tSrc Type) Mapping {
+	type entry struct {
+		name     string
+		key      Value
+		dst      fieldOffset
+		required bool
+		fn       *mappingRef
+	}
+
+	var plan []entry
+	known := make(map[string]bool) // keys that are not unknown to strict Mappings, including those of ignored fields
+	for _, df := range VisibleFields(tDst) {
+		if !mappedField(tDst, df) {
+			continue
+		}
+		name := fieldName(df)
+		known[name] = true
+		d := x.directive(tDst, df)
+		if d&fieldIgnore != 0 {
+			continue
+		}
+		plan = append(plan, entry{
+			name:     name,
+			key:      ValueOf(name).Convert(tSrc.Key()),
+			dst:      newFieldOffset(tDst, df.Index),
+			required: d&fieldRequired != 0,
+			fn: &mappingRef{
+				m:   x.Fields,
+				dst: df.Type,
+				src: tSrc.Elem(),
+			},
+		})
+	}
+
+	return func(dst, src Value, s *State) error {
+		var errs FieldErrors
+		for _, e := range plan {
+			v := src.MapIndex(e.key)
+			if !v.IsValid() {
+				if e.required || s.Strict() {
+					errs = errs.add(e.name, ErrMissingField)
+				}
+				continue
+			}
+			df, _ := e.dst.get(dst)
+			if err := e.fn.get()(df, v, s); err != nil {
+				errs = errs.add(e.name, err)
+			}
+		}
+		if s.Strict() {
+			var unknown []string
+			for iter := src.MapRange(); iter.Next(); {
+				if key := iter.Key().String(); !known[key] {
+					unknown = append(unknown, key)
+				}
+			}
+			sort.Strings(unknown)
+			for _, key := range unknown {
+				errs = errs.add(key, ErrUnknownField)
+			}
+		}
+		return errs.err()
+	}
+}
+
+// fieldName returns the name that StructMap matches field "f" by: its conv tag name, or its field name if untagged or ignored.
+func fieldName(f StructField) string {
+	if name, _ := wrap.Tag(f); name != "-" {
+		return name
+	}
+	return f.Name
+}
+
+// fieldsByName returns the exported fields of struct type "t", other than embedded structs, by the name that StructMap matches them by.
+// Fields promoted through embedded pointers are included, as they can still be read. Of fields with the same name, the first one wins.
+func fieldsByName(t Type) map[string]StructField {
+	o := make(map[string]StructField)
+	for _, f := range VisibleFields(t) {
+		if !f.IsExported() || (f.Anonymous && f.Type.Kind() == Struct) {
+			continue
+		}
+		name := fieldName(f)
+		if _, ok := o[name]; !ok {
+			o[name] = f
+		}
+	}
+	return o
+}
+
+// mappedField returns true if StructMap maps field "f" of struct type "t".
+// Unexported fields are left out, as are embedded structs, whose own fields are mapped instead, and fields promoted through embedded pointers.
+func mappedField(t Type, f StructField) bool {
+	return f.IsExported() && !(f.Anonymous && f.Type.Kind() == Struct) && !throughPointer(t, f.Index)
+}
+
+// A fieldOffset locates a struct field. Fields that are not promoted through embedded pointers are located by their precomputed offset, instead of walking their index on every access.
+type fieldOffset struct {
+	index  []int
+	typ    Type
+	off    uintptr
+	direct bool
+}
+
+func newFieldOffset(t Type, index []int) fieldOffset {
+	o := fieldOffset{
+		index:  index,
+		direct: !throughPointer(t, index),
+	}
+	if !o.direct {
+		o.typ = t.FieldByIndex(index).Type
+		return o
+	}
+	for _, i := range index {
+		f := t.Field(i)
+		o.off += f.Offset
+		t = f.Type
+	}
+	o.typ = t
+	return o
+}
+
+// get returns the field of struct "v", or false if it is promoted through a nil embedded pointer.
+// The offset is only used if "v" is settable, so that the result is too; read only values keep going through reflect.
+func (x fieldOffset) get(v Value) (Value, bool) {
+	if x.direct && !safeMode && v.CanSet() {
+		return fieldAt(v, x.typ, x.off), true
+	}
+	o, err := v.FieldByIndexErr(x.index)
+	return o, err == nil
+}
+
+// pointerFree returns true if values of type "t" hold no pointers, and can be copied as plain memory.
+func pointerFree(t Type) bool {
+	switch t.Kind() {
+	case Bool, Int, Int8, Int16, Int32, Int64, Uint, Uint8, Uint16, Uint32, Uint64, Uintptr, Float32, Float64, Complex64, Complex128:
+		return true
+	case Array:
+		return t.Len() == 0 || pointerFree(t.Elem())
+	case Struct:
+		for i := 0; i < t.NumField(); i++ {
+			if !pointerFree(t.Field(i).Type) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// mappingRef lazily resolves a Mapping on first use.
+// Builders must not request Mappings from their own Mapper while building, as that would deadlock; references are resolved at call time instead.
+type mappingRef struct {
+	m   *Mapper
+	dst Type
+	src Type
+	fn  atomic.Pointer[Mapping]
+}
+
+func (x *mappingRef) get() Mapping {
+	if fn := x.fn.Load(); fn != nil {
+		return *fn
+	}
+	fn := x.m.Get(x.dst, x.src)
+	x.fn.Store(&fn)
+	return fn
+}
+
+// parseTag splits a struct tag value into its name and options.
+func parseTag(tag string) (string, []string) {
+	parts := strings.Split(tag, ",")
+	return parts[0], parts[1:]
+}
+
+func mappingInvalid(dst, src Value, s *State) error {
+	return fmt.Errorf("%s into %s: %w", describeType(src.Type()), describeType(dst.Type()), ErrInvalid)
+}
+
+// A taggedField is a settable struct field, along with its parsed tag.
+type taggedField struct {
+	StructField
+	name string // tag name, defaulting to the field name
+	opts []string
+}
+
+// taggedFields returns the exported fields of struct type "t", including those promoted through embedded structs but not through embedded pointers, in declaration order.
+// Fields tagged "-" under "key" are skipped.
+func taggedFields(t Type, key string) []taggedField {
+	var o []taggedField
+	for _, f := range VisibleFields(t) {
+		if !mappedField(t, f) {
+			continue
+		}
+		name, opts := parseTag(f.Tag.Get(key))
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		o = append(o, taggedField{
+			StructField: f,
+			name:        name,
+			opts:        opts,
+		})
+	}
+	return o
+}
+
+// option returns the value of the "key=value" option, if present.
+func (x taggedField) option(key string) (string, bool) {
+	for _, opt := range x.opts {
+		if k, v, ok := strings.Cut(opt, "="); ok && k == key {
+			return v, true
+		}
+	}
+	return "", false
+}
+
+// flag returns true if the option "key" is present.
+func (x taggedField) flag(key string) bool {
+	for _, opt := range x.opts {
+		if opt == key {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,309 @@
+package conv
+
+import (
+	"errors"
+	. "reflect"
+	"testing"
+	"time"
+)
+
+func TestStructMap(t *testing.T) {
+	type inner struct {
+		X int
+	}
+	type innerOut struct {
+		X float64
+	}
+	type src struct {
+		A int
+		B string
+		C []byte
+		D inner
+		E string
+		F bool
+	}
+	type dst struct {
+		A int64
+		B string `conv:"-"`
+		C []byte `conv:",copy"`
+		D innerOut
+		E string
+		F bool
+	}
+
+	sm := &StructMap{}
+	m := NewMapper(Scheme[Mapping]{AssignMapping, sm.Build}.Build)
+	sm.Fields = m
+	sm.Ignore(TypeEval[src](), "E")
+
+	b := []byte{1, 2}
+	var o dst
+	err := m.Map(&o, src{1, "b", b, inner{2}, "e", true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if o.A != 1 || o.B != "" || &o.C[0] != &b[0] || o.D.X != 2 || o.E != "" || !o.F {
+		t.Error("wrong result", o)
+	}
+
+	type bad struct {
+		A chan int
+	}
+	if err := m.Map(&bad{}, src{}); err == nil {
+		t.Error("expected field error")
+	}
+
+	type required struct {
+		A int
+		G string `conv:",required"`
+	}
+	if err := m.Map(&required{}, src{}); !errors.Is(err, ErrMissingField) {
+		t.Error("expected missing field", err)
+	}
+	type requiredProg struct {
+		A int
+		G string
+	}
+	sm.Require(TypeEval[requiredProg](), "G")
+	if err := m.Map(&requiredProg{}, src{}); !errors.Is(err, ErrMissingField) {
+		t.Error("expected missing field", err)
+	}
+}
+
+func TestStructMapPromoted(t *testing.T) {
+	type Base struct {
+		ID   int
+		Name string
+	}
+	type src struct {
+		*Base
+		Value int32
+	}
+	type dst struct {
+		Base
+		Value int64
+	}
+
+	m := NewDeepMapper(nil)
+	var o dst
+	// settable source, located by offset
+	s := &src{Base: &Base{ID: 1, Name: "x"}, Value: 2}
+	if err := m.Map(&o, s); err != nil || o != (dst{Base{1, "x"}, 2}) {
+		t.Error("wrong result", o, err)
+	}
+	// read only source, and nil embedded pointer
+	o = dst{}
+	if err := m.Map(&o, src{Value: 3}); err != nil || o != (dst{Value: 3}) {
+		t.Error("wrong result", o, err)
+	}
+}
+
+func TestStructMapMemcopy(t *testing.T) {
+	type point struct {
+		X, Y float64
+	}
+	type src struct {
+		A    int32
+		P    point
+		Tags [2]uint8
+		S    string
+	}
+	type dst struct {
+		S    string
+		Tags [2]uint8
+		P    point
+		A    int32
+	}
+
+	m := NewDeepMapper(&StructMap{CopyIdentical: true})
+	exp := dst{"s", [2]uint8{1, 2}, point{3, 4}, 5}
+	s := src{5, point{3, 4}, [2]uint8{1, 2}, "s"}
+
+	var o dst
+	// addressable source, copied as memory
+	if err := m.Map(&o, &s); err != nil || o != exp {
+		t.Error("wrong result", o, err)
+	}
+	// unaddressable source, copied through reflect
+	o = dst{}
+	if err := m.Map(&o, s); err != nil || o != exp {
+		t.Error("wrong result", o, err)
+	}
+
+	for _, tc := range []struct {
+		v   any
+		exp bool
+	}{
+		{0, true},
+		{point{}, true},
+		{[0]*int{}, true},
+		{[1]*int{}, false},
+		{struct{ S string }{}, false},
+		{[]int{}, false},
+	} {
+		if o := pointerFree(TypeOf(tc.v)); o != tc.exp {
+			t.Errorf("%T: expected %v", tc.v, tc.exp)
+		}
+	}
+}
+
+func BenchmarkStructMapMemcopy(b *testing.B) {
+	type src struct {
+		A, B, C int64
+		D       [4]float64
+	}
+	m := NewDeepMapper(&StructMap{CopyIdentical: true})
+	s := &src{1, 2, 3, [4]float64{4, 5, 6, 7}}
+	var o src
+	for i := 0; i < b.N; i++ {
+		if err := m.Map(&o, s); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkStructMap(b *testing.B) {
+	type src struct {
+		A, B, C int32
+		D, E    string
+	}
+	type dst struct {
+		A, B, C int64
+		D, E    string
+	}
+	m := NewDeepMapper(nil)
+	s := &src{1, 2, 3, "d", "e"}
+	var o dst
+	for i := 0; i < b.N; i++ {
+		if err := m.Map(&o, s); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestMapWith(t *testing.T) {
+	// formats times in the zone of the call
+	zoned := func(t Type) (Mapping, bool) {
+		if t.Out(0).Kind() != String || t.In(0) != TypeEval[time.Time]() {
+			return nil, false
+		}
+		return func(dst, src Value, s *State) error {
+			tm := src.Interface().(time.Time)
+			if loc, ok := StateData[*time.Location](s); ok {
+				tm = tm.In(loc)
+			}
+			dst.SetString(tm.Format("15:04"))
+			return nil
+		}, true
+	}
+	m := NewDeepMapper(nil, zoned)
+
+	type event struct {
+		At time.Time
+	}
+	type row struct {
+		At string
+	}
+	src := []event{{time.Date(2000, 1, 1, 12, 0, 0, 0, time.UTC)}}
+	var o []row
+	if err := m.MapWith(time.FixedZone("x", 3600), &o, src); err != nil || len(o) != 1 || o[0].At != "13:00" {
+		t.Error("wrong zoned rows", o, err)
+	}
+	if err := m.Map(&o, src); err != nil || o[0].At != "12:00" {
+		t.Error("wrong plain rows", o, err)
+	}
+	if (*State)(nil).Data() != nil {
+		t.Error("nil state should have no data")
+	}
+}
+
+func TestStructMapStrict(t *testing.T) {
+	type user struct {
+		Name  string
+		Email string
+		Notes string `conv:"-"`
+	}
+	type row struct {
+		Name string
+		Age  int
+		Skip int `conv:"-"`
+	}
+	m := NewDeepMapper(nil)
+	var r row
+	if err := m.Map(&r, user{Name: "ann"}); err != nil || r.Name != "ann" {
+		t.Error("permissive mapping should pass", r, err)
+	}
+
+	m = NewDeepMapper(nil)
+	m.SetStrict(true)
+	err := m.Map(&r, user{Name: "bob"})
+	var errs FieldErrors
+	if !errors.As(err, &errs) || len(errs) != 2 {
+		t.Fatal("expected two field errors", err)
+	}
+	if errs[0].Path != "Age" || !errors.Is(errs[0], ErrMissingField) || errs[1].Path != "Email" || !errors.Is(errs[1], ErrUnknownField) {
+		t.Error("wrong field errors", err)
+	}
+	if r.Name != "bob" {
+		t.Error("matched fields should still map", r)
+	}
+
+	var u user
+	if err := m.Map(&u, user{Name: "cid"}); err != nil || u.Name != "cid" {
+		t.Error("identical structs should pass", u, err)
+	}
+}
+
+func TestStructMapTags(t *testing.T) {
+	type user struct {
+		ID     int    `conv:"id"`
+		Name   string `conv:"name,omitempty"`
+		Secret string `conv:"-"`
+	}
+	type row struct {
+		Key  int `conv:"id"`
+		Name string
+	}
+	m := NewDeepMapper(nil)
+
+	var r row
+	if err := m.Map(&r, user{ID: 1, Name: "ann"}); err != nil || r != (row{1, ""}) {
+		t.Error("renamed field should match by tag", r, err)
+	}
+
+	var out map[string]any
+	if err := m.Map(&out, user{ID: 2, Secret: "s"}); err != nil || !DeepEqual(out, map[string]any{"id": 2}) {
+		t.Error("wrong map", out, err)
+	}
+	if err := m.Map(&out, user{ID: 2, Name: "bob"}); err != nil || !DeepEqual(out, map[string]any{"id": 2, "name": "bob"}) {
+		t.Error("wrong map", out, err)
+	}
+
+	type account struct {
+		Name   string `conv:"name"`
+		Email  string
+		Secret string `conv:"-"`
+	}
+	var a account
+	if err := m.Map(&a, map[string]string{"name": "cid", "Email": "c@x", "Secret": "s", "other": "o"}); err != nil || a != (account{Name: "cid", Email: "c@x"}) {
+		t.Error("wrong account", a, err)
+	}
+	var u user
+	if err := m.Map(&u, map[string]chan int{"id": nil}); !errors.Is(err, ErrInvalid) {
+		t.Error("expected invalid field", err)
+	}
+
+	type required struct {
+		ID int `conv:"id,required"`
+	}
+	var req required
+	if err := m.Map(&req, map[string]int{}); !errors.Is(err, ErrMissingField) {
+		t.Error("expected missing field", err)
+	}
+
+	m.SetStrict(true)
+	err := m.Map(&a, map[string]string{"name": "dan", "Secret": "s", "other": "o"})
+	if err == nil || err.Error() != "field Email: missing field\nfield other: unknown field" {
+		t.Error("wrong strict error", err)
+	}
+}
@@ -0,0 +1,97 @@
+package conv
+
+import (
+	"encoding"
+	. "reflect"
+)
+
+var (
+	typeTextMarshaler   = TypeEval[encoding.TextMarshaler]()
+	typeTextUnmarshaler = TypeEval[encoding.TextUnmarshaler]()
+)
+
+// TextConverter is a Builder of string Converters for types that implement encoding.TextMarshaler, either directly or through their pointer.
+func TextConverter(t Type) (Converter[string], bool) {
+	if !implements(t, typeTextMarshaler) {
+		return nil, false
+	}
+	return func(v Value) (string, error) {
+		b, err := methods[encoding.TextMarshaler](v).MarshalText()
+		return string(b), err
+	}, true
+}
+
+// TextInverter is a Builder of string Inverters for types that implement encoding.TextUnmarshaler through their pointer.
+// Pointer destinations are also accepted, if their element type qualifies.
+func TextInverter(t Type) (Inverter[string], bool) {
+	tElem, ok := unmarshalTarget(t, typeTextUnmarshaler)
+	if !ok {
+		return nil, false
+	}
+	return func(v string) (Value, error) {
+		o := New(tElem)
+		if err := o.Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(v)); err != nil {
+			return Value{}, err
+		}
+		if tElem == t {
+			return o.Elem(), nil
+		}
+		return o, nil
+	}, true
+}
+
+// TextMapping is a Builder of Mappings from encoding.TextMarshaler types to string kinds, and from string kinds to encoding.TextUnmarshaler types.
+func TextMapping(t Type) (Mapping, bool) {
+	tDst, tSrc := t.Out(0), t.In(0)
+	switch {
+	case tDst.Kind() == String && implements(tSrc, typeTextMarshaler):
+		return func(dst, src Value, s *State) error {
+			if tSrc.Kind() == Pointer && src.IsNil() {
+				dst.SetString("")
+				return nil
+			}
+			b, err := methods[encoding.TextMarshaler](src).MarshalText()
+			if err != nil {
+				return err
+			}
+			dst.SetString(string(b))
+			return nil
+		}, true
+	case tSrc.Kind() == String && PointerTo(tDst).Implements(typeTextUnmarshaler):
+		return func(dst, src Value, s *State) error {
+			return dst.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(src.String()))
+		}, true
+	}
+	return nil, false
+}
+
+// implements returns true if "t" or its pointer implements interface "i".
+func implements(t, i Type) bool {
+	return t.Implements(i) || PointerTo(t).Implements(i)
+}
+
+// methods returns "v" as interface I, which must be implemented by v's type or its pointer.
+// Unaddressable values are copied if necessary.
+func methods[I any](v Value) I {
+	if o, ok := v.Interface().(I); ok {
+		return o
+	}
+	if !v.CanAddr() {
+		p := New(v.Type())
+		p.Elem().Set(v)
+		v = p.Elem()
+	}
+	return v.Addr().Interface().(I)
+}
+
+// unmarshalTarget returns the type that should be allocated in order to unmarshal into "t" using interface "i".
+// This is "t" itself if its pointer implements "i", or its element if "t" is a pointer whose element qualifies.
+func unmarshalTarget(t, i Type) (Type, bool) {
+	if PointerTo(t).Implements(i) {
+		return t, true
+	}
+	if t.Kind() == Pointer && PointerTo(t.Elem()).Implements(i) {
+		return t.Elem(), true
+	}
+	return nil, false
+}
@@ -0,0 +1,55 @@
+package conv
+
+import (
+	"errors"
+	"net"
+	. "reflect"
+	"testing"
+	"time"
+)
+
+func TestText(t *testing.T) {
+	tm := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	ip := net.IPv4(1, 2, 3, 4)
+
+	c := NewConversion(TextConverter)
+	if s, err := c.Call(tm); err != nil || s != "2020-01-02T03:04:05Z" {
+		t.Error("time conversion failed", s, err)
+	}
+	if s, err := c.Call(ip); err != nil || s != "1.2.3.4" {
+		t.Error("ip conversion failed", s, err)
+	}
+	if _, err := c.Call((*time.Time)(nil)); !errors.Is(err, ErrNilSource) {
+		t.Error("nil pointers should follow the nil policy", err)
+	}
+
+	inv := NewInversion(TextInverter)
+	if o, err := As[time.Time](inv, "2020-01-02T03:04:05Z"); err != nil || !o.Equal(tm) {
+		t.Error("time inversion failed", o, err)
+	}
+	if o, err := As[*net.IP](inv, "1.2.3.4"); err != nil || !o.Equal(ip) {
+		t.Error("ip inversion failed", o, err)
+	}
+	if _, err := As[time.Time](inv, "bad"); err == nil {
+		t.Error("expected parse error")
+	}
+
+	type src struct {
+		At  time.Time
+		IP  string
+		Opt *time.Time
+	}
+	type dst struct {
+		At  string
+		IP  net.IP
+		Opt string
+	}
+	m := NewDeepMapper(nil, TextMapping)
+	var o dst
+	if err := m.Map(&o, src{tm, "1.2.3.4", nil}); err != nil {
+		t.Fatal(err)
+	}
+	if !DeepEqual(o, dst{"2020-01-02T03:04:05Z", ip, ""}) {
+		t.Error("mapping failed", o)
+	}
+}
@@ -0,0 +1,233 @@
+package conv
+
+import (
+	"fmt"
+	"math"
+	. "reflect"
+	"time"
+)
+
+// Time is a set of Builders for time.Time, which the numeric and text rules alone cannot express.
+// Times convert to and from:
+//
+//   - strings, formatted with the first of Layouts and parsed with each in turn, RFC3339 by default
+//   - integers and floats, counting Units since the Unix epoch, seconds by default
+//   - timestamppb.Timestamp shaped structs, with an int64 Seconds and an int32 Nanos field
+//
+// Times built from strings without zone information, and from numbers or timestamps, are in Location, or UTC if nil.
+// Note that time.Time implements encoding.TextMarshaler, so Builders using that interface will cover it first if placed earlier in a Scheme.
+type Time struct {
+	Layouts  []string
+	Unit     time.Duration
+	Location *time.Location
+}
+
+var typeTime = TypeEval[time.Time]()
+
+// Converter is a Builder of string Converters for time.Time.
+func (x *Time) Converter(t Type) (Converter[string], bool) {
+	if t != typeTime {
+		return nil, false
+	}
+	return func(v Value) (string, error) {
+		return x.format(v.Interface().(time.Time)), nil
+	}, true
+}
+
+// Inverter is a Builder of string Inverters for time.Time.
+func (x *Time) Inverter(t Type) (Inverter[string], bool) {
+	if t != typeTime {
+		return nil, false
+	}
+	return func(s string) (Value, error) {
+		o, err := x.parse(s)
+		if err != nil {
+			return Value{}, err
+		}
+		return ValueOf(o), nil
+	}, true
+}
+
+// Build is a Builder of Mappings between time.Time and string kinds, numeric kinds and timestamp structs.
+func (x *Time) Build(t Type) (Mapping, bool) {
+	tDst, tSrc := t.Out(0), t.In(0)
+	switch {
+	case tSrc == typeTime:
+		return x.from(tDst)
+	case tDst == typeTime:
+		return x.to(tSrc)
+	}
+	return nil, false
+}
+
+// from builds Mappings from time.Time to "t".
+func (x *Time) from(t Type) (Mapping, bool) {
+	switch k := t.Kind(); {
+	case k == String:
+		return func(dst, src Value, s *State) error {
+			dst.SetString(x.format(src.Interface().(time.Time)))
+			return nil
+		}, true
+	case k >= Int && k <= Int64:
+		return func(dst, src Value, s *State) error {
+			n, ok := x.unix(src.Interface().(time.Time))
+			if !ok || dst.OverflowInt(n) {
+				return fmt.Errorf("%v into %s: %w", src, describeType(t), ErrOverflow)
+			}
+			dst.SetInt(n)
+			return nil
+		}, true
+	case k >= Uint && k <= Uint64:
+		return func(dst, src Value, s *State) error {
+			n, ok := x.unix(src.Interface().(time.Time))
+			if !ok || n < 0 || dst.OverflowUint(uint64(n)) {
+				return fmt.Errorf("%v into %s: %w", src, describeType(t), ErrOverflow)
+			}
+			dst.SetUint(uint64(n))
+			return nil
+		}, true
+	case k == Float32 || k == Float64:
+		return func(dst, src Value, s *State) error {
+			tm := src.Interface().(time.Time)
+			sec := float64(tm.Unix()) + float64(tm.Nanosecond())/1e9
+			dst.SetFloat(sec * float64(time.Second) / float64(x.unit()))
+			return nil
+		}, true
+	case isTimestamp(t):
+		seconds, _ := t.FieldByName("Seconds")
+		nanos, _ := t.FieldByName("Nanos")
+		return func(dst, src Value, s *State) error {
+			tm := src.Interface().(time.Time)
+			dst.FieldByIndex(seconds.Index).SetInt(tm.Unix())
+			dst.FieldByIndex(nanos.Index).SetInt(int64(tm.Nanosecond()))
+			return nil
+		}, true
+	}
+	return nil, false
+}
+
+// to builds Mappings from "t" to time.Time.
+func (x *Time) to(t Type) (Mapping, bool) {
+	switch k := t.Kind(); {
+	case k == String:
+		return func(dst, src Value, s *State) error {
+			tm, err := x.parse(src.String())
+			if err != nil {
+				return err
+			}
+			dst.Set(ValueOf(tm))
+			return nil
+		}, true
+	case k >= Int && k <= Int64:
+		return func(dst, src Value, s *State) error {
+			dst.Set(ValueOf(x.fromUnix(src.Int())))
+			return nil
+		}, true
+	case k >= Uint && k <= Uint64:
+		return func(dst, src Value, s *State) error {
+			n := src.Uint()
+			if n > math.MaxInt64 {
+				return fmt.Errorf("%v into time: %w", n, ErrOverflow)
+			}
+			dst.Set(ValueOf(x.fromUnix(int64(n))))
+			return nil
+		}, true
+	case k == Float32 || k == Float64:
+		return func(dst, src Value, s *State) error {
+			f := src.Float() * float64(x.unit()) / float64(time.Second)
+			if math.IsNaN(f) || f < math.MinInt64 || f >= math.MaxInt64 {
+				return fmt.Errorf("%v into time: %w", f, ErrOverflow)
+			}
+			sec, frac := math.Modf(f)
+			dst.Set(ValueOf(time.Unix(int64(sec), int64(frac*1e9)).In(x.location())))
+			return nil
+		}, true
+	case isTimestamp(t):
+		seconds, _ := t.FieldByName("Seconds")
+		nanos, _ := t.FieldByName("Nanos")
+		return func(dst, src Value, s *State) error {
+			tm := time.Unix(src.FieldByIndex(seconds.Index).Int(), src.FieldByIndex(nanos.Index).Int())
+			dst.Set(ValueOf(tm.In(x.location())))
+			return nil
+		}, true
+	}
+	return nil, false
+}
+
+func (x *Time) format(tm time.Time) string {
+	if len(x.Layouts) == 0 {
+		return tm.Format(time.RFC3339Nano)
+	}
+	return tm.Format(x.Layouts[0])
+}
+
+func (x *Time) parse(s string) (time.Time, error) {
+	layouts := x.Layouts
+	if len(layouts) == 0 {
+		// also accepts fractional seconds
+		layouts = []string{time.RFC3339}
+	}
+	var err error
+	for _, layout := range layouts {
+		var o time.Time
+		if o, err = time.ParseInLocation(layout, s, x.location()); err == nil {
+			return o, nil
+		}
+	}
+	return time.Time{}, err
+}
+
+func (x *Time) unit() time.Duration {
+	if x.Unit <= 0 {
+		return time.Second
+	}
+	return x.Unit
+}
+
+func (x *Time) location() *time.Location {
+	if x.Location == nil {
+		return time.UTC
+	}
+	return x.Location
+}
+
+// unix returns the number of Units since the epoch, rounded down, or false on overflow.
+func (x *Time) unix(tm time.Time) (int64, bool) {
+	sec, nsec := tm.Unix(), int64(tm.Nanosecond())
+	unit := int64(x.unit())
+	if unit >= int64(time.Second) {
+		per := unit / int64(time.Second)
+		n := sec / per
+		if sec%per < 0 {
+			n--
+		}
+		return n, true
+	}
+	per := int64(time.Second) / unit
+	if sec > (math.MaxInt64-per)/per || sec < math.MinInt64/per {
+		return 0, false
+	}
+	return sec*per + nsec/unit, true
+}
+
+func (x *Time) fromUnix(n int64) time.Time {
+	unit := int64(x.unit())
+	if unit >= int64(time.Second) {
+		return time.Unix(n*(unit/int64(time.Second)), 0).In(x.location())
+	}
+	per := int64(time.Second) / unit
+	return time.Unix(n/per, n%per*unit).In(x.location())
+}
+
+// isTimestamp returns true if "t" is shaped like timestamppb.Timestamp.
+func isTimestamp(t Type) bool {
+	if t.Kind() != Struct {
+		return false
+	}
+	seconds, ok := t.FieldByName("Seconds")
+	if !ok || !seconds.IsExported() || seconds.Type.Kind() != Int64 {
+		return false
+	}
+	nanos, ok := t.FieldByName("Nanos")
+	return ok && nanos.IsExported() && nanos.Type.Kind() == Int32
+}
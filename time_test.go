@@ -0,0 +1,89 @@
+package conv
+
+import (
+	"errors"
+	"math"
+	. "reflect"
+	"testing"
+	"time"
+)
+
+func TestTime(t *testing.T) {
+	// shaped like timestamppb.Timestamp
+	type timestamp struct {
+		state   int
+		Seconds int64
+		Nanos   int32
+	}
+	type event struct {
+		At      time.Time
+		Created int64
+		Updated float64
+		Stamp   *timestamp
+	}
+	type record struct {
+		At      string
+		Created time.Time
+		Updated time.Time
+		Stamp   time.Time
+	}
+
+	tm := time.Date(2024, 5, 6, 7, 8, 9, 500_000_000, time.UTC)
+	x := &Time{}
+	m := NewDeepMapper(nil, x.Build)
+
+	var r record
+	if err := m.Map(&r, event{tm, tm.Unix(), 1714979289.5, &timestamp{Seconds: tm.Unix(), Nanos: 500_000_000}}); err != nil {
+		t.Fatal(err)
+	}
+	exp := record{"2024-05-06T07:08:09.5Z", tm.Truncate(time.Second), tm, tm}
+	if !DeepEqual(r, exp) {
+		t.Error("wrong record", r)
+	}
+
+	var e event
+	if err := m.Map(&e, r); err != nil {
+		t.Fatal(err)
+	}
+	if !e.At.Equal(tm) || e.Created != tm.Unix() || e.Updated != 1714979289.5 || e.Stamp.Seconds != tm.Unix() || e.Stamp.Nanos != 500_000_000 {
+		t.Error("wrong event", e)
+	}
+
+	// configured layouts and units
+	y := &Time{
+		Layouts:  []string{time.DateOnly, time.DateTime},
+		Unit:     time.Millisecond,
+		Location: time.FixedZone("X", 3600),
+	}
+	m = NewDeepMapper(nil, y.Build)
+	var o time.Time
+	if err := m.Map(&o, "2024-05-06 01:00:00"); err != nil {
+		t.Fatal(err)
+	}
+	if !o.Equal(time.Date(2024, 5, 6, 0, 0, 0, 0, time.UTC)) {
+		t.Error("wrong parsed time", o)
+	}
+	var ms int64
+	if err := m.Map(&ms, tm); err != nil || ms != tm.UnixMilli() {
+		t.Error("wrong milliseconds", ms, err)
+	}
+	if err := m.Map(&o, int64(-1500)); err != nil || !o.Equal(time.UnixMilli(-1500)) {
+		t.Error("wrong time from milliseconds", o, err)
+	}
+	var n int32
+	if err := m.Map(&n, tm); !errors.Is(err, ErrOverflow) {
+		t.Error("expected overflow, got", err)
+	}
+	if err := m.Map(&o, math.Inf(1)); !errors.Is(err, ErrOverflow) {
+		t.Error("expected overflow, got", err)
+	}
+
+	c := NewConversion(y.Converter)
+	if s, err := c.Call(tm); err != nil || s != "2024-05-06" {
+		t.Error("wrong string", s, err)
+	}
+	inv := NewInversion(y.Inverter)
+	if _, err := As[time.Time](inv, "May 6"); err == nil {
+		t.Error("expected parse error")
+	}
+}
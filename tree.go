@@ -0,0 +1,270 @@
+package conv
+
+import (
+	"fmt"
+	. "reflect"
+	"sort"
+	"strings"
+)
+
+// Tree is a set of Builders for Mappings between dynamic trees, as produced by YAML and JSON decoders, and typed values.
+// Trees are made of map[string]any or map[any]any objects, []any lists and scalars, all held in interfaces.
+//
+// Objects map to structs by field name, as given by the Key struct tag (such as "yaml" or "json"), or the field name itself.
+// Keys are matched exactly first, and case insensitively second. Non-string keys, as produced by older YAML libraries, are normalized to their string form.
+// JSON numbers decoded as json.Number map to numeric kinds if JSONNumber is part of the Mapper.
+// In the other direction, typed values become trees when mapped to empty interfaces: structs and maps become map[string]any, slices and arrays (other than byte slices) become []any, and pointers are dereferenced.
+// Wrapping respects the Mapper depth limit, and fails with ErrCycle on cyclic values, as trees can't share nodes.
+type Tree struct {
+	Fields *Mapper
+	Key    string
+}
+
+var (
+	typeAny       = TypeEval[any]()
+	typeTreeMap   = TypeEval[map[string]any]()
+	typeTreeSlice = TypeEval[[]any]()
+)
+
+// NewTreeMapper returns a deep Mapper with the Tree builders taking precedence, wiring "x" to it.
+// "sm" may be nil.
+func NewTreeMapper(x *Tree, sm *StructMap) *Mapper {
+	o := NewDeepMapper(sm, x.Build)
+	x.Fields = o
+	return o
+}
+
+// Build combines the Unwrap, Object and Wrap Builders.
+func (x *Tree) Build(t Type) (Mapping, bool) {
+	if o, ok := x.Unwrap(t); ok {
+		return o, true
+	}
+	if o, ok := x.Object(t); ok {
+		return o, true
+	}
+	return x.Wrap(t)
+}
+
+// Unwrap builds Mappings from interfaces, by mapping their dynamic values.
+// Nil interfaces produce zero values. Scalars held in interfaces are formatted when mapped to strings, to normalize object keys.
+func (x *Tree) Unwrap(t Type) (Mapping, bool) {
+	tDst, tSrc := t.Out(0), t.In(0)
+	if tSrc.Kind() != Interface {
+		return nil, false
+	}
+	return func(dst, src Value, s *State) error {
+		if src.IsNil() {
+			dst.SetZero()
+			return nil
+		}
+		e := src.Elem()
+		if tDst.Kind() == String && treeScalar(e.Kind()) {
+			dst.SetString(fmt.Sprint(e.Interface()))
+			return nil
+		}
+		return x.Fields.Get(tDst, e.Type())(dst, e, s)
+	}, true
+}
+
+// Object builds Mappings from maps with string or interface keys to structs.
+// Unknown keys are ignored, and fields without keys left unset, unless the Mapper is strict, in which case they fail with ErrUnknownField and ErrMissingField respectively; see Mapper.SetStrict.
+// Failed keys are reported together, as FieldErrors with the paths of their keys.
+func (x *Tree) Object(t Type) (Mapping, bool) {
+	tDst, tSrc := t.Out(0), t.In(0)
+	if tDst.Kind() != Struct || tSrc.Kind() != Map {
+		return nil, false
+	}
+	if k := tSrc.Key().Kind(); k != String && k != Interface {
+		return nil, false
+	}
+
+	fields := taggedFields(tDst, x.Key)
+	exact := make(map[string]int, len(fields))
+	folded := make(map[string]int, len(fields))
+	for i, f := range fields {
+		exact[f.name] = i
+		if _, ok := folded[strings.ToLower(f.name)]; !ok {
+			folded[strings.ToLower(f.name)] = i
+		}
+	}
+
+	return func(dst, src Value, s *State) error {
+		var (
+			failed  []error // by field, if any failed
+			seen    []bool  // by field, if strict
+			unknown []string
+		)
+		strict := s.Strict()
+		if strict {
+			seen = make([]bool, len(fields))
+		}
+		for iter := src.MapRange(); iter.Next(); {
+			key := treeKey(iter.Key())
+			i, ok := exact[key]
+			if !ok {
+				if i, ok = folded[strings.ToLower(key)]; !ok {
+					if strict {
+						unknown = append(unknown, key)
+					}
+					continue
+				}
+			}
+			if strict {
+				seen[i] = true
+			}
+			f := fields[i]
+			v := iter.Value()
+			if err := x.Fields.Get(f.Type, v.Type())(dst.FieldByIndex(f.Index), v, s); err != nil {
+				if failed == nil {
+					failed = make([]error, len(fields))
+				}
+				failed[i] = err
+			}
+		}
+
+		// map iteration is random; failures are reported in field order, then unknown keys in key order
+		var errs FieldErrors
+		for i, err := range failed {
+			if err != nil {
+				errs = errs.add(fields[i].name, err)
+			}
+		}
+		for i, ok := range seen {
+			if !ok {
+				errs = errs.add(fields[i].name, ErrMissingField)
+			}
+		}
+		sort.Strings(unknown)
+		for _, key := range unknown {
+			errs = errs.add(key, ErrUnknownField)
+		}
+		return errs.err()
+	}, true
+}
+
+// Wrap builds Mappings from typed values to empty interfaces, producing trees.
+// Types that implement encoding.TextMarshaler are left to other Builders, as they are usually treated as scalars.
+func (x *Tree) Wrap(t Type) (Mapping, bool) {
+	tDst, tSrc := t.Out(0), t.In(0)
+	if tDst.Kind() != Interface || tDst.NumMethod() != 0 || tSrc.Kind() == Interface || implements(tSrc, typeTextMarshaler) {
+		return nil, false
+	}
+
+	switch tSrc.Kind() {
+	case Pointer:
+		return func(dst, src Value, s *State) error {
+			if src.IsNil() {
+				dst.SetZero()
+				return nil
+			}
+			if err := s.descend(); err != nil {
+				return err
+			}
+			defer s.ascend()
+			if err := s.enter(src); err != nil {
+				return err
+			}
+			defer s.leave(src)
+			e := src.Elem()
+			return x.Fields.Get(tDst, e.Type())(dst, e, s)
+		}, true
+	case Slice, Array:
+		if isBytes(tSrc) {
+			return nil, false
+		}
+		return func(dst, src Value, s *State) error {
+			if tSrc.Kind() == Slice && src.IsNil() {
+				dst.SetZero()
+				return nil
+			}
+			if err := s.descend(); err != nil {
+				return err
+			}
+			defer s.ascend()
+			if tSrc.Kind() == Slice && src.Len() > 0 {
+				if err := s.enter(src); err != nil {
+					return err
+				}
+				defer s.leave(src)
+			}
+			n := src.Len()
+			o := MakeSlice(typeTreeSlice, n, n)
+			fn := x.Fields.Get(typeAny, tSrc.Elem())
+			for i := 0; i < n; i++ {
+				if err := fn(o.Index(i), src.Index(i), s); err != nil {
+					return err
+				}
+			}
+			dst.Set(o)
+			return nil
+		}, true
+	case Map:
+		return func(dst, src Value, s *State) error {
+			if src.IsNil() {
+				dst.SetZero()
+				return nil
+			}
+			if err := s.descend(); err != nil {
+				return err
+			}
+			defer s.ascend()
+			if err := s.enter(src); err != nil {
+				return err
+			}
+			defer s.leave(src)
+			o := MakeMapWithSize(typeTreeMap, src.Len())
+			fn := x.Fields.Get(typeAny, tSrc.Elem())
+			for iter := src.MapRange(); iter.Next(); {
+				v := New(typeAny).Elem()
+				if err := fn(v, iter.Value(), s); err != nil {
+					return err
+				}
+				o.SetMapIndex(ValueOf(treeKey(iter.Key())), v)
+			}
+			dst.Set(o)
+			return nil
+		}, true
+	case Struct:
+		fields := taggedFields(tSrc, x.Key)
+		return func(dst, src Value, s *State) error {
+			if err := s.descend(); err != nil {
+				return err
+			}
+			defer s.ascend()
+			o := MakeMapWithSize(typeTreeMap, len(fields))
+			for _, f := range fields {
+				v := New(typeAny).Elem()
+				if err := x.Fields.Get(typeAny, f.Type)(v, src.FieldByIndex(f.Index), s); err != nil {
+					return fmt.Errorf("field %s: %w", f.Name, err)
+				}
+				o.SetMapIndex(ValueOf(f.name), v)
+			}
+			dst.Set(o)
+			return nil
+		}, true
+	}
+	return nil, false
+}
+
+// treeKey normalizes an object key to its string form.
+func treeKey(k Value) string {
+	if k.Kind() == Interface {
+		if k.IsNil() {
+			return ""
+		}
+		k = k.Elem()
+	}
+	if k.Kind() == String {
+		return k.String()
+	}
+	return fmt.Sprint(k.Interface())
+}
+
+// treeScalar returns true for the kinds that YAML and JSON scalars decode into, other than strings.
+func treeScalar(k Kind) bool {
+	switch k {
+	case Bool, Int, Int8, Int16, Int32, Int64, Uint, Uint8, Uint16, Uint32, Uint64, Float32, Float64:
+		return true
+	}
+	return false
+}
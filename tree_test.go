@@ -0,0 +1,117 @@
+package conv
+
+import (
+	"errors"
+	. "reflect"
+	"testing"
+)
+
+func TestTree(t *testing.T) {
+	type server struct {
+		Host  string `yaml:"host"`
+		Port  int    `yaml:"port"`
+		Debug bool
+	}
+	type config struct {
+		Servers []server          `yaml:"servers"`
+		Limits  map[string]int    `yaml:"limits"`
+		Extra   map[string]string `yaml:"extra"`
+	}
+
+	// as decoded by an older YAML library
+	tree := map[any]any{
+		"servers": []any{
+			map[any]any{"host": "a", "port": 80, "DEBUG": true},
+			map[any]any{"host": "b"},
+		},
+		"limits": map[any]any{"x": 1},
+		"extra":  map[any]any{1: 2.5, true: "y"},
+		"ignore": "me",
+	}
+
+	x := &Tree{Key: "yaml"}
+	m := NewTreeMapper(x, nil)
+
+	var c config
+	if err := m.Map(&c, tree); err != nil {
+		t.Fatal(err)
+	}
+	exp := config{
+		Servers: []server{{"a", 80, true}, {"b", 0, false}},
+		Limits:  map[string]int{"x": 1},
+		Extra:   map[string]string{"1": "2.5", "true": "y"},
+	}
+	if !DeepEqual(c, exp) {
+		t.Error("wrong struct", c)
+	}
+
+	var back any
+	if err := m.Map(&back, &c); err != nil {
+		t.Fatal(err)
+	}
+	expTree := map[string]any{
+		"servers": []any{
+			map[string]any{"host": "a", "port": 80, "Debug": true},
+			map[string]any{"host": "b", "port": 0, "Debug": false},
+		},
+		"limits": map[string]any{"x": 1},
+		"extra":  map[string]any{"1": "2.5", "true": "y"},
+	}
+	if !DeepEqual(back, expTree) {
+		t.Error("wrong tree", back)
+	}
+
+	var norm any
+	if err := m.Map(&norm, any(tree["limits"])); err != nil || !DeepEqual(norm, map[string]any{"x": 1}) {
+		t.Error("key normalization failed", norm, err)
+	}
+}
+
+func TestTreeStrict(t *testing.T) {
+	type server struct {
+		Host string `yaml:"host"`
+		Port int    `yaml:"port"`
+	}
+	m := NewTreeMapper(&Tree{Key: "yaml"}, nil)
+	m.SetStrict(true)
+
+	var s server
+	if err := m.Map(&s, map[string]any{"host": "a", "port": 1}); err != nil || s != (server{"a", 1}) {
+		t.Error("exact tree should pass", s, err)
+	}
+	err := m.Map(&s, map[string]any{"host": "b", "tls": true, "debug": 1})
+	if err == nil || err.Error() != "field port: missing field\nfield debug: unknown field\nfield tls: unknown field" {
+		t.Error("wrong strict error", err)
+	}
+}
+
+func TestTreeCycle(t *testing.T) {
+	type node struct {
+		Name string
+		Next *node
+	}
+	n := &node{Name: "a"}
+	n.Next = &node{Name: "b", Next: n}
+	m := NewTreeMapper(&Tree{}, nil)
+
+	var o any
+	if err := m.Map(&o, n); !errors.Is(err, ErrCycle) {
+		t.Error("expected cycle", err)
+	}
+	l := []any{nil}
+	l[0] = l
+	if err := m.Map(&o, map[string][]any{"l": l}); !errors.Is(err, ErrCycle) {
+		t.Error("expected slice cycle", err)
+	}
+
+	n.Next.Next = &node{Name: "c"}
+	m.SetMaxDepth(2)
+	if err := m.Map(&o, n); !errors.Is(err, ErrDepth) {
+		t.Error("expected depth limit", err)
+	}
+	m.SetMaxDepth(10)
+	exp := map[string]any{"Name": "a", "Next": map[string]any{"Name": "b", "Next": map[string]any{"Name": "c", "Next": nil}}}
+	if err := m.Map(&o, n); err != nil || !DeepEqual(o, exp) {
+		t.Error("wrong tree", o, err)
+	}
+}
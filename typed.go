@@ -0,0 +1,55 @@
+package conv
+
+import (
+	. "reflect"
+)
+
+// A TypedScheme is a Scheme of Converters into T, whose members are declared along with their source type through UseTyped.
+// Member signatures are checked by the compiler, rather than surfacing as types that the Scheme doesn't cover at run time.
+type TypedScheme[T any] struct {
+	s Scheme[Converter[T]]
+}
+
+// UseTyped adds "fn" as the Converter of source type S.
+// If S is an interface type, "fn" covers all types implementing it.
+func UseTyped[S any, T any](x *TypedScheme[T], fn func(S) (T, error)) {
+	tS := TypeEval[S]()
+	x.s.Use(func(t Type) (Converter[T], bool) {
+		if t != tS && (tS.Kind() != Interface || !t.Implements(tS)) {
+			return nil, false
+		}
+		return func(v Value) (T, error) {
+			return fn(v.Interface().(S))
+		}, true
+	})
+}
+
+func (x *TypedScheme[T]) Build(t Type) (Converter[T], bool) {
+	return x.s.Build(t)
+}
+
+// A TypedInverseScheme is a Scheme of Inverters from T, whose members are declared along with their destination type through UseTypedInverse.
+type TypedInverseScheme[T any] struct {
+	s Scheme[Inverter[T]]
+}
+
+// UseTypedInverse adds "fn" as the Inverter into destination type S.
+func UseTypedInverse[S any, T any](x *TypedInverseScheme[T], fn func(T) (S, error)) {
+	tS := TypeEval[S]()
+	x.s.Use(func(t Type) (Inverter[T], bool) {
+		if t != tS {
+			return nil, false
+		}
+		return func(v T) (Value, error) {
+			o, err := fn(v)
+			if err != nil {
+				return Value{}, err
+			}
+			return ValueOf(&o).Elem(), nil
+		}, true
+	})
+}
+
+func (x *TypedInverseScheme[T]) Build(t Type) (Inverter[T], bool) {
+	return x.s.Build(t)
+}
@@ -0,0 +1,40 @@
+package conv
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestTypedScheme(t *testing.T) {
+	var scheme TypedScheme[string]
+	UseTyped(&scheme, func(v int) (string, error) {
+		return strconv.Itoa(v), nil
+	})
+	UseTyped(&scheme, func(v fmt.Stringer) (string, error) {
+		return v.String(), nil
+	})
+	c := NewConversion(scheme.Build)
+
+	if o, err := c.Call(42); err != nil || o != "42" {
+		t.Error("wrong int", o, err)
+	}
+	if o, err := c.Call(time.Second); err != nil || o != "1s" {
+		t.Error("wrong Stringer", o, err)
+	}
+	if _, err := c.Call(int8(1)); !errors.Is(err, ErrInvalid) {
+		t.Error("expected invalid int8", err)
+	}
+
+	var inverse TypedInverseScheme[string]
+	UseTypedInverse(&inverse, strconv.Atoi)
+	inv := NewInversion(inverse.Build)
+	if o, err := As[int](inv, "7"); err != nil || o != 7 {
+		t.Error("wrong inversion", o, err)
+	}
+	if _, err := As[int](inv, "x"); err == nil {
+		t.Error("expected parse error")
+	}
+}
@@ -0,0 +1,63 @@
+package conv
+
+import (
+	. "reflect"
+	"sync"
+)
+
+// typeNames holds the types registered through RegisterType.
+var typeNames = struct {
+	m     map[string]Type
+	names map[Type]string // first name of each type
+	mux   sync.RWMutex
+}{m: make(map[string]Type), names: make(map[Type]string)}
+
+// RegisterType makes "t" resolvable by "name" through TypeByName, so that dynamic systems, such as configuration driven pipelines and script hosts, can pick destination types from strings, and look up their Inverters:
+//
+//	conv.RegisterType("user", conv.TypeEval[User]())
+//
+//	t, _ := conv.TypeByName(cfg.Type)
+//	v, err := inversion.Invert(t, input)
+//
+// Registering the same type again under the same name has no effect. Panics if "t" is nil, or "name" is already registered with another type.
+func RegisterType(name string, t Type) {
+	if t == nil {
+		panic("conv: nil type " + name)
+	}
+	typeNames.mux.Lock()
+	defer typeNames.mux.Unlock()
+
+	if old, ok := typeNames.m[name]; ok && old != t {
+		panic("conv: type " + name + " already registered as " + old.String())
+	}
+	typeNames.m[name] = t
+	if _, ok := typeNames.names[t]; !ok {
+		typeNames.names[t] = name
+	}
+}
+
+// TypeByName returns the type registered under "name".
+func TypeByName(name string) (Type, bool) {
+	typeNames.mux.RLock()
+	defer typeNames.mux.RUnlock()
+
+	t, ok := typeNames.m[name]
+	return t, ok
+}
+
+// TypeName returns the name "t" was first registered under.
+func TypeName(t Type) (string, bool) {
+	typeNames.mux.RLock()
+	defer typeNames.mux.RUnlock()
+
+	name, ok := typeNames.names[t]
+	return name, ok
+}
+
+// TypeNames returns the registered type names, in order.
+func TypeNames() []string {
+	typeNames.mux.RLock()
+	defer typeNames.mux.RUnlock()
+
+	return sortedKeys(typeNames.m)
+}
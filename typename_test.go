@@ -0,0 +1,48 @@
+package conv
+
+import (
+	"testing"
+)
+
+func TestTypeByName(t *testing.T) {
+	type user struct {
+		Name string
+	}
+	RegisterType("test.user", TypeEval[user]())
+	RegisterType("test.user", TypeEval[user]())
+	RegisterType("test.count", TypeEval[int]())
+
+	typ, ok := TypeByName("test.count")
+	if !ok || typ != TypeEval[int]() {
+		t.Fatal("wrong type", typ)
+	}
+	if name, ok := TypeName(TypeEval[user]()); !ok || name != "test.user" {
+		t.Error("wrong name", name)
+	}
+	if _, ok := TypeByName("test.missing"); ok {
+		t.Error("unexpected type")
+	}
+
+	inv := NewInversion(StrconvInverter)
+	if v, err := inv.Invert(typ, "12"); err != nil || v.Interface() != 12 {
+		t.Error("wrong inversion", v, err)
+	}
+
+	names := TypeNames()
+	found := 0
+	for _, name := range names {
+		if name == "test.user" || name == "test.count" {
+			found++
+		}
+	}
+	if found != 2 {
+		t.Error("wrong names", names)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("conflicting registration should panic")
+		}
+	}()
+	RegisterType("test.count", TypeEval[string]())
+}
@@ -0,0 +1,189 @@
+package conv
+
+import (
+	"fmt"
+	. "reflect"
+	"sync"
+)
+
+// A Union is a Builder of Mappings between a tagged union, held in interface type Type, and its encoded forms, where discriminator Key names the concrete type.
+// Encoded forms are structs with a string field named Key, and maps with string keys, holding Key as an entry, such as the objects of Tree.
+//
+// Mapping an encoded form into Type reads the discriminator, and maps the whole source into a new value of the registered member type.
+// Mapping into an encoded form maps the dynamic value of the source through Fields, then writes the discriminator of its type back. Empty interface destinations receive the result of Fields as is, such as a tree object, with the discriminator added if it is a map.
+//
+// As Mapper.Map receives top level sources as their dynamic values, unions are encoded when held in fields or elements, or when mapped through Mapper.Get.
+//
+// Members are registered through Member.
+// If Registered is set, types registered through RegisterType also serve as members, under their registered names, when they implement Type. Members registered through Member take precedence.
+type Union struct {
+	Fields     *Mapper
+	Type       Type
+	Key        string
+	Registered bool // resolve discriminators through the type name registry
+
+	types map[string]Type
+	names map[Type]string
+	mux   sync.RWMutex
+}
+
+// Member registers "t" as the concrete type for discriminator value "name". "t" must implement Type.
+// Panics if either the name or type is already registered, as that is a programming error.
+func (x *Union) Member(name string, t Type) {
+	if !t.Implements(x.Type) {
+		panic(fmt.Sprintf("conv: union member %v does not implement %v", t, x.Type))
+	}
+
+	x.mux.Lock()
+	defer x.mux.Unlock()
+
+	if x.types == nil {
+		x.types = make(map[string]Type)
+		x.names = make(map[Type]string)
+	}
+	if _, ok := x.types[name]; ok {
+		panic(fmt.Sprintf("conv: union member %q registered twice", name))
+	}
+	if _, ok := x.names[t]; ok {
+		panic(fmt.Sprintf("conv: union member %v registered twice", t))
+	}
+	x.types[name] = t
+	x.names[t] = name
+}
+
+func (x *Union) member(name string) (Type, bool) {
+	x.mux.RLock()
+	t, ok := x.types[name]
+	x.mux.RUnlock()
+	if ok || !x.Registered {
+		return t, ok
+	}
+	if t, ok = TypeByName(name); ok && t.Implements(x.Type) {
+		return t, true
+	}
+	return nil, false
+}
+
+func (x *Union) name(t Type) (string, bool) {
+	x.mux.RLock()
+	name, ok := x.names[t]
+	x.mux.RUnlock()
+	if ok || !x.Registered {
+		return name, ok
+	}
+	return TypeName(t)
+}
+
+// Build combines the Decode and Encode Builders.
+func (x *Union) Build(t Type) (Mapping, bool) {
+	if o, ok := x.Decode(t); ok {
+		return o, true
+	}
+	return x.Encode(t)
+}
+
+// Decode builds Mappings from encoded forms to Type.
+// Missing discriminators fail with ErrMissingField, and unregistered ones with ErrInvalid.
+func (x *Union) Decode(t Type) (Mapping, bool) {
+	tDst, tSrc := t.Out(0), t.In(0)
+	if tDst != x.Type || !x.tagged(tSrc) {
+		return nil, false
+	}
+	return func(dst, src Value, s *State) error {
+		d, ok := x.discriminator(src)
+		if !ok {
+			return fmt.Errorf("union %s: %s: %w", describeType(tDst), x.Key, ErrMissingField)
+		}
+		tMember, ok := x.member(d)
+		if !ok {
+			return fmt.Errorf("union %s: %s %q: %w", describeType(tDst), x.Key, d, ErrInvalid)
+		}
+		o := New(tMember).Elem()
+		if err := x.Fields.Get(tMember, tSrc)(o, src, s); err != nil {
+			return fmt.Errorf("union %s: %s %q: %w", describeType(tDst), x.Key, d, err)
+		}
+		dst.Set(o)
+		return nil
+	}, true
+}
+
+// Encode builds Mappings from Type to encoded forms, or empty interfaces.
+// Nil sources produce zero destinations. Unregistered dynamic types fail with ErrInvalid.
+func (x *Union) Encode(t Type) (Mapping, bool) {
+	tDst, tSrc := t.Out(0), t.In(0)
+	if tSrc != x.Type {
+		return nil, false
+	}
+	dynamic := tDst.Kind() == Interface && tDst.NumMethod() == 0
+	if !dynamic && !x.tagged(tDst) {
+		return nil, false
+	}
+	return func(dst, src Value, s *State) error {
+		if src.IsNil() {
+			dst.SetZero()
+			return nil
+		}
+		e := src.Elem()
+		d, ok := x.name(e.Type())
+		if !ok {
+			return fmt.Errorf("union %s: member %s: %w", describeType(tSrc), describeType(e.Type()), ErrInvalid)
+		}
+		if err := x.Fields.Get(tDst, e.Type())(dst, e, s); err != nil {
+			return fmt.Errorf("union %s: %s %q: %w", describeType(tSrc), x.Key, d, err)
+		}
+
+		o := dst
+		if dynamic {
+			if o = dst.Elem(); o.Kind() != Map || !x.tagged(o.Type()) {
+				return nil
+			}
+		}
+		x.setDiscriminator(o, d)
+		return nil
+	}, true
+}
+
+// tagged returns true if "t" can hold the discriminator.
+func (x *Union) tagged(t Type) bool {
+	switch t.Kind() {
+	case Struct:
+		f, ok := t.FieldByName(x.Key)
+		return ok && f.IsExported() && f.Type.Kind() == String
+	case Map:
+		if t.Key().Kind() != String {
+			return false
+		}
+		k := t.Elem().Kind()
+		return k == String || (k == Interface && t.Elem().NumMethod() == 0)
+	}
+	return false
+}
+
+func (x *Union) discriminator(v Value) (string, bool) {
+	if v.Kind() == Struct {
+		return v.FieldByName(x.Key).String(), true
+	}
+	if v.IsNil() {
+		return "", false
+	}
+	e := v.MapIndex(ValueOf(x.Key).Convert(v.Type().Key()))
+	if e.IsValid() && e.Kind() == Interface {
+		e = e.Elem()
+	}
+	if !e.IsValid() || e.Kind() != String {
+		return "", false
+	}
+	return e.String(), true
+}
+
+func (x *Union) setDiscriminator(v Value, d string) {
+	if v.Kind() == Struct {
+		v.FieldByName(x.Key).SetString(d)
+		return
+	}
+	t := v.Type()
+	if v.IsNil() {
+		v.Set(MakeMap(t))
+	}
+	v.SetMapIndex(ValueOf(x.Key).Convert(t.Key()), ValueOf(d).Convert(t.Elem()))
+}
@@ -0,0 +1,164 @@
+package conv
+
+import (
+	"errors"
+	. "reflect"
+	"testing"
+)
+
+type unionShape interface {
+	area() float64
+}
+
+type unionCircle struct {
+	R float64
+}
+
+func (x unionCircle) area() float64 {
+	return 3 * x.R * x.R
+}
+
+type unionSquare struct {
+	S float64
+}
+
+func (x *unionSquare) area() float64 {
+	return x.S * x.S
+}
+
+func TestUnionTree(t *testing.T) {
+	tree := &Tree{}
+	u := &Union{Type: TypeEval[unionShape](), Key: "kind"}
+	u.Member("circle", TypeEval[unionCircle]())
+	u.Member("square", TypeEval[*unionSquare]())
+	m := NewDeepMapper(nil, u.Build, tree.Build)
+	tree.Fields, u.Fields = m, m
+
+	type drawing struct {
+		Shapes []unionShape
+	}
+	var d drawing
+	err := m.Map(&d, map[string]any{
+		"Shapes": []any{
+			map[string]any{"kind": "circle", "R": 2.0},
+			map[string]any{"kind": "square", "S": 3.0},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(d.Shapes) != 2 || d.Shapes[0] != (unionCircle{2}) || d.Shapes[1].(*unionSquare).S != 3 {
+		t.Fatal("wrong shapes", d.Shapes)
+	}
+
+	// top level interface values reach Map as their dynamic type, so the union is encoded from within a struct
+	var out any
+	if err := m.Map(&out, d); err != nil {
+		t.Fatal(err)
+	}
+	shapes := out.(map[string]any)["Shapes"].([]any)
+	if o, ok := shapes[1].(map[string]any); !ok || o["kind"] != "square" || o["S"] != 3.0 {
+		t.Error("wrong tree", shapes)
+	}
+
+	var sh unionShape
+	if err := m.Map(&sh, map[string]any{"kind": "triangle"}); !errors.Is(err, ErrInvalid) {
+		t.Error("expected unknown member", err)
+	}
+	if err := m.Map(&sh, map[string]any{"R": 1.0}); !errors.Is(err, ErrMissingField) {
+		t.Error("expected missing discriminator", err)
+	}
+}
+
+func TestUnionStruct(t *testing.T) {
+	type record struct {
+		Kind string
+		R, S float64
+	}
+	u := &Union{Type: TypeEval[unionShape](), Key: "Kind"}
+	u.Member("circle", TypeEval[unionCircle]())
+	u.Member("square", TypeEval[*unionSquare]())
+	m := NewDeepMapper(nil, u.Build)
+	u.Fields = m
+
+	var sh unionShape
+	if err := m.Map(&sh, record{Kind: "square", S: 2}); err != nil || sh.area() != 4 {
+		t.Error("wrong shape", sh, err)
+	}
+
+	type drawing struct {
+		Shapes []unionShape
+	}
+	type table struct {
+		Shapes []record
+	}
+	var tb table
+	if err := m.Map(&tb, drawing{[]unionShape{unionCircle{1}, &unionSquare{2}}}); err != nil {
+		t.Fatal(err)
+	}
+	if len(tb.Shapes) != 2 || tb.Shapes[0] != (record{Kind: "circle", R: 1}) || tb.Shapes[1] != (record{Kind: "square", S: 2}) {
+		t.Error("wrong records", tb)
+	}
+	r := record{Kind: "x"}
+	if err := m.Get(TypeEval[record](), u.Type)(ValueOf(&r).Elem(), Zero(u.Type), nil); err != nil || r != (record{}) {
+		t.Error("nil should map to zero", r, err)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("duplicate member should panic")
+		}
+	}()
+	u.Member("circle", TypeEval[*unionCircle]())
+}
+
+type unionTriangle struct {
+	B, H float64
+}
+
+func (x unionTriangle) area() float64 {
+	return x.B * x.H / 2
+}
+
+func TestUnionRegistered(t *testing.T) {
+	RegisterType("test.union.triangle", TypeEval[unionTriangle]())
+	RegisterType("test.union.int", TypeEval[int]())
+
+	tree := &Tree{}
+	u := &Union{Type: TypeEval[unionShape](), Key: "kind", Registered: true}
+	u.Member("circle", TypeEval[unionCircle]())
+	m := NewDeepMapper(nil, u.Build, tree.Build)
+	tree.Fields, u.Fields = m, m
+
+	type drawing struct {
+		Shapes []unionShape
+	}
+	var d drawing
+	err := m.Map(&d, map[string]any{
+		"Shapes": []any{
+			map[string]any{"kind": "circle", "R": 1.0},
+			map[string]any{"kind": "test.union.triangle", "B": 2.0, "H": 3.0},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(d.Shapes) != 2 || d.Shapes[1] != (unionTriangle{2, 3}) {
+		t.Fatal("wrong shapes", d.Shapes)
+	}
+
+	var out any
+	if err := m.Map(&out, d); err != nil {
+		t.Fatal(err)
+	}
+	shapes := out.(map[string]any)["Shapes"].([]any)
+	if o, ok := shapes[1].(map[string]any); !ok || o["kind"] != "test.union.triangle" || o["H"] != 3.0 {
+		t.Error("wrong tree", shapes)
+	}
+
+	// registered types must still implement the union
+	var sh unionShape
+	if err := m.Map(&sh, map[string]any{"kind": "test.union.int"}); !errors.Is(err, ErrInvalid) {
+		t.Error("expected unknown member", err)
+	}
+}
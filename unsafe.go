@@ -0,0 +1,55 @@
+//go:build !conv_safe && !purego
+
+package conv
+
+import (
+	. "reflect"
+	"unsafe"
+)
+
+// Package unsafe is only used by this file. Building with the conv_safe or purego tag replaces it with safe.go, for environments that forbid unsafe code.
+// Features relying on it then fall back to plain reflection, or are left out.
+const safeMode = false
+
+// hostLittleEndian is true if values can be copied between slices and Buffers directly.
+var hostLittleEndian = func() bool {
+	n := uint16(1)
+	return *(*byte)(unsafe.Pointer(&n)) == 1
+}()
+
+// fieldAt returns the value of type "t" at offset "off" inside settable value "v".
+func fieldAt(v Value, t Type, off uintptr) Value {
+	return NewAt(t, unsafe.Add(v.Addr().UnsafePointer(), off)).Elem()
+}
+
+// viewAs returns the memory of settable value "v" as a value of type "t", which must have the same representation.
+func viewAs(v Value, t Type) Value {
+	return NewAt(t, v.Addr().UnsafePointer()).Elem()
+}
+
+// typeHash returns a hash of "t", from the address of its runtime descriptor.
+func typeHash(t Type) uint64 {
+	p := (*[2]uintptr)(unsafe.Pointer(&t))[1]
+	// Fibonacci hashing spreads the aligned addresses over the high bits
+	return uint64(p) * 0x9e3779b97f4a7c15
+}
+
+// copyAt copies "n" bytes from offset "sOff" inside addressable value "src", to offset "dOff" inside settable value "dst".
+// The copied memory must not hold pointers, as the copy bypasses write barriers.
+func copyAt(dst, src Value, dOff, sOff, n uintptr) {
+	d := unsafe.Add(dst.Addr().UnsafePointer(), dOff)
+	s := unsafe.Add(src.Addr().UnsafePointer(), sOff)
+	copy(unsafe.Slice((*byte)(d), n), unsafe.Slice((*byte)(s), n))
+}
+
+// rawBytes returns the first "n" bytes of the backing array of slice "v".
+func rawBytes(v Value, n int) []byte {
+	return unsafe.Slice((*byte)(v.UnsafePointer()), n)
+}
+
+// Memory returns the Size bytes at "p" as a slice, for use with Read and Write, such as for C allocated structs.
+// The caller is responsible for keeping the memory alive while the slice is in use.
+// Not available in safe mode.
+func (x *Layout) Memory(p unsafe.Pointer) []byte {
+	return unsafe.Slice((*byte)(p), x.Size)
+}
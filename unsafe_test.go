@@ -0,0 +1,44 @@
+//go:build !conv_safe && !purego
+
+package conv
+
+import (
+	"encoding/binary"
+	. "reflect"
+	"testing"
+	"unsafe"
+)
+
+func TestLayoutMemory(t *testing.T) {
+	type header struct {
+		Flag  bool
+		Count uint32
+		Scale float64
+	}
+
+	var order binary.ByteOrder = binary.BigEndian
+	if hostLittleEndian {
+		order = binary.LittleEndian
+	}
+	l, err := CLayout(TypeEval[header](), order)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// native memory, as shared with C
+	h := header{true, 7, 0.5}
+	var o header
+	if err := l.Read(l.Memory(unsafe.Pointer(&h)), ValueOf(&o).Elem()); err != nil {
+		t.Fatal(err)
+	}
+	if o != h {
+		t.Error("wrong read", o)
+	}
+	o.Count = 9
+	if err := l.Write(l.Memory(unsafe.Pointer(&h)), ValueOf(o)); err != nil {
+		t.Fatal(err)
+	}
+	if h.Count != 9 {
+		t.Error("wrong write", h)
+	}
+}
@@ -0,0 +1,315 @@
+package conv
+
+import (
+	"fmt"
+	"math"
+	. "reflect"
+
+	"github.com/blitz-frost/conv/wrap"
+)
+
+// wireMaxDepth limits the nesting of encoded and decoded values, as wrappers don't expose pointer identities to detect cycles with.
+const wireMaxDepth = 1000
+
+// Self describing binary formats (CBOR, MessagePack) are decoded in two steps: first into a wire tree, then into the destination value.
+// Wire trees are made of nil, bool, int64, uint64, float64, string, []byte, []any and wireMap values.
+type wireMap []wireEntry
+
+// wireEntry holds a map entry. Keys are kept as decoded, and may be any wire value.
+type wireEntry struct {
+	k, v any
+}
+
+// wireAssign sets "dst" from wire value "v".
+// Numbers are only assigned if they fit the destination exactly: integers must be in range, and floats must have the same value after conversion.
+// Nil sets zero values. Interface destinations receive plain values, with integers as int64 where possible, and wireMaps becoming map[string]any, or map[any]any if not all keys are strings.
+// Struct destinations take maps keyed by field name, or conv tag name, as visited by wrap.StructIter; unknown keys are ignored.
+func wireAssign(dst Value, v any) error {
+	if v == nil {
+		dst.SetZero()
+		return nil
+	}
+
+	t := dst.Type()
+	switch t.Kind() {
+	case Pointer:
+		if dst.IsNil() {
+			dst.Set(New(t.Elem()))
+		}
+		return wireAssign(dst.Elem(), v)
+	case Interface:
+		o, err := wirePlain(v)
+		if err != nil {
+			return err
+		}
+		ov := ValueOf(o)
+		if !ov.Type().AssignableTo(t) {
+			return wireMismatch(t, v)
+		}
+		dst.Set(ov)
+		return nil
+	case Bool:
+		if b, ok := v.(bool); ok {
+			dst.SetBool(b)
+			return nil
+		}
+	case Int, Int8, Int16, Int32, Int64:
+		n, ok := wireInt(v)
+		if !ok || dst.OverflowInt(n) {
+			return wireRange(t, v)
+		}
+		dst.SetInt(n)
+		return nil
+	case Uint, Uint8, Uint16, Uint32, Uint64, Uintptr:
+		n, ok := wireUint(v)
+		if !ok || dst.OverflowUint(n) {
+			return wireRange(t, v)
+		}
+		dst.SetUint(n)
+		return nil
+	case Float32, Float64:
+		f, ok := wireFloat(v)
+		if !ok || (t.Kind() == Float32 && !math.IsNaN(f) && float64(float32(f)) != f) {
+			return wireRange(t, v)
+		}
+		dst.SetFloat(f)
+		return nil
+	case Complex64, Complex128:
+		f, ok := wireFloat(v)
+		if !ok || (t.Kind() == Complex64 && !math.IsNaN(f) && float64(float32(f)) != f) {
+			return wireRange(t, v)
+		}
+		dst.SetComplex(complex(f, 0))
+		return nil
+	case String:
+		switch x := v.(type) {
+		case string:
+			dst.SetString(x)
+			return nil
+		case []byte:
+			dst.SetString(string(x))
+			return nil
+		}
+	case Slice:
+		if isBytes(t) {
+			switch x := v.(type) {
+			case []byte:
+				dst.SetBytes(append([]byte{}, x...))
+				return nil
+			case string:
+				dst.SetBytes([]byte(x))
+				return nil
+			}
+		}
+		if x, ok := v.([]any); ok {
+			o := MakeSlice(t, len(x), len(x))
+			if err := wireElems(o, x); err != nil {
+				return err
+			}
+			dst.Set(o)
+			return nil
+		}
+	case Array:
+		if t.Elem().Kind() == Uint8 {
+			if x, ok := v.([]byte); ok {
+				if len(x) != t.Len() {
+					return fmt.Errorf("%d bytes into %s: %w", len(x), describeType(t), ErrInvalid)
+				}
+				for i, c := range x {
+					dst.Index(i).SetUint(uint64(c))
+				}
+				return nil
+			}
+		}
+		if x, ok := v.([]any); ok {
+			if len(x) != t.Len() {
+				return fmt.Errorf("%d elements into %s: %w", len(x), describeType(t), ErrInvalid)
+			}
+			return wireElems(dst, x)
+		}
+	case Map:
+		if x, ok := v.(wireMap); ok {
+			o := MakeMapWithSize(t, len(x))
+			k, e := New(t.Key()).Elem(), New(t.Elem()).Elem()
+			for _, entry := range x {
+				k.SetZero()
+				e.SetZero()
+				if err := wireAssign(k, entry.k); err != nil {
+					return fmt.Errorf("key: %w", err)
+				}
+				// keys holding interfaces may hold decoded slices and maps, which can't be hashed
+				if !k.Comparable() {
+					return fmt.Errorf("unhashable key %v: %w", entry.k, ErrInvalid)
+				}
+				if err := wireAssign(e, entry.v); err != nil {
+					return fmt.Errorf("key %v: %w", entry.k, err)
+				}
+				o.SetMapIndex(k, e)
+			}
+			dst.Set(o)
+			return nil
+		}
+	case Struct:
+		if x, ok := v.(wireMap); ok {
+			fields := make(map[string][]int)
+			for _, f := range wrap.Fields(t) {
+				name, _ := wrap.Tag(f)
+				fields[name] = f.Index
+			}
+			for _, entry := range x {
+				name, ok := entry.k.(string)
+				if !ok {
+					continue
+				}
+				index, ok := fields[name]
+				if !ok {
+					continue
+				}
+				fv, err := dst.FieldByIndexErr(index)
+				if err != nil {
+					// nil embedded pointer
+					fv = wireField(dst, index)
+				}
+				if err := wireAssign(fv, entry.v); err != nil {
+					return fmt.Errorf("field %s: %w", name, err)
+				}
+			}
+			return nil
+		}
+	}
+	return wireMismatch(t, v)
+}
+
+func wireElems(dst Value, src []any) error {
+	for i, e := range src {
+		if err := wireAssign(dst.Index(i), e); err != nil {
+			return fmt.Errorf("index %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// wireField returns the field of struct "v" at "index", allocating nil embedded pointers on the way.
+func wireField(v Value, index []int) Value {
+	for i, x := range index {
+		if i > 0 && v.Kind() == Pointer {
+			if v.IsNil() {
+				v.Set(New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v
+}
+
+// wirePlain converts wire value "v" to the plain values held by interface destinations.
+// Integers become int64, unless out of range.
+func wirePlain(v any) (any, error) {
+	switch x := v.(type) {
+	case uint64:
+		if x <= math.MaxInt64 {
+			return int64(x), nil
+		}
+	case []any:
+		o := make([]any, len(x))
+		for i, e := range x {
+			var err error
+			if o[i], err = wirePlain(e); err != nil {
+				return nil, err
+			}
+		}
+		return o, nil
+	case wireMap:
+		text := true
+		for _, entry := range x {
+			if _, ok := entry.k.(string); !ok {
+				text = false
+				break
+			}
+		}
+		if text {
+			o := make(map[string]any, len(x))
+			for _, entry := range x {
+				e, err := wirePlain(entry.v)
+				if err != nil {
+					return nil, err
+				}
+				o[entry.k.(string)] = e
+			}
+			return o, nil
+		}
+		o := make(map[any]any, len(x))
+		for _, entry := range x {
+			if !TypeOf(entry.k).Comparable() {
+				return nil, fmt.Errorf("%T map key: %w", entry.k, ErrInvalid)
+			}
+			e, err := wirePlain(entry.v)
+			if err != nil {
+				return nil, err
+			}
+			o[entry.k] = e
+		}
+		return o, nil
+	}
+	return v, nil
+}
+
+func wireInt(v any) (int64, bool) {
+	switch x := v.(type) {
+	case int64:
+		return x, true
+	case uint64:
+		return int64(x), x <= math.MaxInt64
+	case float64:
+		// 2^63 itself is representable as float64, but not as int64
+		return int64(x), x == math.Trunc(x) && x >= -(1<<63) && x < 1<<63
+	}
+	return 0, false
+}
+
+func wireUint(v any) (uint64, bool) {
+	switch x := v.(type) {
+	case int64:
+		return uint64(x), x >= 0
+	case uint64:
+		return x, true
+	case float64:
+		return uint64(x), x == math.Trunc(x) && x >= 0 && x < 1<<64
+	}
+	return 0, false
+}
+
+func wireFloat(v any) (float64, bool) {
+	switch x := v.(type) {
+	case int64:
+		f := float64(x)
+		// float64(MaxInt64) rounds up to 2^63, which cannot be converted back
+		return f, f < 1<<63 && int64(f) == x
+	case uint64:
+		f := float64(x)
+		return f, f < 1<<64 && uint64(f) == x
+	case float64:
+		return x, true
+	}
+	return 0, false
+}
+
+func wireMismatch(t Type, v any) error {
+	return fmt.Errorf("cannot decode %T into %s: %w", v, describeType(t), ErrInvalid)
+}
+
+func wireRange(t Type, v any) error {
+	switch v.(type) {
+	case int64, uint64, float64:
+		k := t.Kind()
+		switch k {
+		case Complex64:
+			k = Float32
+		case Complex128:
+			k = Float64
+		}
+		return fmt.Errorf("%v into %s: %w", v, describeType(t), numericLoss(ValueOf(v), k))
+	}
+	return wireMismatch(t, v)
+}
@@ -0,0 +1,57 @@
+package conv
+
+import (
+	"errors"
+	"math"
+	. "reflect"
+	"testing"
+)
+
+func TestWireAssign(t *testing.T) {
+	cases := []struct {
+		dst any
+		v   any
+		exp any
+		err error
+	}{
+		{int8(0), int64(-128), int8(-128), nil},
+		{int8(0), int64(128), nil, ErrOverflow},
+		{uint(0), int64(-1), nil, ErrOverflow},
+		{int64(0), uint64(math.MaxUint64), nil, ErrOverflow},
+		{int(0), 2.0, 2, nil},
+		{int(0), 2.5, nil, ErrPrecisionLoss},
+		{float32(0), 0.1, nil, ErrPrecisionLoss},
+		{float32(0), 0.5, float32(0.5), nil},
+		{float64(0), int64(math.MaxInt64), nil, ErrPrecisionLoss},
+		{float64(0), int64(1 << 53), float64(1 << 53), nil},
+		{"", []byte("b"), "b", nil},
+		{[2]byte{}, []byte("ab"), [2]byte{'a', 'b'}, nil},
+		{[2]byte{}, []byte("abc"), nil, ErrInvalid},
+		{true, int64(1), nil, ErrInvalid},
+		{new(any), uint64(1), ptr[any](int64(1)), nil},
+		{map[int]string{}, wireMap{{int64(1), "a"}}, map[int]string{1: "a"}, nil},
+	}
+	for _, c := range cases {
+		dst := New(TypeOf(c.dst)).Elem()
+		err := wireAssign(dst, c.v)
+		if !errors.Is(err, c.err) {
+			t.Errorf("%T from %v: expected error %v, got %v", c.dst, c.v, c.err, err)
+			continue
+		}
+		if err == nil && !DeepEqual(dst.Interface(), c.exp) {
+			t.Errorf("%T from %v: got %v", c.dst, c.v, dst.Interface())
+		}
+	}
+
+	var tree any
+	if err := wireAssign(ValueOf(&tree).Elem(), wireMap{{"a", []any{uint64(1), nil}}, {true, "b"}}); err != nil {
+		t.Fatal(err)
+	}
+	if !DeepEqual(tree, map[any]any{"a": []any{int64(1), nil}, true: "b"}) {
+		t.Error("wrong tree", tree)
+	}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
@@ -0,0 +1,185 @@
+package wrap
+
+import (
+	"reflect"
+)
+
+// SliceOf wraps "s" as Of would, but without going through reflect, for when its type is statically known.
+// Elements of bool, string and basic numeric types are wrapped directly; others fall back to Of.
+func SliceOf[T any](s []T) Slice {
+	return sliceOf[T]{s}
+}
+
+// MapOf wraps "m" as Of would, but without going through reflect, for when its type is statically known.
+// Keys and values are wrapped as the elements of SliceOf.
+func MapOf[K comparable, V any](m map[K]V) Map {
+	return mapOf[K, V]{m}
+}
+
+// StructOf wraps struct "v" as Of would, for when its type is statically known.
+// Only the type is known without reflect; fields are still iterated through a StructIter.
+func StructOf[T any](v T) Struct {
+	return structOf[T]{v}
+}
+
+// typeOf returns the type of T, without boxing a value.
+func typeOf[T any]() reflect.Type {
+	return reflect.TypeOf((*T)(nil)).Elem()
+}
+
+// elemOf wraps the value pointed to by "p", taking a pointer so that the type switch doesn't box the value itself.
+func elemOf[T any](p *T) any {
+	switch p := any(p).(type) {
+	case *bool:
+		return *p
+	case *string:
+		return *p
+	case *int:
+		return numberOf[int]{*p}
+	case *int8:
+		return numberOf[int8]{*p}
+	case *int16:
+		return numberOf[int16]{*p}
+	case *int32:
+		return numberOf[int32]{*p}
+	case *int64:
+		return numberOf[int64]{*p}
+	case *uint:
+		return numberOf[uint]{*p}
+	case *uint8:
+		return numberOf[uint8]{*p}
+	case *uint16:
+		return numberOf[uint16]{*p}
+	case *uint32:
+		return numberOf[uint32]{*p}
+	case *uint64:
+		return numberOf[uint64]{*p}
+	case *uintptr:
+		return numberOf[uintptr]{*p}
+	case *float32:
+		return numberOf[float32]{*p}
+	case *float64:
+		return numberOf[float64]{*p}
+	}
+	// not through the pointer, as that would expose a settable value
+	return Of(reflect.ValueOf(*p))
+}
+
+// basicNumber lists the basic numeric types that numberOf holds. Complex numbers are left to reflect.
+type basicNumber interface {
+	int | int8 | int16 | int32 | int64 | uint | uint8 | uint16 | uint32 | uint64 | uintptr | float32 | float64
+}
+
+type numberOf[N basicNumber] struct {
+	n N
+}
+
+func (x numberOf[N]) Type() reflect.Type {
+	return typeOf[N]()
+}
+
+func (x numberOf[N]) Value() any {
+	if n, ok := x.Int64(); ok {
+		return n
+	}
+	if n, ok := x.Uint64(); ok {
+		return n
+	}
+	return float64(x.n)
+}
+
+func (x numberOf[N]) Int64() (int64, bool) {
+	switch any(x.n).(type) {
+	case int, int8, int16, int32, int64:
+		return int64(x.n), true
+	}
+	return 0, false
+}
+
+func (x numberOf[N]) Uint64() (uint64, bool) {
+	switch any(x.n).(type) {
+	case uint, uint8, uint16, uint32, uint64, uintptr:
+		return uint64(x.n), true
+	}
+	return 0, false
+}
+
+func (x numberOf[N]) Float64() (float64, bool) {
+	switch any(x.n).(type) {
+	case float32, float64:
+		return float64(x.n), true
+	}
+	return 0, false
+}
+
+func (x numberOf[N]) typed() N {
+	return x.n
+}
+
+type sliceOf[T any] struct {
+	s []T
+}
+
+func (x sliceOf[T]) Type() reflect.Type {
+	return typeOf[[]T]()
+}
+
+func (x sliceOf[T]) IsNil() bool {
+	return x.s == nil
+}
+
+func (x sliceOf[T]) Len() int {
+	return len(x.s)
+}
+
+func (x sliceOf[T]) Index(i int) any {
+	return elemOf(&x.s[i])
+}
+
+func (x sliceOf[T]) typed() []T {
+	return x.s
+}
+
+type mapOf[K comparable, V any] struct {
+	m map[K]V
+}
+
+func (x mapOf[K, V]) Type() reflect.Type {
+	return typeOf[map[K]V]()
+}
+
+func (x mapOf[K, V]) IsNil() bool {
+	return x.m == nil
+}
+
+func (x mapOf[K, V]) Len() int {
+	return len(x.m)
+}
+
+func (x mapOf[K, V]) Range(fn func(k, v any) bool) {
+	for k, v := range x.m {
+		if !fn(elemOf(&k), elemOf(&v)) {
+			return
+		}
+	}
+}
+
+func (x mapOf[K, V]) typed() map[K]V {
+	return x.m
+}
+
+type structOf[T any] struct {
+	v T
+}
+
+func (x structOf[T]) Type() reflect.Type {
+	return typeOf[T]()
+}
+
+func (x structOf[T]) Iter() *StructIter {
+	return NewStructIter(reflect.ValueOf(x.v))
+}
+
+func (x structOf[T]) typed() T {
+	return x.v
+}
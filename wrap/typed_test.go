@@ -0,0 +1,123 @@
+package wrap
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSliceOf(t *testing.T) {
+	type point struct {
+		X int
+	}
+	s := []int{1, -2}
+	w := SliceOf(s)
+	if w.Type() != reflect.TypeOf(s) || w.IsNil() || w.Len() != 2 {
+		t.Fatal("wrong slice", w.Type(), w.Len())
+	}
+	n, ok := w.Index(1).(Number)
+	if !ok || n.Type() != reflect.TypeOf(0) || n.Value() != int64(-2) {
+		t.Fatal("wrong element", w.Index(1))
+	}
+	if i, ok := n.Int64(); !ok || i != -2 {
+		t.Error("wrong Int64", i, ok)
+	}
+	if _, ok := n.Float64(); ok {
+		t.Error("int should not be a float")
+	}
+	if i, ok := Get[int](n); !ok || i != -2 {
+		t.Error("wrong Get", i, ok)
+	}
+	if o, ok := Get[[]int](w); !ok || &o[0] != &s[0] {
+		t.Error("slice should unwrap to itself")
+	}
+	if !SliceOf[int](nil).IsNil() {
+		t.Error("nil slice")
+	}
+
+	// the fast path must agree with Of
+	mixed := []any{"a", true, float32(0.5), uint8(3), nil, point{1}, []string{"x"}}
+	w = SliceOf(mixed)
+	ref := Of(reflect.ValueOf(mixed)).(Slice)
+	for i := range mixed {
+		a, b := w.Index(i), ref.Index(i)
+		if an, ok := a.(Number); ok {
+			if bn, ok := b.(Number); !ok || an.Value() != bn.Value() || an.Type() != bn.Type() {
+				t.Errorf("%d: %v, expected %v", i, a, b)
+			}
+			continue
+		}
+		if ta, tb := reflect.TypeOf(a), reflect.TypeOf(b); ta != tb {
+			t.Errorf("%d: wrapped as %v, expected %v", i, ta, tb)
+		}
+	}
+
+	// elements are read only, as with Of
+	st := SliceOf([]point{{1}}).Index(0).(Struct)
+	iter := st.Iter()
+	if !iter.Next() || iter.Reflect().CanSet() {
+		t.Error("struct elements should not be settable")
+	}
+}
+
+func TestMapOf(t *testing.T) {
+	m := map[string]float64{"a": 1.5}
+	w := MapOf(m)
+	if w.Type() != reflect.TypeOf(m) || w.IsNil() || w.Len() != 1 {
+		t.Fatal("wrong map", w.Type(), w.Len())
+	}
+	w.Range(func(k, v any) bool {
+		if k != "a" {
+			t.Error("wrong key", k)
+		}
+		if f, ok := Get[float64](v); !ok || f != 1.5 {
+			t.Error("wrong value", v)
+		}
+		return true
+	})
+	if o, ok := Get[map[string]float64](w); !ok || len(o) != 1 {
+		t.Error("map should unwrap to itself")
+	}
+	if !MapOf[int, int](nil).IsNil() {
+		t.Error("nil map")
+	}
+}
+
+func TestStructOf(t *testing.T) {
+	type point struct {
+		X, Y int
+	}
+	w := StructOf(point{1, 2})
+	if w.Type() != reflect.TypeOf(point{}) {
+		t.Error("wrong type", w.Type())
+	}
+	var names []string
+	for iter := w.Iter(); iter.Next(); {
+		names = append(names, iter.Name())
+	}
+	if len(names) != 2 || names[1] != "Y" {
+		t.Error("wrong fields", names)
+	}
+	if o, ok := Get[point](w); !ok || o.Y != 2 {
+		t.Error("struct should unwrap to itself")
+	}
+}
+
+func BenchmarkSliceOf(b *testing.B) {
+	s := make([]int, 1000)
+	b.Run("Of", func(b *testing.B) {
+		w := Of(reflect.ValueOf(s)).(Slice)
+		for i := 0; i < b.N; i++ {
+			for j := 0; j < w.Len(); j++ {
+				Get[int](w.Index(j))
+			}
+		}
+	})
+	b.Run("SliceOf", func(b *testing.B) {
+		w := SliceOf(s)
+		for i := 0; i < b.N; i++ {
+			for j := 0; j < w.Len(); j++ {
+				Get[int](w.Index(j))
+			}
+		}
+	})
+}
@@ -0,0 +1,400 @@
+// Package wrap provides uniform, read only access to values of any type, so that encoders and builders can traverse them by shape rather than through reflect directly.
+package wrap
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// Of returns "v" in the wrapper matching its kind:
+//
+//   - bool and string kinds as plain Go bool and string values
+//   - numeric kinds as Number
+//   - slices and arrays as Slice
+//   - maps as Map
+//   - structs as Struct
+//   - pointers as Pointer
+//   - interfaces as the wrapper of their dynamic value
+//   - nil interfaces and invalid values as nil
+//
+// Other kinds (channels, functions, unsafe pointers) are returned as the reflect.Value itself.
+func Of(v reflect.Value) any {
+	switch v.Kind() {
+	case reflect.Invalid:
+		return nil
+	case reflect.Bool:
+		return v.Bool()
+	case reflect.String:
+		return v.String()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr, reflect.Float32, reflect.Float64, reflect.Complex64, reflect.Complex128:
+		return numberValue{v}
+	case reflect.Slice, reflect.Array:
+		return sliceValue{v}
+	case reflect.Map:
+		return mapValue{v}
+	case reflect.Struct:
+		return structValue{v}
+	case reflect.Pointer:
+		return pointerValue{v}
+	case reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+		return Of(v.Elem())
+	}
+	return v
+}
+
+// A Number wraps a value of numeric kind.
+type Number interface {
+	Type() reflect.Type
+	// Value returns the number as an int64, uint64, float64 or complex128, according to its kind.
+	Value() any
+
+	// The typed accessors return the same as Value, without boxing it, or false if the number is of another kind.
+	Int64() (int64, bool)
+	Uint64() (uint64, bool)
+	Float64() (float64, bool)
+}
+
+// A Slice wraps a slice or array.
+type Slice interface {
+	Type() reflect.Type
+	IsNil() bool // always false for arrays
+	Len() int
+	Index(i int) any // wrapped element
+}
+
+// A Map wraps a map.
+type Map interface {
+	Type() reflect.Type
+	IsNil() bool
+	Len() int
+	Range(fn func(k, v any) bool) // wrapped keys and values, stopping on the first false
+}
+
+// A Struct wraps a struct.
+type Struct interface {
+	Type() reflect.Type
+	Iter() *StructIter
+}
+
+// A Pointer wraps a pointer.
+type Pointer interface {
+	Type() reflect.Type
+	IsNil() bool
+	Elem() any // wrapped pointed value
+}
+
+type numberValue struct {
+	v reflect.Value
+}
+
+func (x numberValue) Type() reflect.Type {
+	return x.v.Type()
+}
+
+func (x numberValue) Value() any {
+	switch x.v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return x.v.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return x.v.Uint()
+	case reflect.Float32, reflect.Float64:
+		return x.v.Float()
+	}
+	return x.v.Complex()
+}
+
+func (x numberValue) Int64() (int64, bool) {
+	switch x.v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return x.v.Int(), true
+	}
+	return 0, false
+}
+
+func (x numberValue) Uint64() (uint64, bool) {
+	switch x.v.Kind() {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return x.v.Uint(), true
+	}
+	return 0, false
+}
+
+func (x numberValue) Float64() (float64, bool) {
+	switch x.v.Kind() {
+	case reflect.Float32, reflect.Float64:
+		return x.v.Float(), true
+	}
+	return 0, false
+}
+
+type sliceValue struct {
+	v reflect.Value
+}
+
+func (x sliceValue) Type() reflect.Type {
+	return x.v.Type()
+}
+
+func (x sliceValue) IsNil() bool {
+	return x.v.Kind() == reflect.Slice && x.v.IsNil()
+}
+
+func (x sliceValue) Len() int {
+	return x.v.Len()
+}
+
+func (x sliceValue) Index(i int) any {
+	return Of(x.v.Index(i))
+}
+
+type mapValue struct {
+	v reflect.Value
+}
+
+func (x mapValue) Type() reflect.Type {
+	return x.v.Type()
+}
+
+func (x mapValue) IsNil() bool {
+	return x.v.IsNil()
+}
+
+func (x mapValue) Len() int {
+	return x.v.Len()
+}
+
+func (x mapValue) Range(fn func(k, v any) bool) {
+	for iter := x.v.MapRange(); iter.Next(); {
+		if !fn(Of(iter.Key()), Of(iter.Value())) {
+			return
+		}
+	}
+}
+
+type structValue struct {
+	v reflect.Value
+}
+
+func (x structValue) Type() reflect.Type {
+	return x.v.Type()
+}
+
+func (x structValue) Iter() *StructIter {
+	return NewStructIter(x.v)
+}
+
+type pointerValue struct {
+	v reflect.Value
+}
+
+func (x pointerValue) Type() reflect.Type {
+	return x.v.Type()
+}
+
+func (x pointerValue) IsNil() bool {
+	return x.v.IsNil()
+}
+
+func (x pointerValue) Elem() any {
+	return Of(x.v.Elem())
+}
+
+// Get returns the value wrapped by Of, as type T, or false if it is not of exactly that type.
+// Plain bool and string values are returned as themselves. Values of the basic numeric types are read without boxing, so that numeric heavy traversals don't allocate per element.
+// Wrappers returned by SliceOf, MapOf and StructOf, and their elements, are unwrapped without reflect.
+func Get[T any](x any) (T, bool) {
+	if o, ok := x.(T); ok {
+		return o, true
+	}
+	if w, ok := x.(interface{ typed() T }); ok {
+		return w.typed(), true
+	}
+	var o T
+	var v reflect.Value
+	switch x := x.(type) {
+	case numberValue:
+		v = x.v
+	case sliceValue:
+		v = x.v
+	case mapValue:
+		v = x.v
+	case structValue:
+		v = x.v
+	case pointerValue:
+		v = x.v
+	default:
+		return o, false
+	}
+	if v.Type() != reflect.TypeOf((*T)(nil)).Elem() {
+		return o, false
+	}
+
+	switch p := any(&o).(type) {
+	case *int:
+		*p = int(v.Int())
+	case *int8:
+		*p = int8(v.Int())
+	case *int16:
+		*p = int16(v.Int())
+	case *int32:
+		*p = int32(v.Int())
+	case *int64:
+		*p = v.Int()
+	case *uint:
+		*p = uint(v.Uint())
+	case *uint8:
+		*p = uint8(v.Uint())
+	case *uint16:
+		*p = uint16(v.Uint())
+	case *uint32:
+		*p = uint32(v.Uint())
+	case *uint64:
+		*p = v.Uint()
+	case *uintptr:
+		*p = uintptr(v.Uint())
+	case *float32:
+		*p = float32(v.Float())
+	case *float64:
+		*p = v.Float()
+	default:
+		if !v.CanInterface() {
+			return o, false
+		}
+		o = v.Interface().(T)
+	}
+	return o, true
+}
+
+// A StructIter iterates over the convertible fields of a struct value: its visible exported fields, in declaration order, excluding those tagged `conv:"-"`.
+// Fields promoted through nil embedded pointers are skipped.
+//
+// Fields are named by their conv tag, as parsed by Tag, so that a single tag renames a field for all the formats that go through a StructIter:
+//
+//	`conv:"name"`        visit the field as "name"
+//	`conv:"name,opts"`   also mark it with comma separated options, as returned by Options, for the consumer to interpret, such as "omitempty"
+//	`conv:"-"`           skip the field
+//
+//	for iter := NewStructIter(v); iter.Next(); {
+//		name, field := iter.Name(), iter.Value()
+//	}
+type StructIter struct {
+	v   reflect.Value
+	f   *structFields
+	i   int
+	cur reflect.Value
+}
+
+// NewStructIter returns an iterator over the fields of struct value "v".
+func NewStructIter(v reflect.Value) *StructIter {
+	return &StructIter{
+		v: v,
+		f: fields(v.Type()),
+		i: -1,
+	}
+}
+
+// Fields returns the fields of struct type "t" that a StructIter visits.
+func Fields(t reflect.Type) []reflect.StructField {
+	return append([]reflect.StructField(nil), fields(t).fields...)
+}
+
+// Tag returns the name and options of struct field "f", from its conv tag, as a StructIter reports them.
+// The name defaults to the field name, and is "-" for skipped fields.
+func Tag(f reflect.StructField) (string, []string) {
+	tag, ok := f.Tag.Lookup("conv")
+	if !ok {
+		return f.Name, nil
+	}
+	parts := strings.Split(tag, ",")
+	if parts[0] == "" {
+		parts[0] = f.Name
+	}
+	return parts[0], parts[1:]
+}
+
+// structFields describes the visited fields of a struct type, along with their parsed tags.
+type structFields struct {
+	fields []reflect.StructField
+	names  []string
+	opts   [][]string
+}
+
+// fieldCache holds the visited fields of each struct type, as types are immutable.
+var fieldCache sync.Map
+
+// fields returns the cached, shared fields of "t". The result must not be modified.
+func fields(t reflect.Type) *structFields {
+	if o, ok := fieldCache.Load(t); ok {
+		return o.(*structFields)
+	}
+	o, _ := fieldCache.LoadOrStore(t, visibleFields(t))
+	return o.(*structFields)
+}
+
+func visibleFields(t reflect.Type) *structFields {
+	o := &structFields{}
+	for _, f := range reflect.VisibleFields(t) {
+		if !f.IsExported() || (f.Anonymous && f.Type.Kind() == reflect.Struct) {
+			continue
+		}
+		name, opts := Tag(f)
+		if name == "-" {
+			continue
+		}
+		o.fields = append(o.fields, f)
+		o.names = append(o.names, name)
+		o.opts = append(o.opts, opts)
+	}
+	return o
+}
+
+// Next advances to the next field, returning false when there are none left.
+func (x *StructIter) Next() bool {
+	for x.i++; x.i < len(x.f.fields); x.i++ {
+		v, err := x.v.FieldByIndexErr(x.f.fields[x.i].Index)
+		if err == nil {
+			x.cur = v
+			return true
+		}
+	}
+	return false
+}
+
+// Name returns the current field name, as given by its conv tag, or else the field name.
+func (x *StructIter) Name() string {
+	return x.f.names[x.i]
+}
+
+// Options returns the options of the current field, as given by its conv tag. The result must not be modified.
+func (x *StructIter) Options() []string {
+	return x.f.opts[x.i]
+}
+
+// HasOption returns true if the current field is marked with option "opt".
+func (x *StructIter) HasOption(opt string) bool {
+	for _, o := range x.f.opts[x.i] {
+		if o == opt {
+			return true
+		}
+	}
+	return false
+}
+
+// Field returns the current field description.
+func (x *StructIter) Field() reflect.StructField {
+	return x.f.fields[x.i]
+}
+
+// Value returns the wrapped current field value.
+func (x *StructIter) Value() any {
+	return Of(x.cur)
+}
+
+// Reflect returns the current field value.
+func (x *StructIter) Reflect() reflect.Value {
+	return x.cur
+}
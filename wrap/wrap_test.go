@@ -0,0 +1,187 @@
+package wrap
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestOf(t *testing.T) {
+	type inner struct {
+		B int
+	}
+	type outer struct {
+		A      string
+		Skip   int `conv:"-"`
+		hidden int
+		*inner
+		P *int
+		S []int
+		M map[string]uint8
+	}
+
+	v := outer{A: "a", S: []int{1, 2}, M: map[string]uint8{"x": 3}}
+	s, ok := Of(reflect.ValueOf(v)).(Struct)
+	if !ok {
+		t.Fatal("struct not wrapped")
+	}
+
+	var names []string
+	for iter := s.Iter(); iter.Next(); {
+		names = append(names, iter.Name())
+		switch iter.Name() {
+		case "A":
+			if iter.Value() != "a" {
+				t.Error("wrong string", iter.Value())
+			}
+		case "P":
+			if p := iter.Value().(Pointer); !p.IsNil() {
+				t.Error("pointer not nil")
+			}
+		case "S":
+			sl := iter.Value().(Slice)
+			if sl.IsNil() || sl.Len() != 2 || sl.Index(1).(Number).Value() != int64(2) {
+				t.Error("wrong slice")
+			}
+		case "M":
+			var n int
+			iter.Value().(Map).Range(func(k, v any) bool {
+				if k != "x" || v.(Number).Value() != uint64(3) {
+					t.Error("wrong entry", k, v)
+				}
+				n++
+				return true
+			})
+			if n != 1 {
+				t.Error("wrong map length", n)
+			}
+		}
+	}
+	// B is promoted through a nil pointer
+	if !reflect.DeepEqual(names, []string{"A", "P", "S", "M"}) {
+		t.Error("wrong fields", names)
+	}
+
+	v.inner = &inner{B: 4}
+	names = names[:0]
+	for iter := NewStructIter(reflect.ValueOf(v)); iter.Next(); {
+		names = append(names, iter.Name())
+	}
+	if !reflect.DeepEqual(names, []string{"A", "B", "P", "S", "M"}) {
+		t.Error("wrong fields", names)
+	}
+
+	var i any
+	if Of(reflect.ValueOf(&i).Elem()) != nil {
+		t.Error("nil interface wrapped")
+	}
+	i = 1.5
+	if n := Of(reflect.ValueOf(&i).Elem()).(Number); n.Value() != 1.5 {
+		t.Error("wrong interface value", n.Value())
+	}
+}
+
+func TestFieldsCache(t *testing.T) {
+	type s struct {
+		A int
+		B string `conv:"-"`
+		C bool
+	}
+	typ := reflect.TypeOf(s{})
+
+	a := Fields(typ)
+	if len(a) != 2 || a[0].Name != "A" || a[1].Name != "C" {
+		t.Fatal("wrong fields", a)
+	}
+	// callers own the returned slice
+	a[0].Name = "X"
+	if b := Fields(typ); b[0].Name != "A" {
+		t.Error("cached fields modified", b)
+	}
+
+	v := reflect.ValueOf(s{A: 1})
+	if n := testing.AllocsPerRun(100, func() {
+		for iter := NewStructIter(v); iter.Next(); {
+		}
+	}); n > 1 {
+		t.Error("iteration should only allocate the iterator", n)
+	}
+}
+
+func TestTyped(t *testing.T) {
+	type id int32
+	n := Of(reflect.ValueOf(int16(-3))).(Number)
+	if o, ok := n.Int64(); o != -3 || !ok {
+		t.Error("wrong Int64", o, ok)
+	}
+	if _, ok := n.Uint64(); ok {
+		t.Error("Uint64 of signed number")
+	}
+	if o, ok := Of(reflect.ValueOf(uint8(4))).(Number).Uint64(); o != 4 || !ok {
+		t.Error("wrong Uint64", o, ok)
+	}
+	if o, ok := Of(reflect.ValueOf(float32(1.5))).(Number).Float64(); o != 1.5 || !ok {
+		t.Error("wrong Float64", o, ok)
+	}
+
+	if o, ok := Get[int16](n); o != -3 || !ok {
+		t.Error("wrong Get", o, ok)
+	}
+	if _, ok := Get[int64](n); ok {
+		t.Error("Get should require the exact type")
+	}
+	if o, ok := Get[id](Of(reflect.ValueOf(id(5)))); o != 5 || !ok {
+		t.Error("wrong named Get", o, ok)
+	}
+	if o, ok := Get[string](Of(reflect.ValueOf("s"))); o != "s" || !ok {
+		t.Error("wrong string Get", o, ok)
+	}
+	if o, ok := Get[[]int](Of(reflect.ValueOf([]int{1}))); len(o) != 1 || !ok {
+		t.Error("wrong slice Get", o, ok)
+	}
+	x := 1
+	if o, ok := Get[*int](Of(reflect.ValueOf(&x))); o != &x || !ok {
+		t.Error("wrong pointer Get", o, ok)
+	}
+
+	f := Of(reflect.ValueOf(1e10))
+	if allocs := testing.AllocsPerRun(100, func() {
+		if _, ok := Get[float64](f); !ok {
+			t.Error("wrong float")
+		}
+		if _, ok := f.(Number).Float64(); !ok {
+			t.Error("wrong float")
+		}
+	}); allocs != 0 {
+		t.Error("typed access allocates", allocs)
+	}
+}
+
+func TestStructIterTags(t *testing.T) {
+	type s struct {
+		A int    `conv:"a,omitempty"`
+		B string `conv:",flag"`
+		C bool
+		D int `conv:"-"`
+	}
+
+	var names []string
+	var opts [][]string
+	for iter := NewStructIter(reflect.ValueOf(s{})); iter.Next(); {
+		names = append(names, iter.Name())
+		opts = append(opts, iter.Options())
+		if iter.HasOption("omitempty") != (iter.Name() == "a") {
+			t.Error("wrong omitempty option", iter.Name())
+		}
+	}
+	if !reflect.DeepEqual(names, []string{"a", "B", "C"}) {
+		t.Error("wrong names", names)
+	}
+	if !reflect.DeepEqual(opts, [][]string{{"omitempty"}, {"flag"}, nil}) {
+		t.Error("wrong options", opts)
+	}
+
+	f, _ := reflect.TypeOf(s{}).FieldByName("D")
+	if name, _ := Tag(f); name != "-" {
+		t.Error("wrong skipped name", name)
+	}
+}
@@ -0,0 +1,411 @@
+package conv
+
+import (
+	"encoding/xml"
+	"fmt"
+	. "reflect"
+	"strings"
+	"sync"
+)
+
+// An XMLNode is a generic XML element, usable with encoding/xml as both source and target.
+type XMLNode struct {
+	Name     xml.Name
+	Attrs    []xml.Attr
+	Children []XMLNode
+	Text     string // character data, concatenated
+}
+
+// Child returns the first child element with local name "name".
+func (x *XMLNode) Child(name string) (*XMLNode, bool) {
+	for i := range x.Children {
+		if x.Children[i].Name.Local == name {
+			return &x.Children[i], true
+		}
+	}
+	return nil, false
+}
+
+// Attr returns the value of the attribute with local name "name".
+func (x *XMLNode) Attr(name string) (string, bool) {
+	for _, a := range x.Attrs {
+		if a.Name.Local == name {
+			return a.Value, true
+		}
+	}
+	return "", false
+}
+
+func (x XMLNode) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	start.Name, start.Attr = x.Name, x.Attrs
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+	if x.Text != "" {
+		if err := e.EncodeToken(xml.CharData(x.Text)); err != nil {
+			return err
+		}
+	}
+	for _, c := range x.Children {
+		if err := e.Encode(c); err != nil {
+			return err
+		}
+	}
+	return e.EncodeToken(start.End())
+}
+
+func (x *XMLNode) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	x.Name, x.Attrs = start.Name, start.Attr
+	var text strings.Builder
+	for {
+		tok, err := d.Token()
+		if err != nil {
+			return err
+		}
+		switch tok := tok.(type) {
+		case xml.StartElement:
+			var c XMLNode
+			if err := c.UnmarshalXML(d, tok); err != nil {
+				return err
+			}
+			x.Children = append(x.Children, c)
+		case xml.CharData:
+			text.Write(tok)
+		case xml.EndElement:
+			x.Text = strings.TrimSpace(text.String())
+			return nil
+		}
+	}
+}
+
+// XML is a set of Builders converting between structs and XMLNodes.
+// Attribute values and character data are converted as with CSV, through Format and Parse.
+//
+// Fields are configured using the "xml" struct tag, like in encoding/xml:
+//
+//	`xml:"name"`            child element, named after the field by default
+//	`xml:"name,attr"`       attribute
+//	`xml:",chardata"`       character data
+//	`xml:",omitempty"`      don't encode zero values
+//	`xml:"-"`               ignore the field
+//
+// A field named XMLName, of type xml.Name, holds the element name; its tag gives the default. Otherwise, elements are named after their type.
+// Fields whose types are covered by Format (or Parse) become elements holding character data. Slices of such types, and of structs, are repeated elements.
+// Other struct fields, or pointers to them, are nested elements. Nil pointers are not encoded, and are only allocated on decode if their element is present.
+// Names are matched by their local part. Unknown elements and attributes are ignored. Paths ("a>b") and inner XML are not supported.
+type XML struct {
+	Format *Conversion[string]
+	Parse  *Inversion[string]
+
+	plans map[Type]*xmlPlan
+	mux   sync.Mutex
+}
+
+type xmlKind uint8
+
+const (
+	xmlAttr xmlKind = iota
+	xmlCharData
+	xmlValue
+	xmlValues
+	xmlNested
+	xmlNesteds
+)
+
+type xmlPlan struct {
+	name     xml.Name
+	nameIdx  []int // index of the XMLName field, if any
+	fields   []xmlField
+	children map[string]int // field positions by element name
+	attrs    map[string]int // field positions by attribute name
+}
+
+type xmlField struct {
+	index     []int
+	name      xml.Name
+	kind      xmlKind
+	ptr       bool
+	typ       Type // the value type, slice element type or nested struct type, after pointer indirection
+	omitEmpty bool
+}
+
+var typeXMLName = TypeEval[xml.Name]()
+
+// Converter is a Builder of XMLNode Converters for struct types.
+func (x *XML) Converter(t Type) (Converter[XMLNode], bool) {
+	if t.Kind() != Struct {
+		return nil, false
+	}
+	return func(v Value) (XMLNode, error) {
+		return x.encode(v)
+	}, true
+}
+
+// Inverter is a Builder of XMLNode Inverters for struct types.
+func (x *XML) Inverter(t Type) (Inverter[XMLNode], bool) {
+	if t.Kind() != Struct {
+		return nil, false
+	}
+	return func(n XMLNode) (Value, error) {
+		o := New(t).Elem()
+		if err := x.decode(&n, o); err != nil {
+			return Value{}, err
+		}
+		return o, nil
+	}, true
+}
+
+// plan returns the cached field plan of struct type "t".
+// Nested types are only planned when encountered, so recursive types don't need special handling.
+func (x *XML) plan(t Type) *xmlPlan {
+	x.mux.Lock()
+	defer x.mux.Unlock()
+
+	if o, ok := x.plans[t]; ok {
+		return o
+	}
+
+	covers := func(t Type) bool {
+		_, ok := (*Library[Converter[string]])(x.Format).Lookup(t)
+		return ok
+	}
+	if x.Format == nil {
+		covers = func(t Type) bool {
+			_, ok := (*Library[Inverter[string]])(x.Parse).Lookup(t)
+			return ok
+		}
+	}
+
+	o := &xmlPlan{
+		name:     xml.Name{Local: t.Name()},
+		children: make(map[string]int),
+		attrs:    make(map[string]int),
+	}
+	for _, f := range taggedFields(t, "xml") {
+		if f.Name == "XMLName" && f.Type == typeXMLName {
+			o.nameIdx = f.Index
+			if name, _ := parseTag(f.Tag.Get("xml")); name != "" {
+				o.name = xmlName(name)
+			}
+			continue
+		}
+
+		xf := xmlField{
+			index:     f.Index,
+			name:      xmlName(f.name),
+			typ:       f.Type,
+			omitEmpty: f.flag("omitempty"),
+		}
+		if xf.typ.Kind() == Pointer {
+			xf.ptr = true
+			xf.typ = xf.typ.Elem()
+		}
+
+		switch {
+		case f.flag("attr"):
+			xf.kind = xmlAttr
+		case f.flag("chardata"):
+			xf.kind = xmlCharData
+		case covers(xf.typ):
+			xf.kind = xmlValue
+		case xf.typ.Kind() == Slice && !xf.ptr && covers(xf.typ.Elem()):
+			xf.kind = xmlValues
+			xf.typ = xf.typ.Elem()
+		case xf.typ.Kind() == Struct:
+			xf.kind = xmlNested
+		case xf.typ.Kind() == Slice && !xf.ptr && xf.typ.Elem().Kind() == Struct:
+			xf.kind = xmlNesteds
+			xf.typ = xf.typ.Elem()
+		default:
+			continue
+		}
+
+		switch xf.kind {
+		case xmlAttr:
+			o.attrs[xf.name.Local] = len(o.fields)
+		case xmlCharData:
+		default:
+			o.children[xf.name.Local] = len(o.fields)
+		}
+		o.fields = append(o.fields, xf)
+	}
+
+	if x.plans == nil {
+		x.plans = make(map[Type]*xmlPlan)
+	}
+	x.plans[t] = o
+	return o
+}
+
+// xmlName splits a tag name of the form "namespace local".
+func xmlName(s string) xml.Name {
+	if i := strings.LastIndexByte(s, ' '); i >= 0 {
+		return xml.Name{Space: s[:i], Local: s[i+1:]}
+	}
+	return xml.Name{Local: s}
+}
+
+func (x *XML) encode(v Value) (XMLNode, error) {
+	p := x.plan(v.Type())
+	o := XMLNode{Name: p.name}
+	if p.nameIdx != nil {
+		if name := v.FieldByIndex(p.nameIdx).Interface().(xml.Name); name.Local != "" {
+			o.Name = name
+		}
+	}
+
+	lib := (*Library[Converter[string]])(x.Format)
+	format := func(v Value) (string, error) {
+		return lib.Get(v.Type())(v)
+	}
+	for _, f := range p.fields {
+		fv := v.FieldByIndex(f.index)
+		if f.ptr {
+			if fv.IsNil() {
+				continue
+			}
+			fv = fv.Elem()
+		}
+		if f.omitEmpty && fv.IsZero() {
+			continue
+		}
+
+		switch f.kind {
+		case xmlAttr, xmlCharData, xmlValue:
+			s, err := format(fv)
+			if err != nil {
+				return o, fmt.Errorf("field %s: %w", f.name.Local, err)
+			}
+			switch f.kind {
+			case xmlAttr:
+				o.Attrs = append(o.Attrs, xml.Attr{Name: f.name, Value: s})
+			case xmlCharData:
+				o.Text += s
+			default:
+				o.Children = append(o.Children, XMLNode{Name: f.name, Text: s})
+			}
+		case xmlValues:
+			for i, n := 0, fv.Len(); i < n; i++ {
+				s, err := format(fv.Index(i))
+				if err != nil {
+					return o, fmt.Errorf("field %s: %w", f.name.Local, err)
+				}
+				o.Children = append(o.Children, XMLNode{Name: f.name, Text: s})
+			}
+		case xmlNested:
+			c, err := x.encode(fv)
+			if err != nil {
+				return o, fmt.Errorf("field %s: %w", f.name.Local, err)
+			}
+			c.Name = f.name
+			o.Children = append(o.Children, c)
+		case xmlNesteds:
+			for i, n := 0, fv.Len(); i < n; i++ {
+				c, err := x.encode(fv.Index(i))
+				if err != nil {
+					return o, fmt.Errorf("field %s: %w", f.name.Local, err)
+				}
+				c.Name = f.name
+				o.Children = append(o.Children, c)
+			}
+		}
+	}
+	return o, nil
+}
+
+func (x *XML) decode(n *XMLNode, v Value) error {
+	p := x.plan(v.Type())
+	if p.nameIdx != nil {
+		v.FieldByIndex(p.nameIdx).Set(ValueOf(n.Name))
+	}
+
+	lib := (*Library[Inverter[string]])(x.Parse)
+	parse := func(s string, f *xmlField) (Value, error) {
+		o, err := lib.Get(f.typ)(s)
+		if err != nil {
+			return Value{}, fmt.Errorf("field %s: %w", f.name.Local, err)
+		}
+		return o, nil
+	}
+	set := func(f *xmlField, o Value) {
+		fv := v.FieldByIndex(f.index)
+		if f.ptr {
+			p := New(f.typ)
+			p.Elem().Set(o)
+			o = p
+		}
+		fv.Set(o)
+	}
+
+	for _, a := range n.Attrs {
+		i, ok := p.attrs[a.Name.Local]
+		if !ok {
+			continue
+		}
+		f := &p.fields[i]
+		o, err := parse(a.Value, f)
+		if err != nil {
+			return err
+		}
+		set(f, o)
+	}
+
+	for i := range p.fields {
+		if f := &p.fields[i]; f.kind == xmlCharData {
+			o, err := parse(n.Text, f)
+			if err != nil {
+				return err
+			}
+			set(f, o)
+		}
+	}
+
+	// repeated elements replace existing values
+	reset := make(map[int]bool)
+	for ci := range n.Children {
+		c := &n.Children[ci]
+		i, ok := p.children[c.Name.Local]
+		if !ok {
+			continue
+		}
+		f := &p.fields[i]
+
+		switch f.kind {
+		case xmlValue:
+			o, err := parse(c.Text, f)
+			if err != nil {
+				return err
+			}
+			set(f, o)
+		case xmlNested:
+			o := New(f.typ).Elem()
+			if err := x.decode(c, o); err != nil {
+				return fmt.Errorf("field %s: %w", f.name.Local, err)
+			}
+			set(f, o)
+		case xmlValues, xmlNesteds:
+			var (
+				o   Value
+				err error
+			)
+			if f.kind == xmlValues {
+				o, err = parse(c.Text, f)
+			} else {
+				o = New(f.typ).Elem()
+				if err = x.decode(c, o); err != nil {
+					err = fmt.Errorf("field %s: %w", f.name.Local, err)
+				}
+			}
+			if err != nil {
+				return err
+			}
+			fv := v.FieldByIndex(f.index)
+			if !reset[i] {
+				fv.SetZero()
+				reset[i] = true
+			}
+			fv.Set(Append(fv, o))
+		}
+	}
+	return nil
+}
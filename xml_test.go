@@ -0,0 +1,85 @@
+package conv
+
+import (
+	"encoding/xml"
+	. "reflect"
+	"testing"
+	"time"
+)
+
+func TestXML(t *testing.T) {
+	type item struct {
+		SKU   string  `xml:"sku,attr"`
+		Price float64 `xml:"price"`
+		Note  string  `xml:",chardata"`
+	}
+	type order struct {
+		XMLName xml.Name  `xml:"order"`
+		ID      int       `xml:"id,attr"`
+		Placed  time.Time `xml:"placed"`
+		Items   []item    `xml:"item"`
+		Tags    []string  `xml:"tag"`
+		Gift    *item     `xml:"gift"`
+		Memo    string    `xml:"memo,omitempty"`
+		Skip    int       `xml:"-"`
+	}
+
+	x := &XML{
+		Format: NewConversion(Scheme[Converter[string]]{TextConverter, StrconvConverter}.Build),
+		Parse:  NewInversion(Scheme[Inverter[string]]{TextInverter, StrconvInverter}.Build),
+	}
+
+	src := `<order id="7">
+		<placed>2024-05-06T07:08:09Z</placed>
+		<item sku="a1"><price>2.5</price>fragile</item>
+		<item sku="b2"><price>10</price></item>
+		<tag>x</tag><tag>y</tag>
+		<unknown>ignored</unknown>
+	</order>`
+	var n XMLNode
+	if err := xml.Unmarshal([]byte(src), &n); err != nil {
+		t.Fatal(err)
+	}
+
+	inv := NewInversion(x.Inverter)
+	o, err := As[order](inv, n)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := order{
+		XMLName: xml.Name{Local: "order"},
+		ID:      7,
+		Placed:  time.Date(2024, 5, 6, 7, 8, 9, 0, time.UTC),
+		Items:   []item{{"a1", 2.5, "fragile"}, {"b2", 10, ""}},
+		Tags:    []string{"x", "y"},
+	}
+	if !DeepEqual(o, exp) {
+		t.Error("wrong order", o)
+	}
+
+	o.Gift = &item{SKU: "g"}
+	c := NewConversion(x.Converter)
+	back, err := c.Call(o)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := xml.Marshal(back)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expXML := `<order id="7"><placed>2024-05-06T07:08:09Z</placed><item sku="a1">fragile<price>2.5</price></item><item sku="b2"><price>10</price></item><tag>x</tag><tag>y</tag><gift sku="g"><price>0</price></gift></order>`
+	if string(b) != expXML {
+		t.Error("wrong xml", string(b))
+	}
+
+	if gift, ok := back.Child("gift"); !ok {
+		t.Error("missing gift")
+	} else if sku, _ := gift.Attr("sku"); sku != "g" {
+		t.Error("wrong gift", sku)
+	}
+
+	n.Attrs[0].Value = "x"
+	if _, err := As[order](inv, n); err == nil {
+		t.Error("expected parse error")
+	}
+}